@@ -12,26 +12,34 @@ import (
 	"github.com/gardenbed/basil-cli/internal/ui"
 	"github.com/gardenbed/basil-cli/metadata"
 
+	cachecleancmd "github.com/gardenbed/basil-cli/internal/command/cache/clean"
 	configcmd "github.com/gardenbed/basil-cli/internal/command/config"
 	createmonorepocmd "github.com/gardenbed/basil-cli/internal/command/monorepo/create"
 	buildcmd "github.com/gardenbed/basil-cli/internal/command/project/build"
 	createprojectcmd "github.com/gardenbed/basil-cli/internal/command/project/create"
+	gatecmd "github.com/gardenbed/basil-cli/internal/command/project/gate"
 	releasecmd "github.com/gardenbed/basil-cli/internal/command/project/release"
 	semvercmd "github.com/gardenbed/basil-cli/internal/command/project/semver"
 	updatecmd "github.com/gardenbed/basil-cli/internal/command/update"
+	verifycmd "github.com/gardenbed/basil-cli/internal/command/verify"
 )
 
 func createCLI(ui ui.UI, config config.Config, spec spec.Spec) *cli.CLI {
 	c := cli.NewCLI("basil", metadata.String())
 	c.Args = os.Args[1:]
 	c.Commands = map[string]cli.CommandFactory{
-		"update":          updatecmd.NewFactory(ui, config),
-		"config":          configcmd.NewFactory(ui, config),
-		"monorepo create": createmonorepocmd.NewFactory(ui, config),
-		"project create":  createprojectcmd.NewFactory(ui, config),
-		"project semver":  semvercmd.NewFactory(ui),
-		"project build":   buildcmd.NewFactory(ui, spec),
-		"project release": releasecmd.NewFactory(ui, config, spec),
+		"update":                 updatecmd.NewFactory(ui, config),
+		"verify":                 verifycmd.NewFactory(ui),
+		"config":                 configcmd.NewFactory(ui, config),
+		"cache clean":            cachecleancmd.NewFactory(ui),
+		"monorepo create":        createmonorepocmd.NewFactory(ui, config),
+		"project create":         createprojectcmd.NewFactory(ui, config),
+		"project semver":         semvercmd.NewFactory(ui, spec),
+		"project gate":           gatecmd.NewFactory(ui, spec),
+		"project build":          buildcmd.NewFactory(ui, spec),
+		"project release":        releasecmd.NewFactory(ui, config, spec),
+		"project release finish": releasecmd.NewFinishFactory(ui, config, spec),
+		"project release status": releasecmd.NewStatusFactory(ui, config, spec),
 	}
 
 	return c
@@ -25,7 +25,7 @@ func createCLI(ui ui.UI, config config.Config, spec spec.Spec) *cli.CLI {
 	c.Commands = map[string]cli.CommandFactory{
 		"monorepo create": createmonorepocmd.NewFactory(ui, config),
 		"project create":  createprojectcmd.NewFactory(ui, config),
-		"project semver":  semvercmd.NewFactory(ui),
+		"project semver":  semvercmd.NewFactory(ui, spec),
 		"project build":   buildcmd.NewFactory(ui, spec),
 		"code mock":       mockcmd.NewFactory(ui),
 		"code build":      buildcmd.NewFactory(ui),
@@ -11,6 +11,7 @@ require (
 	github.com/mitchellh/cli v1.1.5
 	github.com/moorara/promptui v0.10.0
 	github.com/stretchr/testify v1.11.1
+	golang.org/x/mod v0.22.0
 	golang.org/x/sync v0.18.0
 	gopkg.in/yaml.v3 v3.0.1
 )
@@ -53,7 +54,6 @@ require (
 	github.com/spf13/cast v1.3.1 // indirect
 	github.com/xanzy/ssh-agent v0.3.3 // indirect
 	golang.org/x/crypto v0.37.0 // indirect
-	golang.org/x/mod v0.22.0 // indirect
 	golang.org/x/net v0.39.0 // indirect
 	golang.org/x/sys v0.32.0 // indirect
 	golang.org/x/tools v0.28.0 // indirect
@@ -3,6 +3,7 @@ package archive
 
 import (
 	"archive/tar"
+	"archive/zip"
 	"compress/gzip"
 	"fmt"
 	"io"
@@ -17,6 +18,14 @@ import (
 // The selector function can also maps the give path to a new path when extracting.
 type Selector func(string) (string, bool)
 
+// File is a file for adding to an archive.
+type File struct {
+	// Path is the path to the file on disk.
+	Path string
+	// Name is the name of the file in the archive.
+	Name string
+}
+
 // TarArchive facilitates working with tar.gz files.
 type TarArchive struct {
 	ui ui.UI
@@ -82,3 +91,126 @@ func (a *TarArchive) Extract(dest string, r io.Reader, f Selector) error {
 
 	return nil
 }
+
+// Create creates a tar.gz archive from a list of files and writes it to a writer.
+func (a *TarArchive) Create(w io.Writer, files []File) error {
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for _, file := range files {
+		if err := a.addFile(tarWriter, file); err != nil {
+			return err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return fmt.Errorf("error on closing tar writer: %s", err)
+	}
+
+	if err := gzipWriter.Close(); err != nil {
+		return fmt.Errorf("error on closing gzip writer: %s", err)
+	}
+
+	return nil
+}
+
+func (a *TarArchive) addFile(tarWriter *tar.Writer, file File) error {
+	f, err := os.Open(file.Path)
+	if err != nil {
+		return fmt.Errorf("error on opening file: %s", err)
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("error on getting file info: %s", err)
+	}
+
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return fmt.Errorf("error on creating tar header: %s", err)
+	}
+
+	header.Name = filepath.ToSlash(file.Name)
+
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return fmt.Errorf("error on writing tar header: %s", err)
+	}
+
+	if _, err := io.Copy(tarWriter, f); err != nil {
+		return fmt.Errorf("error on copying to tar writer: %s", err)
+	}
+
+	a.ui.Debugf(ui.Cyan, "  File archived: %s", file.Name)
+
+	return nil
+}
+
+// ZipArchive facilitates working with zip files.
+type ZipArchive struct {
+	ui ui.UI
+}
+
+// NewZipArchive creates a new instance of ZipArchive.
+func NewZipArchive(ui ui.UI) *ZipArchive {
+	return &ZipArchive{
+		ui: ui,
+	}
+}
+
+// Create creates a zip archive from a list of files and writes it to a writer.
+func (a *ZipArchive) Create(w io.Writer, files []File) error {
+	zipWriter := zip.NewWriter(w)
+
+	for _, file := range files {
+		if err := a.addFile(zipWriter, file); err != nil {
+			return err
+		}
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return fmt.Errorf("error on closing zip writer: %s", err)
+	}
+
+	return nil
+}
+
+func (a *ZipArchive) addFile(zipWriter *zip.Writer, file File) error {
+	f, err := os.Open(file.Path)
+	if err != nil {
+		return fmt.Errorf("error on opening file: %s", err)
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("error on getting file info: %s", err)
+	}
+
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return fmt.Errorf("error on creating zip header: %s", err)
+	}
+
+	header.Name = filepath.ToSlash(file.Name)
+	header.Method = zip.Deflate
+
+	writer, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		return fmt.Errorf("error on writing zip header: %s", err)
+	}
+
+	if _, err := io.Copy(writer, f); err != nil {
+		return fmt.Errorf("error on copying to zip writer: %s", err)
+	}
+
+	a.ui.Debugf(ui.Cyan, "  File archived: %s", file.Name)
+
+	return nil
+}
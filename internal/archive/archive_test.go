@@ -1,7 +1,11 @@
 package archive
 
 import (
+	"archive/zip"
+	"bytes"
+	"io"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/gardenbed/charm/ui"
@@ -77,3 +81,130 @@ func TestTarArchive_Extract(t *testing.T) {
 		})
 	}
 }
+
+func TestTarArchive_Create(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "app-linux-amd64"), []byte("binary"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# App"), 0644))
+
+	tests := []struct {
+		name          string
+		files         []File
+		expectedError string
+		expectedFiles map[string]string
+	}{
+		{
+			name: "FileNotFound",
+			files: []File{
+				{Path: filepath.Join(dir, "missing"), Name: "missing"},
+			},
+			expectedError: "error on opening file",
+		},
+		{
+			name: "Success",
+			files: []File{
+				{Path: filepath.Join(dir, "app-linux-amd64"), Name: "app"},
+				{Path: filepath.Join(dir, "README.md"), Name: "README.md"},
+			},
+			expectedFiles: map[string]string{
+				"app":       "binary",
+				"README.md": "# App",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			arch := NewTarArchive(ui.NewNop())
+
+			buf := new(bytes.Buffer)
+			err := arch.Create(buf, tc.files)
+
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			dest := t.TempDir()
+			err = arch.Extract(dest, buf, func(path string) (string, bool) {
+				return path, true
+			})
+			assert.NoError(t, err)
+
+			for name, content := range tc.expectedFiles {
+				b, err := os.ReadFile(filepath.Join(dest, name))
+				assert.NoError(t, err)
+				assert.Equal(t, content, string(b))
+			}
+		})
+	}
+}
+
+func TestNewZipArchive(t *testing.T) {
+	arch := NewZipArchive(ui.NewNop())
+
+	assert.NotNil(t, arch)
+}
+
+func TestZipArchive_Create(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "app-windows-amd64"), []byte("binary"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "LICENSE"), []byte("MIT"), 0644))
+
+	tests := []struct {
+		name          string
+		files         []File
+		expectedError string
+		expectedFiles map[string]string
+	}{
+		{
+			name: "FileNotFound",
+			files: []File{
+				{Path: filepath.Join(dir, "missing"), Name: "missing"},
+			},
+			expectedError: "error on opening file",
+		},
+		{
+			name: "Success",
+			files: []File{
+				{Path: filepath.Join(dir, "app-windows-amd64"), Name: "app.exe"},
+				{Path: filepath.Join(dir, "LICENSE"), Name: "LICENSE"},
+			},
+			expectedFiles: map[string]string{
+				"app.exe": "binary",
+				"LICENSE": "MIT",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			arch := NewZipArchive(ui.NewNop())
+
+			buf := new(bytes.Buffer)
+			err := arch.Create(buf, tc.files)
+
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			assert.NoError(t, err)
+			assert.Len(t, r.File, len(tc.expectedFiles))
+
+			for _, f := range r.File {
+				rc, err := f.Open()
+				assert.NoError(t, err)
+				b, err := io.ReadAll(rc)
+				assert.NoError(t, err)
+				assert.NoError(t, rc.Close())
+				assert.Equal(t, tc.expectedFiles[f.Name], string(b))
+			}
+		})
+	}
+}
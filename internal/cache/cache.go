@@ -0,0 +1,233 @@
+// Package cache provides a local content-addressed cache for build artifacts.
+// Cache entries are stored by keys computed from the inputs of a build, so a matching entry can replace a build.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// DirEnv is the environment variable for overriding the cache directory.
+	DirEnv = "BASIL_CACHE_DIR"
+
+	defaultDir = "basil/build"
+)
+
+// Dir returns the cache directory.
+// By default, it is basil/build in the user cache directory (e.g. ~/.cache/basil/build).
+func Dir() (string, error) {
+	if dir := os.Getenv(DirEnv); dir != "" {
+		return dir, nil
+	}
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, defaultDir), nil
+}
+
+// Key computes a cache key from a list of inputs.
+func Key(inputs ...string) string {
+	h := sha256.New()
+	for _, in := range inputs {
+		_, _ = io.WriteString(h, in)
+		_, _ = h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// HashSources computes a hash of all source files in a directory tree.
+// Every file that can affect a build is included (Go, assembly, C, and embedded files), except test files.
+// Directories ignored by the go command (testdata and names starting with . or _) and the excluded paths (e.g. build outputs) are skipped.
+func HashSources(root string, exclude ...string) (string, error) {
+	excluded := make(map[string]bool, len(exclude))
+	for _, path := range exclude {
+		excluded[filepath.Clean(path)] = true
+	}
+
+	h := sha256.New()
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		name := d.Name()
+
+		if d.IsDir() {
+			if path != root && (name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || excluded[filepath.Clean(path)]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !d.Type().IsRegular() || strings.HasSuffix(name, "_test.go") || excluded[filepath.Clean(path)] {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+
+		// Files are walked in lexical order, so the hash is deterministic
+		_, _ = io.WriteString(h, filepath.ToSlash(rel))
+		_, _ = h.Write([]byte{0})
+		if _, err := io.Copy(h, f); err != nil {
+			return err
+		}
+		_, _ = h.Write([]byte{0})
+
+		return nil
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Cache is a local content-addressed cache for files.
+type Cache struct {
+	dir string
+}
+
+// New creates a new cache in a directory.
+func New(dir string) *Cache {
+	return &Cache{
+		dir: dir,
+	}
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key[:2], key)
+}
+
+// Get copies the cache entry for a key to a destination file.
+// It returns false if there is no cache entry for the key.
+func (c *Cache) Get(key, dst string) (bool, error) {
+	src, err := os.Open(c.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+
+	if err := copyFile(dst, src); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Put stores a file as the cache entry for a key.
+// The entry is written to a temporary file first, so concurrent readers never see a partial entry.
+func (c *Cache) Put(key, src string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(tmp, in); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// Clean removes all cache entries.
+// It returns the number and the total size of the removed entries.
+func (c *Cache) Clean() (int, int64, error) {
+	var count int
+	var size int64
+
+	err := filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			count++
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, 0, nil
+	} else if err != nil {
+		return 0, 0, err
+	}
+
+	if err := os.RemoveAll(c.dir); err != nil {
+		return 0, 0, err
+	}
+
+	return count, size, nil
+}
+
+// copyFile copies a reader to an executable file.
+func copyFile(dst string, src io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, src); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
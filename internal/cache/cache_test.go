@@ -0,0 +1,154 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestDir(t *testing.T) {
+	t.Run("FromEnv", func(t *testing.T) {
+		t.Setenv(DirEnv, "/tmp/basil-cache")
+
+		dir, err := Dir()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "/tmp/basil-cache", dir)
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		t.Setenv(DirEnv, "")
+		t.Setenv("XDG_CACHE_HOME", "/tmp/xdg-cache")
+		t.Setenv("HOME", "/tmp/home")
+
+		dir, err := Dir()
+
+		assert.NoError(t, err)
+		assert.Contains(t, dir, filepath.Join("basil", "build"))
+	})
+}
+
+func TestKey(t *testing.T) {
+	assert.Len(t, Key("foo"), 64)
+	assert.Equal(t, Key("foo", "bar"), Key("foo", "bar"))
+	assert.NotEqual(t, Key("foo", "bar"), Key("foo", "baz"))
+	assert.NotEqual(t, Key("foo", "bar"), Key("foob", "ar"))
+}
+
+func TestHashSources(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "go.mod"), "module example.com/app\n")
+	writeFile(t, filepath.Join(root, "main.go"), "package main\n")
+	writeFile(t, filepath.Join(root, "internal", "foo", "foo.go"), "package foo\n")
+
+	hash, err := HashSources(root)
+	assert.NoError(t, err)
+	assert.Len(t, hash, 64)
+
+	t.Run("IgnoredFilesDoNotChangeHash", func(t *testing.T) {
+		writeFile(t, filepath.Join(root, "main_test.go"), "package main\n")
+		writeFile(t, filepath.Join(root, "testdata", "data.go"), "package data\n")
+		writeFile(t, filepath.Join(root, ".git", "hooks.go"), "package hooks\n")
+		writeFile(t, filepath.Join(root, "_tools", "tools.go"), "package tools\n")
+		writeFile(t, filepath.Join(root, "bin", "app"), "binary")
+
+		h, err := HashSources(root, filepath.Join(root, "bin"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, hash, h)
+	})
+
+	t.Run("SourceChangesHash", func(t *testing.T) {
+		writeFile(t, filepath.Join(root, "internal", "foo", "foo.go"), "package foo\n\nconst Bar = 1\n")
+
+		h, err := HashSources(root)
+
+		assert.NoError(t, err)
+		assert.NotEqual(t, hash, h)
+	})
+
+	t.Run("EmbeddedFileChangesHash", func(t *testing.T) {
+		writeFile(t, filepath.Join(root, "static", "index.html"), "<html></html>\n")
+
+		h, err := HashSources(root)
+
+		assert.NoError(t, err)
+		assert.NotEqual(t, hash, h)
+	})
+
+	t.Run("AssemblyFileChangesHash", func(t *testing.T) {
+		writeFile(t, filepath.Join(root, "internal", "foo", "foo_amd64.s"), "TEXT ·add(SB),$0\n")
+
+		h, err := HashSources(root)
+
+		assert.NoError(t, err)
+		assert.NotEqual(t, hash, h)
+	})
+
+	t.Run("NoDirectory", func(t *testing.T) {
+		_, err := HashSources(filepath.Join(root, "missing"))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestCache(t *testing.T) {
+	dir := t.TempDir()
+	c := New(filepath.Join(dir, "cache"))
+	key := Key("app", "linux", "amd64")
+
+	src := filepath.Join(dir, "app")
+	writeFile(t, src, "binary")
+
+	t.Run("GetMiss", func(t *testing.T) {
+		ok, err := c.Get(key, filepath.Join(dir, "bin", "app"))
+
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.NoFileExists(t, filepath.Join(dir, "bin", "app"))
+	})
+
+	t.Run("PutFails", func(t *testing.T) {
+		err := c.Put(key, filepath.Join(dir, "missing"))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("PutAndGet", func(t *testing.T) {
+		assert.NoError(t, c.Put(key, src))
+
+		dst := filepath.Join(dir, "bin", "app")
+		ok, err := c.Get(key, dst)
+
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		b, err := os.ReadFile(dst)
+		assert.NoError(t, err)
+		assert.Equal(t, "binary", string(b))
+	})
+
+	t.Run("Clean", func(t *testing.T) {
+		count, size, err := c.Clean()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.Equal(t, int64(6), size)
+		assert.NoDirExists(t, filepath.Join(dir, "cache"))
+	})
+
+	t.Run("CleanEmpty", func(t *testing.T) {
+		count, size, err := c.Clean()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, count)
+		assert.Equal(t, int64(0), size)
+	})
+}
@@ -0,0 +1,139 @@
+// Package checksum provides functionality for computing and verifying checksums of files.
+// Checksum files are in the same format as the sha256sum and sha512sum commands.
+package checksum
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Algorithm is a hash algorithm for computing checksums.
+type Algorithm string
+
+const (
+	// SHA256 is the SHA-256 hash algorithm.
+	SHA256 Algorithm = "sha256"
+	// SHA512 is the SHA-512 hash algorithm.
+	SHA512 Algorithm = "sha512"
+)
+
+func (a Algorithm) new() hash.Hash {
+	switch a {
+	case SHA512:
+		return sha512.New()
+	default:
+		return sha256.New()
+	}
+}
+
+// Sum computes the checksum of a reader in hex.
+func Sum(a Algorithm, r io.Reader) (string, error) {
+	h := a.new()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// SumFile computes the checksum of a file in hex.
+func SumFile(a Algorithm, path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return Sum(a, f)
+}
+
+// Checksums is a map of file names to their checksums in hex.
+type Checksums map[string]string
+
+// Write writes the checksums sorted by file names in the sha256sum/sha512sum format.
+func (c Checksums) Write(w io.Writer) error {
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, err := fmt.Fprintf(w, "%s  %s\n", c[name], name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteFile writes the checksums to a file in the sha256sum/sha512sum format.
+func (c Checksums) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := c.Write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// Parse reads checksums in the sha256sum/sha512sum format.
+func Parse(r io.Reader) (Checksums, error) {
+	c := Checksums{}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		// The file name is preceded by a space and either a space (text mode) or an asterisk (binary mode).
+		sum, name, ok := strings.Cut(text, " ")
+		if !ok || len(name) < 2 || (name[0] != ' ' && name[0] != '*') {
+			return nil, fmt.Errorf("invalid checksum at line %d: %s", line, text)
+		}
+
+		if _, err := hex.DecodeString(sum); err != nil {
+			return nil, fmt.Errorf("invalid checksum at line %d: %s", line, text)
+		}
+
+		c[name[1:]] = strings.ToLower(sum)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Verify verifies the checksum of a file against the checksums.
+func (c Checksums) Verify(name, sum string) error {
+	expected, ok := c[name]
+	if !ok {
+		return fmt.Errorf("no checksum found for %s", name)
+	}
+
+	if !strings.EqualFold(expected, sum) {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", name, expected, sum)
+	}
+
+	return nil
+}
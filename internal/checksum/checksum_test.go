@@ -0,0 +1,148 @@
+package checksum
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	helloSHA256 = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	helloSHA512 = "9b71d224bd62f3785d96d46ad3ea3d73319bfbc2890caadae2dff72519673ca72323c3d99ba5c11d7c7acc6e14b8c5da0c4663475c2e5c3adef46f73bcdec043"
+)
+
+type errorReader struct{}
+
+func (errorReader) Read([]byte) (int, error) {
+	return 0, errors.New("read error")
+}
+
+func TestSum(t *testing.T) {
+	tests := []struct {
+		name          string
+		algorithm     Algorithm
+		content       string
+		expectedSum   string
+		expectedError string
+	}{
+		{
+			name:        "SHA256",
+			algorithm:   SHA256,
+			content:     "hello",
+			expectedSum: helloSHA256,
+		},
+		{
+			name:        "SHA512",
+			algorithm:   SHA512,
+			content:     "hello",
+			expectedSum: helloSHA512,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sum, err := Sum(tc.algorithm, strings.NewReader(tc.content))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedSum, sum)
+		})
+	}
+
+	t.Run("ReadFails", func(t *testing.T) {
+		_, err := Sum(SHA256, errorReader{})
+		assert.EqualError(t, err, "read error")
+	})
+}
+
+func TestSumFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hello")
+	assert.NoError(t, os.WriteFile(path, []byte("hello"), 0644))
+
+	sum, err := SumFile(SHA256, path)
+	assert.NoError(t, err)
+	assert.Equal(t, helloSHA256, sum)
+
+	_, err = SumFile(SHA256, filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+func TestChecksums_Write(t *testing.T) {
+	c := Checksums{
+		"basil-linux-amd64":  helloSHA256,
+		"basil-darwin-arm64": helloSHA256,
+	}
+
+	var buf bytes.Buffer
+	err := c.Write(&buf)
+
+	assert.NoError(t, err)
+	assert.Equal(t, helloSHA256+"  basil-darwin-arm64\n"+helloSHA256+"  basil-linux-amd64\n", buf.String())
+}
+
+func TestChecksums_WriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checksums.txt")
+	c := Checksums{"basil": helloSHA256}
+
+	assert.NoError(t, c.WriteFile(path))
+
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, helloSHA256+"  basil\n", string(b))
+
+	assert.Error(t, c.WriteFile(filepath.Join(t.TempDir(), "missing", "checksums.txt")))
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name              string
+		content           string
+		expectedChecksums Checksums
+		expectedError     string
+	}{
+		{
+			name:          "InvalidLine",
+			content:       "basil-linux-amd64\n",
+			expectedError: "invalid checksum at line 1: basil-linux-amd64",
+		},
+		{
+			name:          "InvalidHex",
+			content:       "xyz  basil-linux-amd64\n",
+			expectedError: "invalid checksum at line 1: xyz  basil-linux-amd64",
+		},
+		{
+			name:    "Success",
+			content: helloSHA256 + "  basil-linux-amd64\n\n" + strings.ToUpper(helloSHA256) + " *basil-windows-amd64.exe\n",
+			expectedChecksums: Checksums{
+				"basil-linux-amd64":       helloSHA256,
+				"basil-windows-amd64.exe": helloSHA256,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := Parse(strings.NewReader(tc.content))
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				assert.Nil(t, c)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedChecksums, c)
+			}
+		})
+	}
+}
+
+func TestChecksums_Verify(t *testing.T) {
+	c := Checksums{"basil": helloSHA256}
+
+	assert.NoError(t, c.Verify("basil", strings.ToUpper(helloSHA256)))
+	assert.EqualError(t, c.Verify("app", helloSHA256), "no checksum found for app")
+	assert.EqualError(t, c.Verify("basil", "abcd"), "checksum mismatch for basil: expected "+helloSHA256+", got abcd")
+}
@@ -0,0 +1,124 @@
+// Package clean implements the command for cleaning the build cache.
+package clean
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/gardenbed/basil-cli/internal/cache"
+	"github.com/gardenbed/basil-cli/internal/command"
+	"github.com/gardenbed/basil-cli/internal/ui"
+)
+
+const (
+	synopsis = `Clean the build cache`
+	help     = `
+  Use this command for removing all cached build artifacts.
+  The cache directory is in the user cache directory by default and can be overridden by BASIL_CACHE_DIR.
+
+  Usage:  basil cache clean
+
+  Examples:
+    basil cache clean
+  `
+)
+
+type cacheService interface {
+	Clean() (int, int64, error)
+}
+
+// Command is the cli.Command implementation for cache clean command.
+type Command struct {
+	ui       ui.UI
+	services struct {
+		cache cacheService
+	}
+}
+
+// New creates a new command.
+func New(ui ui.UI) *Command {
+	return &Command{
+		ui: ui,
+	}
+}
+
+// NewFactory returns a cli.CommandFactory for creating a new command.
+func NewFactory(ui ui.UI) cli.CommandFactory {
+	return func() (cli.Command, error) {
+		return New(ui), nil
+	}
+}
+
+// Synopsis returns a short one-line synopsis for the command.
+func (c *Command) Synopsis() string {
+	return synopsis
+}
+
+// Help returns a long help text including usage, description, and list of flags for the command.
+func (c *Command) Help() string {
+	return help
+}
+
+// Run runs the actual command with the given command-line arguments.
+// This method is used as a proxy for creating dependencies and the actual command execution is delegated to the run method for testing purposes.
+func (c *Command) Run(args []string) int {
+	if code := c.parseFlags(args); code != command.Success {
+		return code
+	}
+
+	dir, err := cache.Dir()
+	if err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.OSError
+	}
+
+	c.services.cache = cache.New(dir)
+
+	return c.exec()
+}
+
+func (c *Command) parseFlags(args []string) int {
+	fs := flag.NewFlagSet("cache clean", flag.ContinueOnError)
+
+	fs.Usage = func() {
+		c.ui.Printf(c.Help())
+	}
+
+	if err := fs.Parse(args); err != nil {
+		// In case of error, the error and help will be printed by the Parse method
+		return command.FlagError
+	}
+
+	return command.Success
+}
+
+// exec in an auxiliary method, so we can test the business logic with mock dependencies.
+func (c *Command) exec() int {
+	count, size, err := c.services.cache.Clean()
+	if err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.OSError
+	}
+
+	c.ui.Infof(ui.Green, "🧹 Removed %d cached artifacts (%s)", count, formatSize(size))
+
+	return command.Success
+}
+
+// formatSize formats a size in bytes in a human-readable form.
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
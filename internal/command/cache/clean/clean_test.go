@@ -0,0 +1,124 @@
+package clean
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/basil-cli/internal/cache"
+	"github.com/gardenbed/basil-cli/internal/command"
+	"github.com/gardenbed/basil-cli/internal/ui"
+)
+
+func TestNew(t *testing.T) {
+	ui := ui.NewNop()
+	c := New(ui)
+
+	assert.NotNil(t, c)
+}
+
+func TestNewFactory(t *testing.T) {
+	ui := ui.NewNop()
+	c, err := NewFactory(ui)()
+
+	assert.NoError(t, err)
+	assert.NotNil(t, c)
+}
+
+func TestCommand_Synopsis(t *testing.T) {
+	c := new(Command)
+	synopsis := c.Synopsis()
+
+	assert.NotEmpty(t, synopsis)
+}
+
+func TestCommand_Help(t *testing.T) {
+	c := new(Command)
+	help := c.Help()
+
+	assert.NotEmpty(t, help)
+}
+
+func TestCommand_Run(t *testing.T) {
+	t.Run("InvalidFlag", func(t *testing.T) {
+		c := &Command{ui: ui.NewNop()}
+		exitCode := c.Run([]string{"-undefined"})
+
+		assert.Equal(t, command.FlagError, exitCode)
+	})
+
+	t.Run("OK", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Setenv(cache.DirEnv, dir)
+
+		bin := filepath.Join(dir, "app")
+		assert.NoError(t, os.WriteFile(bin, []byte("binary"), 0755))
+		assert.NoError(t, cache.New(dir).Put("ab12cd34", bin))
+
+		c := &Command{ui: ui.NewNop()}
+		exitCode := c.Run([]string{})
+
+		assert.Equal(t, command.Success, exitCode)
+		assert.NotNil(t, c.services.cache)
+		assert.NoDirExists(t, dir)
+	})
+}
+
+func TestCommand_exec(t *testing.T) {
+	tests := []struct {
+		name             string
+		cache            *MockCacheService
+		expectedExitCode int
+	}{
+		{
+			name: "CleanFails",
+			cache: &MockCacheService{
+				CleanMocks: []CleanMock{
+					{OutError: errors.New("permission denied")},
+				},
+			},
+			expectedExitCode: command.OSError,
+		},
+		{
+			name: "Success",
+			cache: &MockCacheService{
+				CleanMocks: []CleanMock{
+					{OutCount: 10, OutSize: 52428800},
+				},
+			},
+			expectedExitCode: command.Success,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Command{ui: ui.NewNop()}
+			c.services.cache = tc.cache
+
+			exitCode := c.exec()
+
+			assert.Equal(t, tc.expectedExitCode, exitCode)
+		})
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		size         int64
+		expectedSize string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KiB"},
+		{1536, "1.5 KiB"},
+		{52428800, "50.0 MiB"},
+		{3221225472, "3.0 GiB"},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.expectedSize, formatSize(tc.size))
+	}
+}
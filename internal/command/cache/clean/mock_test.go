@@ -0,0 +1,20 @@
+package clean
+
+type (
+	CleanMock struct {
+		OutCount int
+		OutSize  int64
+		OutError error
+	}
+
+	MockCacheService struct {
+		CleanIndex int
+		CleanMocks []CleanMock
+	}
+)
+
+func (m *MockCacheService) Clean() (int, int64, error) {
+	i := m.CleanIndex
+	m.CleanIndex++
+	return m.CleanMocks[i].OutCount, m.CleanMocks[i].OutSize, m.CleanMocks[i].OutError
+}
@@ -45,6 +45,16 @@ const (
 	TemplateError
 	// CompileError is the exit code when compiling a package/file fails.
 	CompileError
+	// ChecksumError is the exit code when computing or verifying a checksum fails.
+	ChecksumError
+	// HookError is the exit code when running a hook fails.
+	HookError
+	// GateError is the exit code when a quality gate fails.
+	GateError
+	// ImageError is the exit code when building or pushing a container image fails.
+	ImageError
+	// GitLabError is the exit code when a GitLab project cannot be set up or accessed.
+	GitLabError
 )
 
 var (
@@ -4,14 +4,20 @@ package build
 import (
 	"bytes"
 	"context"
+	"errors"
 	"flag"
 	"fmt"
-	"html/template"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
+	"runtime"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
 
 	"github.com/gardenbed/charm/flagit"
@@ -19,8 +25,14 @@ import (
 	"github.com/mitchellh/cli"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/gardenbed/basil-cli/internal/archive"
+	"github.com/gardenbed/basil-cli/internal/cache"
+	"github.com/gardenbed/basil-cli/internal/checksum"
 	"github.com/gardenbed/basil-cli/internal/command"
+	gatecmd "github.com/gardenbed/basil-cli/internal/command/project/gate"
 	semvercmd "github.com/gardenbed/basil-cli/internal/command/project/semver"
+	"github.com/gardenbed/basil-cli/internal/gomod"
+	"github.com/gardenbed/basil-cli/internal/image"
 	"github.com/gardenbed/basil-cli/internal/semver"
 	"github.com/gardenbed/basil-cli/internal/spec"
 	"github.com/gardenbed/basil-cli/internal/ui"
@@ -36,16 +48,88 @@ const (
 
   By convention, It assumes the current directory is a main package if it contains a main.go file.
   It also assumes every directory inside cmd is a main package for a binary with the same name as the directory name.
+  In a Go workspace (go.work), every module used by the workspace is built; otherwise, every module with a go.mod file is built.
+  Every module resolves its own metadata package for ld flags and the output is grouped per module.
+  A module in a sub-directory is versioned with its own release tags (e.g. services/billing/v1.2.3), so its binaries, archives, and images carry its own version.
+
+  Alternatively, the binaries can be declared as targets in the spec file (project.build.targets).
+  Every target has its own main package, output name, platforms, build tags, CGO_ENABLED, -trimpath, extra ld flags, and environment variables.
+
+  The platforms for cross compilation are in the form of os-arch or os-arch-variant (e.g. linux-amd64, linux-arm-v7, or linux-amd64-v3).
+  Wildcards are expanded to all matching platforms supported by the Go toolchain (e.g. linux-* or *-arm64).
+  The variant sets the sub-architecture environment variable for the build (e.g. GOARM=7 or GOAMD64=v3).
+  All platforms are validated against "go tool dist list" before building.
+  The default platforms not supported by the Go toolchain (e.g. windows-arm in recent Go versions) are skipped with a warning.
+
+  Custom metadata variables can be injected into the binaries using the spec file (project.build.metadata).
+  A key is either a variable name in the metadata package or a fully-qualified name (e.g. github.com/octocat/app/internal/info.BuildNumber).
+  A value is a template with access to .Version, .SemVer, .Git.Commit, .Git.ShortCommit, .Git.Branch, .Git.RemoteURL, .Git.Dirty, .GoVersion, .Env, and .Time.
+  A warning is printed if a variable does not exist in its package.
+
+  The SHA-256 checksums of all binaries are written to bin/checksums.txt in the sha256sum format.
+  Optionally, the SHA-512 checksums are also written to bin/checksums-sha512.txt in the sha512sum format.
+
+  If archiving is enabled, every binary is archived together with extra files (by default README.md, LICENSE, and CHANGELOG.md).
+  The archive format is configured per OS (by default zip for windows and tar.gz for others).
+  The archive name is a template with access to .Name, .Version, .OS, and .Arch (default: {{"{{.Name}}_{{.Version}}_{{.OS}}_{{.Arch}}"}}).
+  The archives replace the binaries as the build artifacts and checksums are computed for the archives.
+
+  Built binaries are cached locally (by default in the user cache directory, overridable by BASIL_CACHE_DIR).
+  The cache key is computed from the source files (except tests), ld flags (except the build time and the metadata values using .Time), GOOS/GOARCH, GOFLAGS, CGO_ENABLED, CC, GOEXPERIMENT, and the Go version.
+  If a matching binary is found in the cache, it is copied to bin instead of building it again.
+  A cached binary keeps the build time and the .Time metadata values of the build it was cached from.
+  Use "basil cache clean" for removing all cached binaries.
+
+  In reproducible mode, the build time is set from SOURCE_DATE_EPOCH or the timestamp of the last commit.
+  The binaries are built with -trimpath and -buildvcs=false, GOFLAGS is cleared, and CGO is disabled unless a target enables it.
+  The -verify flag builds every binary twice and fails if the two builds are not identical.
+
+  A manifest of all artifacts is written to bin/manifest.json in JSON format.
+  Every entry has the path, target OS/arch, main package, version, commit, SHA-256, size, and build duration of an artifact.
+  The -json flag prints the manifest to the standard output instead of the progress messages.
+
+  If building images is enabled, an OCI image is built for every binary with Linux builds and written to bin as an OCI image layout tarball.
+  If a Dockerfile is specified or found in the current directory, the images are built using "docker buildx build".
+  The name of the binary is passed as the BINARY build argument and the binaries are staged in the build context
+  under the BINARY_DIR build argument by platform (e.g. COPY ${BINARY_DIR}/${TARGETPLATFORM}/${BINARY} /usr/local/bin/).
+  Otherwise, a minimal distroless-style image is assembled from the binaries, running the binary as a nonroot user.
+  The images are tagged as [registry/]dockerid/name:version and the Docker ID is required.
+  The Docker ID is read from project.docker_id in the spec file (written by basil project create -dockerid) or given with -docker-id.
+  The images are listed under images in the manifest and, unlike the artifacts, they are not checksummed or uploaded by releases.
+  If pushing is enabled, the images are pushed to the registry (by default Docker Hub).
+  The registry credentials are read from BASIL_REGISTRY_USERNAME and BASIL_REGISTRY_PASSWORD.
+
+  If any quality gate is enabled in the spec file (project.gates), the quality gates run before building in the modules to build.
+  See "basil project gate -help" for the list of quality gates.
 
   Usage:  basil project build [flags]
 
   Flags:
     -cross-compile    build the binary for all platforms (default: {{.Project.Build.CrossCompile}})
     -platforms        platforms for cross compilation (default: {{join .Project.Build.Platforms ","}})
+    -sha512           also write the SHA-512 checksums of binaries (default: {{.Project.Build.SHA512}})
+    -archive          archive the binaries with extra files (default: {{.Project.Build.Archive.Enabled}})
+    -image            build container images for Linux binaries (default: {{.Project.Build.Image.Enabled}})
+    -docker-id        the Docker ID for tagging images (default: {{.Project.DockerID}})
+    -registry         the registry for tagging and pushing images (default: Docker Hub)
+    -push             push the images to the registry (default: {{.Project.Build.Image.Push}})
+    -parallel         maximum number of concurrent go builds (default: number of CPUs)
+    -no-cache         do not use the build cache (default: {{.Project.Build.NoCache}})
+    -reproducible     build reproducible binaries (default: {{.Project.Build.Reproducible}})
+    -verify           build twice and verify the builds are reproducible (implies -reproducible)
+    -json             print the build manifest in JSON format
+    -module           comma-separated directories or paths of the modules to build (default: all modules)
+    -skip-gates       do not run the quality gates
 
   Examples:
     basil project build
     basil project build -cross-compile
+    basil project build -cross-compile -platforms "linux-*,darwin-arm64,linux-arm-v7"
+    basil project build -no-cache
+    basil project build -reproducible -verify
+    basil project build -cross-compile -platforms "linux-amd64,linux-arm64" -image -docker-id octocat
+    basil project build -json
+    basil project build -module ./tools/gen
   `
 )
 
@@ -53,22 +137,70 @@ const (
 	cmdDir       = "cmd"
 	binPath      = "./bin/"
 	metadataPath = "./metadata"
+	remoteName   = "origin"
 	timeFormat   = "2006-01-02 15:04:05 MST"
+
+	sourceDateEpochEnv = "SOURCE_DATE_EPOCH"
+
+	checksumsFile       = "checksums.txt"
+	checksumsSHA512File = "checksums-sha512.txt"
+
+	formatTarGz          = "tar.gz"
+	formatZip            = "zip"
+	defaultArchiveName   = "{{.Name}}_{{.Version}}_{{.OS}}_{{.Arch}}"
+	defaultArchiveFormat = formatTarGz
 )
 
 var (
 	goVersionRE = regexp.MustCompile(`\d+\.\d+(\.\d+)?`)
+
+	defaultArchiveFormats = map[string]string{
+		"windows": formatZip,
+	}
+
+	defaultArchiveFiles = []string{"README.md", "LICENSE", "CHANGELOG.md"}
+
+	// cacheEnvVars are the environment variables of the go command that change the built binaries.
+	cacheEnvVars = []string{"GOFLAGS", "CGO_ENABLED", "CC", "GOEXPERIMENT"}
 )
 
-type semverCommand interface {
-	Run([]string) int
-	SemVer() semver.SemVer
-}
+type (
+	semverCommand interface {
+		Run([]string) int
+		SemVer() semver.SemVer
+	}
+
+	gateCommand interface {
+		Run([]string) int
+	}
+
+	archiveService interface {
+		Create(io.Writer, []archive.File) error
+	}
+
+	cacheService interface {
+		Get(string, string) (bool, error)
+		Put(string, string) error
+	}
+
+	imageRegistry interface {
+		Push(context.Context, *image.Layout, image.Reference) error
+	}
+)
 
 // Artifact is a build artifact.
 type Artifact struct {
-	Path  string
-	Label string
+	Path     string
+	Label    string
+	OS       string
+	Arch     string
+	Variant  string
+	Main     string
+	Version  string
+	SHA256   string
+	SHA512   string
+	Size     int64
+	Duration time.Duration
 }
 
 // Command is the cli.Command implementation for build command.
@@ -76,17 +208,42 @@ type Command struct {
 	sync.Mutex
 	ui    ui.UI
 	spec  spec.Spec
+	flags struct {
+		verify    bool
+		json      bool
+		modules   string
+		skipGates bool
+	}
 	funcs struct {
-		gitRevSHA    shell.RunnerFunc
-		gitRevBranch shell.RunnerFunc
-		goList       shell.RunnerFunc
-		goBuild      shell.RunnerWithFunc
+		gitRevSHA     shell.RunnerFunc
+		gitRevBranch  shell.RunnerFunc
+		gitCommitTime shell.RunnerFunc
+		gitStatus     shell.RunnerFunc
+		gitRemoteURL  shell.RunnerFunc
+		goDistList    shell.RunnerFunc
+		goList        shell.RunnerWithFunc
+		goListDir     shell.RunnerWithFunc
+		goBuild       shell.RunnerWithFunc
+		dockerBuild   shell.RunnerWithFunc
+	}
+	services struct {
+		tarArchive archiveService
+		zipArchive archiveService
+		cache      cacheService
+		registry   imageRegistry
 	}
 	commands struct {
 		semver semverCommand
+		gate   gateCommand
+	}
+	inputs struct {
+		// hash is the hash of the build inputs shared by all binaries (empty if caching is disabled).
+		hash string
 	}
 	outputs struct {
 		artifacts []Artifact
+		images    []Artifact
+		manifest  string
 	}
 }
 
@@ -128,9 +285,28 @@ func (c *Command) Run(args []string) int {
 
 	c.funcs.gitRevSHA = shell.Runner("git", "rev-parse", "HEAD")
 	c.funcs.gitRevBranch = shell.Runner("git", "rev-parse", "--abbrev-ref", "HEAD")
-	c.funcs.goList = shell.Runner("go", "list", metadataPath)
+	c.funcs.gitCommitTime = shell.Runner("git", "log", "-1", "--format=%ct")
+	c.funcs.gitStatus = shell.Runner("git", "status", "--porcelain")
+	c.funcs.gitRemoteURL = shell.Runner("git", "remote", "get-url", remoteName)
+	c.funcs.goDistList = shell.Runner("go", "tool", "dist", "list")
+	c.funcs.goList = shell.RunnerWith("go", "list", metadataPath)
+	c.funcs.goListDir = shell.RunnerWith("go", "list", "-f", "{{.Dir}}")
 	c.funcs.goBuild = shell.RunnerWith("go", "build")
-	c.commands.semver = semvercmd.New(ui.NewNop())
+	c.funcs.dockerBuild = shell.RunnerWith("docker", "buildx", "build")
+	c.services.tarArchive = archive.NewTarArchive(c.progressUI())
+	c.services.zipArchive = archive.NewZipArchive(c.progressUI())
+	c.services.registry = image.NewRegistry(os.Getenv(registryUsernameEnv), os.Getenv(registryPasswordEnv))
+	c.commands.semver = semvercmd.New(ui.NewNop(), c.spec)
+	c.commands.gate = gatecmd.New(c.progressUI(), c.spec)
+
+	if !c.spec.Project.Build.NoCache {
+		dir, err := cache.Dir()
+		if err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.OSError
+		}
+		c.services.cache = cache.New(dir)
+	}
 
 	return c.exec()
 }
@@ -146,16 +322,49 @@ func (c *Command) parseFlags(args []string) int {
 		return command.GenericError
 	}
 
+	fs.StringVar(&c.spec.Project.DockerID, "docker-id", c.spec.Project.DockerID, "")
+	fs.BoolVar(&c.flags.verify, "verify", false, "")
+	fs.BoolVar(&c.flags.json, "json", false, "")
+	fs.StringVar(&c.flags.modules, "module", "", "")
+	fs.BoolVar(&c.flags.skipGates, "skip-gates", false, "")
+
 	if err := fs.Parse(args); err != nil {
 		// In case of error, the error and help will be printed by the Parse method
 		return command.FlagError
 	}
 
+	// Verifying only makes sense for reproducible builds
+	if c.flags.verify {
+		c.spec.Project.Build.Reproducible = true
+	}
+
 	return command.Success
 }
 
 // exec in an auxiliary method, so we can test the business logic with mock dependencies.
 func (c *Command) exec() int {
+	// The progress is reported through the progress UI and the injected UI is only used for printing the manifest
+	out := c.ui
+	c.ui = c.progressUI()
+	defer func() {
+		c.ui = out
+	}()
+
+	// ==============================> RUN QUALITY GATES <==============================
+
+	// The quality gates have their own timeout, so they run before the build timeout starts
+	if c.spec.Project.Gates.Enabled() && !c.flags.skipGates {
+		// The gates run in the same modules as the build
+		args := []string{}
+		if c.flags.modules != "" {
+			args = append(args, "-module", c.flags.modules)
+		}
+
+		if code := c.commands.gate.Run(args); code != command.Success {
+			return code
+		}
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
@@ -185,9 +394,6 @@ func (c *Command) exec() int {
 		return command.GitError
 	}
 
-	// If metadata package not found, we simply skip it
-	_, metadataPkg, _ := c.funcs.goList(ctx)
-
 	// Run semver command
 	if code := c.commands.semver.Run(nil); code != command.Success {
 		return code
@@ -195,68 +401,272 @@ func (c *Command) exec() int {
 
 	semver := c.commands.semver.SemVer()
 
-	// ==============================> CONSTRUCT LD FLAGS <==============================
+	buildTime := time.Now()
+
+	// Reproducible builds use a fixed build time
+	if c.spec.Project.Build.Reproducible {
+		if val := os.Getenv(sourceDateEpochEnv); val != "" {
+			sec, err := strconv.ParseInt(val, 10, 64)
+			if err != nil {
+				c.ui.Errorf(ui.Red, "Invalid %s: %s", sourceDateEpochEnv, val)
+				return command.GenericError
+			}
+			buildTime = time.Unix(sec, 0)
+		} else {
+			_, out, err := c.funcs.gitCommitTime(ctx)
+			if err != nil {
+				c.ui.Errorf(ui.Red, "%s", err)
+				return command.GitError
+			}
+
+			sec, err := strconv.ParseInt(out, 10, 64)
+			if err != nil {
+				c.ui.Errorf(ui.Red, "Invalid commit timestamp: %s", out)
+				return command.GitError
+			}
+			buildTime = time.Unix(sec, 0)
+		}
+	}
+
+	// ==============================> VALIDATE PLATFORMS <==============================
+
+	if c.spec.Project.Build.CrossCompile {
+		_, out, err := c.funcs.goDistList(ctx)
+		if err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GoError
+		}
+
+		if err := c.resolvePlatforms(parseDistList(out)); err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.SpecError
+		}
+	}
+
+	// ==============================> FIND MODULES <==============================
+
+	found, err := gomod.Find(".")
+	if err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.OSError
+	}
+
+	if err := c.checkTargetModules(found); err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.SpecError
+	}
 
-	var ldFlags string
+	selected, err := gomod.Select(found, c.moduleSelectors())
+	if err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.FlagError
+	}
 
-	// Construct the LD flags only if the version package exist
-	if metadataPkg != "" {
-		goVersion := goVersionRE.FindString(info.Go.Version)
-		buildTime := time.Now().UTC().Format(timeFormat)
-		buildTool := "Basil"
+	modules := make([]module, len(selected))
+	for i, m := range selected {
+		modules[i].Module = m
+	}
+
+	// ==============================> RESOLVE MODULE VERSIONS <==============================
 
-		if metadata.Version != "" {
-			buildTool += " " + metadata.Version
+	// A module in a sub-directory has its own release tags, so its version is resolved from its directory
+	for i := range modules {
+		modules[i].version = semver
+
+		if modules[i].Dir != "." {
+			if code := c.commands.semver.Run([]string{"-dir", modules[i].Dir}); code != command.Success {
+				return code
+			}
+
+			modules[i].version = c.commands.semver.SemVer()
 		}
+	}
+
+	// ==============================> CONSTRUCT LD FLAGS <==============================
 
-		ldFlags = strings.Join([]string{
-			fmt.Sprintf(`-X "%s.Version=%s"`, metadataPkg, semver),
-			fmt.Sprintf(`-X "%s.Commit=%s"`, metadataPkg, gitSHA[:7]),
-			fmt.Sprintf(`-X "%s.Branch=%s"`, metadataPkg, gitBranch),
-			fmt.Sprintf(`-X "%s.GoVersion=%s"`, metadataPkg, goVersion),
-			fmt.Sprintf(`-X "%s.BuildTool=%s"`, metadataPkg, buildTool),
-			fmt.Sprintf(`-X "%s.BuildTime=%s"`, metadataPkg, buildTime),
-		}, " ")
+	goVersion := goVersionRE.FindString(info.Go.Version)
+	buildTool := "Basil"
+
+	if metadata.Version != "" {
+		buildTool += " " + metadata.Version
 	}
 
-	// ==============================> BUILD BINARIES <==============================
+	metadataVars, err := parseMetadataVars(c.spec.Project.Build.Metadata)
+	if err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.SpecError
+	}
 
-	cmdPath := fmt.Sprintf("./%s/", cmdDir)
+	// The custom metadata is rendered with the version of every module
+	moduleVars := make([][]metadataVar, len(modules))
 
-	// By convention, we assume every directory inside cmd is a main package for a binary with the same name as the directory name.
-	if _, err := os.Stat(cmdPath); err == nil {
-		files, err := os.ReadDir(cmdPath)
+	if len(metadataVars) > 0 {
+		_, gitStatus, err := c.funcs.gitStatus(ctx)
 		if err != nil {
 			c.ui.Errorf(ui.Red, "%s", err)
-			return command.OSError
+			return command.GitError
 		}
 
-		for _, file := range files {
-			if file.IsDir() {
-				mainPkg := cmdPath + file.Name()
-				output := binPath + file.Name()
+		// The remote URL is optional and left empty if there is no remote
+		_, gitRemoteURL, _ := c.funcs.gitRemoteURL(ctx)
 
-				if err := c.buildAll(ctx, ldFlags, mainPkg, output); err != nil {
-					c.ui.Errorf(ui.Red, "%s", err)
-					return command.GoError
-				}
+		data := metadataData{
+			Git: gitData{
+				Commit:      gitSHA,
+				ShortCommit: gitSHA[:7],
+				Branch:      gitBranch,
+				RemoteURL:   gitRemoteURL,
+				Dirty:       gitStatus != "",
+			},
+			GoVersion: goVersion,
+			Env:       environ(),
+			Time:      buildTime.UTC(),
+		}
+
+		for i := range modules {
+			data.Version, data.SemVer = modules[i].version.String(), modules[i].version
+
+			if moduleVars[i], err = renderMetadata(metadataVars, data); err != nil {
+				c.ui.Errorf(ui.Red, "%s", err)
+				return command.TemplateError
 			}
 		}
 	}
 
-	// We also assume the current directory is a main package if it contains a main.go file.
-	if _, err := os.Stat("./main.go"); err == nil {
-		mainPkg := "."
-		output := binPath + filepath.Base(info.WorkingDirectory)
+	for i := range modules {
+		// Every module resolves its own metadata package and if it is not found, we simply skip it
+		_, metadataPkg, _ := c.funcs.goList(ctx, shell.RunOptions{WorkingDir: modules[i].Dir})
+
+		flags := []string{}
+
+		// Construct the LD flags only if the version package exist
+		if metadataPkg != "" {
+			flags = append(flags,
+				fmt.Sprintf(`-X "%s.Version=%s"`, metadataPkg, modules[i].version),
+				fmt.Sprintf(`-X "%s.Commit=%s"`, metadataPkg, gitSHA[:7]),
+				fmt.Sprintf(`-X "%s.Branch=%s"`, metadataPkg, gitBranch),
+				fmt.Sprintf(`-X "%s.GoVersion=%s"`, metadataPkg, goVersion),
+				fmt.Sprintf(`-X "%s.BuildTool=%s"`, metadataPkg, buildTool),
+			)
+		}
 
-		if err := c.buildAll(ctx, ldFlags, mainPkg, output); err != nil {
+		customFlags, timedFlags, err := c.metadataFlags(ctx, modules[i], metadataPkg, moduleVars[i])
+		if err != nil {
 			c.ui.Errorf(ui.Red, "%s", err)
-			return command.GoError
+			return command.SpecError
+		}
+		flags = append(flags, customFlags...)
+
+		// The build time and the custom metadata depending on it are excluded from the stable LD flags,
+		// so they do not invalidate the build cache.
+		modules[i].stableLDFlags = strings.Join(flags, " ")
+
+		if metadataPkg != "" {
+			flags = append(flags, fmt.Sprintf(`-X "%s.BuildTime=%s"`, metadataPkg, buildTime.UTC().Format(timeFormat)))
+		}
+		flags = append(flags, timedFlags...)
+
+		modules[i].ldFlags = strings.Join(flags, " ")
+	}
+
+	// ==============================> FIND TARGETS <==============================
+
+	for i := range modules {
+		targets, err := c.moduleTargets(info.WorkingDirectory, modules[i])
+		if err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.OSError
+		}
+
+		modules[i].targets = targets
+	}
+
+	if err := checkTargetNames(modules); err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.SpecError
+	}
+
+	// ==============================> HASH BUILD INPUTS <==============================
+
+	if c.services.cache != nil {
+		// The build outputs are not inputs
+		sourcesHash, err := cache.HashSources(info.WorkingDirectory, filepath.Join(info.WorkingDirectory, binPath))
+		if err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.OSError
 		}
+
+		reproducible := fmt.Sprintf("%t", c.spec.Project.Build.Reproducible)
+		c.inputs.hash = cache.Key(append([]string{sourcesHash, info.Go.Version, reproducible}, envInputs()...)...)
+	}
+
+	// ==============================> BUILD BINARIES <==============================
+
+	if err := c.buildModules(ctx, binPath, modules); err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.GoError
 	}
 
 	if len(c.outputs.artifacts) == 0 {
 		c.ui.Warnf(ui.Yellow, "No main package found.")
+		return command.Success
+	}
+
+	// ==============================> VERIFY REPRODUCIBILITY <==============================
+
+	if c.flags.verify {
+		ok, err := c.verifyBuilds(ctx, modules)
+		if err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GoError
+		}
+
+		if !ok {
+			return command.ChecksumError
+		}
+	}
+
+	// ==============================> BUILD IMAGES <==============================
+
+	if c.spec.Project.Build.Image.Enabled {
+		if err := c.buildImages(ctx, binPath, gitSHA, buildTime); err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.ImageError
+		}
+	}
+
+	// ==============================> ARCHIVE BINARIES <==============================
+
+	if c.spec.Project.Build.Archive.Enabled {
+		if err := c.archiveAll(binPath); err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.ArchiveError
+		}
+	}
+
+	// ==============================> WRITE CHECKSUMS <==============================
+
+	if err := c.writeChecksums(binPath); err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.ChecksumError
+	}
+
+	// ==============================> WRITE MANIFEST <==============================
+
+	manifest, err := c.writeManifest(binPath, semver.String(), gitSHA)
+	if err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.OSError
+	}
+
+	if c.flags.json {
+		var buf bytes.Buffer
+		if err := manifest.Write(&buf); err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GenericError
+		}
+
+		out.Printf("%s", strings.TrimSuffix(buf.String(), "\n"))
 	}
 
 	// ==============================> DONE <==============================
@@ -264,58 +674,831 @@ func (c *Command) exec() int {
 	return command.Success
 }
 
-func (c *Command) buildAll(ctx context.Context, ldFlags, mainPkg, output string) error {
-	if !c.spec.Project.Build.CrossCompile {
-		return c.build(ctx, "", "", ldFlags, mainPkg, output)
+// moduleSelectors returns the modules selected by the -module flag.
+func (c *Command) moduleSelectors() []string {
+	return gomod.ParseSelectors(c.flags.modules)
+}
+
+// targetModule returns the module of a target (by default, the module in the working directory).
+func targetModule(target spec.Target) string {
+	if target.Module == "" {
+		return "."
+	}
+
+	return target.Module
+}
+
+// metadataFlags returns the ld flags for the custom metadata variables of a module.
+// The flags for the variables depending on the build time are returned separately, since they change on every build.
+// A variable without a package is set in the metadata package of the module.
+// A warning is printed for every variable not declared in its package, since the linker silently ignores it.
+func (c *Command) metadataFlags(ctx context.Context, mod module, metadataPkg string, vars []metadataVar) ([]string, []string, error) {
+	flags, timedFlags := []string{}, []string{}
+	pkgVars := map[string]map[string]bool{}
+
+	for _, v := range vars {
+		pkg := v.pkg
+		if pkg == "" {
+			if metadataPkg == "" {
+				c.ui.Warnf(ui.Yellow, "Metadata variable %s skipped: no metadata package in module %s", v.name, mod.Name())
+				continue
+			}
+			pkg = metadataPkg
+		}
+
+		// Variables in the main package cannot be resolved by go list
+		if pkg != "main" {
+			if _, ok := pkgVars[pkg]; !ok {
+				pkgVars[pkg] = nil
+				if _, dir, err := c.funcs.goListDir(ctx, shell.RunOptions{WorkingDir: mod.Dir}, pkg); err != nil {
+					c.ui.Warnf(ui.Yellow, "Metadata package %s not found in module %s", pkg, mod.Name())
+				} else if names, err := packageVars(dir); err != nil {
+					c.ui.Warnf(ui.Yellow, "Cannot parse metadata package %s: %s", pkg, err)
+				} else {
+					pkgVars[pkg] = names
+				}
+			}
+
+			if names := pkgVars[pkg]; names != nil && !names[v.name] {
+				c.ui.Warnf(ui.Yellow, "Metadata variable %s.%s does not exist", pkg, v.name)
+			}
+		}
+
+		flag, err := xFlag(pkg, v.name, v.value)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if v.timed {
+			timedFlags = append(timedFlags, flag)
+		} else {
+			flags = append(flags, flag)
+		}
+	}
+
+	return flags, timedFlags, nil
+}
+
+// resolvePlatforms validates the default and target platforms against the platforms supported by the toolchain.
+// The wildcards are expanded in place, so the rest of the build only deals with concrete platforms.
+func (c *Command) resolvePlatforms(supported []platform) error {
+	entries := c.spec.Project.Build.Platforms
+
+	// The default platforms not supported by the toolchain are skipped instead of failing the build
+	if slices.Equal(entries, spec.DefaultPlatforms()) {
+		entries = supportedPlatforms(entries, supported)
+		for _, entry := range c.spec.Project.Build.Platforms {
+			if !slices.Contains(entries, entry) {
+				c.ui.Warnf(ui.Yellow, "Default platform %s skipped: not supported by the Go toolchain", entry)
+			}
+		}
+	}
+
+	platforms, err := resolvePlatforms(entries, supported)
+	if err != nil {
+		return err
+	}
+	c.spec.Project.Build.Platforms = platforms
+
+	// The targets slice is copied, so the spec passed in by the caller is not modified
+	targets := make([]spec.Target, len(c.spec.Project.Build.Targets))
+	copy(targets, c.spec.Project.Build.Targets)
+
+	for i, target := range targets {
+		if len(target.Platforms) == 0 {
+			continue
+		}
+
+		platforms, err := resolvePlatforms(target.Platforms, supported)
+		if err != nil {
+			return fmt.Errorf("target %s: %s", target.Name, err)
+		}
+		targets[i].Platforms = platforms
+	}
+
+	c.spec.Project.Build.Targets = targets
+
+	return nil
+}
+
+// checkTargetModules verifies every target in the spec belongs to a module.
+func (c *Command) checkTargetModules(modules []gomod.Module) error {
+	for _, target := range c.spec.Project.Build.Targets {
+		if _, err := gomod.Select(modules, []string{targetModule(target)}); err != nil {
+			return fmt.Errorf("invalid target %s: %s", target.Main, err)
+		}
+	}
+
+	return nil
+}
+
+// checkTargetNames verifies no two targets in different modules have the same name, so they do not overwrite each other.
+func checkTargetNames(modules []module) error {
+	names := map[string]module{}
+	for _, mod := range modules {
+		for _, target := range mod.targets {
+			if other, ok := names[target.Name]; ok && other.Dir != mod.Dir {
+				return fmt.Errorf("binary %s is built in both %s and %s modules", target.Name, other.Name(), mod.Name())
+			}
+			names[target.Name] = mod
+		}
+	}
+
+	return nil
+}
+
+// moduleTargets returns the build targets of a module.
+// If targets are specified in the spec, the targets belonging to the module are returned.
+// Otherwise, the targets are found by convention in the module directory.
+// The main packages of targets are relative to the module directory.
+func (c *Command) moduleTargets(workDir string, mod module) ([]spec.Target, error) {
+	// The name of the module directory is used for a main package in the module directory
+	dirName := filepath.Base(mod.Dir)
+	if mod.Dir == "." {
+		dirName = filepath.Base(workDir)
+	}
+
+	targets := []spec.Target{}
+
+	if len(c.spec.Project.Build.Targets) > 0 {
+		for _, target := range c.spec.Project.Build.Targets {
+			if !mod.Matches(targetModule(target)) {
+				continue
+			}
+
+			if target.Main == "" {
+				target.Main = "."
+			}
+
+			if target.Name == "" {
+				target.Name = filepath.Base(filepath.Clean(target.Main))
+				if target.Name == "." {
+					target.Name = dirName
+				}
+			}
+
+			targets = append(targets, target)
+		}
+
+		return targets, nil
 	}
 
-	// Cross-compiling
-	group, groupCtx := errgroup.WithContext(ctx)
-	for _, platform := range c.spec.Project.Build.Platforms {
-		output := output + "-" + platform
-		vals := strings.Split(platform, "-")
+	cmdPath := filepath.Join(mod.Dir, cmdDir)
 
+	// By convention, we assume every directory inside cmd is a main package for a binary with the same name as the directory name.
+	if _, err := os.Stat(cmdPath); err == nil {
+		files, err := os.ReadDir(cmdPath)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			if file.IsDir() {
+				targets = append(targets, spec.Target{
+					Main: fmt.Sprintf("./%s/%s", cmdDir, file.Name()),
+					Name: file.Name(),
+				})
+			}
+		}
+	}
+
+	// We also assume the module directory is a main package if it contains a main.go file.
+	if _, err := os.Stat(filepath.Join(mod.Dir, "main.go")); err == nil {
+		targets = append(targets, spec.Target{
+			Main: ".",
+			Name: dirName,
+		})
+	}
+
+	return targets, nil
+}
+
+// buildModules builds the targets of all modules.
+// The modules are built one after another, so the output is grouped per module.
+// A failed module does not stop the other modules, so all failed builds are reported together.
+func (c *Command) buildModules(ctx context.Context, dir string, modules []module) error {
+	errs := []string{}
+	for _, mod := range modules {
+		if len(mod.targets) == 0 {
+			continue
+		}
+
+		if len(modules) > 1 {
+			c.ui.Infof(ui.Cyan, "Module %s", mod.Name())
+		}
+
+		if err := c.buildAll(ctx, mod, dir); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
+
+// buildJob is a single go build for a target and a platform.
+type buildJob struct {
+	target                spec.Target
+	goos, goarch, variant string
+	output                string
+}
+
+// buildAll builds all targets of a module for all of their platforms with a bounded number of concurrent go builds.
+// A failed build does not stop the other builds, so all failed builds are reported together.
+// The binaries are written to a directory, which should end with a path separator.
+func (c *Command) buildAll(ctx context.Context, mod module, dir string) error {
+	jobs := []buildJob{}
+	remaining := map[string]int{}
+
+	for _, target := range mod.targets {
+		output := dir + target.Name
+
+		if !c.spec.Project.Build.CrossCompile {
+			jobs = append(jobs, buildJob{target: target, output: output})
+			remaining[target.Name]++
+			continue
+		}
+
+		// The target platforms override the default platforms
+		platforms := target.Platforms
+		if len(platforms) == 0 {
+			platforms = c.spec.Project.Build.Platforms
+		}
+
+		for _, s := range platforms {
+			p, err := parsePlatform(s)
+			if err != nil {
+				return err
+			}
+
+			jobs = append(jobs, buildJob{
+				target:  target,
+				goos:    p.os,
+				goarch:  p.arch,
+				variant: p.variant,
+				output:  output + "-" + p.String(),
+			})
+			remaining[target.Name]++
+		}
+	}
+
+	parallel := c.spec.Project.Build.Parallel
+	if parallel <= 0 {
+		parallel = runtime.NumCPU()
+	}
+
+	var mu sync.Mutex
+	var done int
+	failed := map[string]int{}
+	errs := []string{}
+
+	group := new(errgroup.Group)
+	group.SetLimit(parallel)
+
+	for _, job := range jobs {
 		group.Go(func() error {
-			return c.build(groupCtx, vals[0], vals[1], ldFlags, mainPkg, output)
+			cached, err := c.build(ctx, mod, job)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			done++
+			name := job.target.Name
+			remaining[name]--
+
+			switch {
+			case err != nil:
+				failed[name]++
+				errs = append(errs, fmt.Sprintf("%s: %s", job.output, err))
+				c.ui.Errorf(ui.Red, "[%d/%d] %s failed", done, len(jobs), job.output)
+			case cached:
+				c.ui.Printf("[%d/%d] %s (cached)", done, len(jobs), job.output)
+			default:
+				c.ui.Printf("[%d/%d] %s", done, len(jobs), job.output)
+			}
+
+			if remaining[name] == 0 {
+				if failed[name] > 0 {
+					c.ui.Warnf(ui.Yellow, "Target %s: %d build(s) failed", name, failed[name])
+				} else {
+					c.ui.Infof(ui.Green, "Target %s built", name)
+				}
+			}
+
+			// Errors are collected, so the other builds are not cancelled
+			return nil
 		})
 	}
 
-	return group.Wait()
+	_ = group.Wait()
+
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("%d of %d builds failed:\n  %s", len(errs), len(jobs), strings.Join(errs, "\n  "))
+	}
+
+	return nil
 }
 
-func (c *Command) build(ctx context.Context, os, arch, ldFlags, mainPkg, output string) error {
+// build builds a target for a platform.
+// It returns true if the binary is copied from the cache instead of being built.
+func (c *Command) build(ctx context.Context, mod module, job buildJob) (bool, error) {
+	target, goos, goarch, variant, output := job.target, job.goos, job.goarch, job.variant, job.output
+
+	env := map[string]string{}
+	for key, val := range target.Env {
+		env[key] = val
+	}
+
+	if target.CGOEnabled != nil {
+		env["CGO_ENABLED"] = "0"
+		if *target.CGOEnabled {
+			env["CGO_ENABLED"] = "1"
+		}
+	}
+
+	// Normalize the environment for reproducible builds
+	if c.spec.Project.Build.Reproducible {
+		env["GOFLAGS"] = ""
+		if target.CGOEnabled == nil {
+			env["CGO_ENABLED"] = "0"
+		}
+	}
+
+	env["GOOS"] = goos
+	env["GOARCH"] = goarch
+
+	// The sub-architecture variant is set only when cross-compiling
+	if goos != "" {
+		for key, val := range (platform{os: goos, arch: goarch, variant: variant}).env() {
+			env[key] = val
+		}
+	}
+
+	// go build runs in the module directory
 	opts := shell.RunOptions{
-		Environment: map[string]string{
-			"GOOS":   os,
-			"GOARCH": arch,
-		},
+		WorkingDir:  mod.Dir,
+		Environment: env,
+	}
+
+	ldFlags := mod.ldFlags
+	if target.LDFlags != "" {
+		ldFlags = strings.TrimSpace(ldFlags + " " + target.LDFlags)
+	}
+
+	// Empty GOOS and GOARCH mean the current platform
+	platformOS, platformArch := goos, goarch
+	if platformOS == "" {
+		platformOS = runtime.GOOS
+	}
+	if platformArch == "" {
+		platformArch = runtime.GOARCH
+	}
+
+	var key string
+	if c.services.cache != nil && c.inputs.hash != "" {
+		key = cache.Key(append([]string{c.inputs.hash, mod.Dir, mod.stableLDFlags, platformOS, platformArch, variant}, targetInputs(target)...)...)
+
+		ok, err := c.services.cache.Get(key, output)
+		if err != nil {
+			return false, err
+		}
+
+		if ok {
+			c.addArtifact(output, platformOS, platformArch, variant, target.Main, mod.version.String(), 0)
+			return true, nil
+		}
 	}
 
 	args := []string{}
+	if len(target.Tags) > 0 {
+		args = append(args, "-tags", strings.Join(target.Tags, ","))
+	}
+	if target.TrimPath || c.spec.Project.Build.Reproducible {
+		args = append(args, "-trimpath")
+	}
+	if c.spec.Project.Build.Reproducible {
+		args = append(args, "-buildvcs=false")
+	}
 	if ldFlags != "" {
 		args = append(args, "-ldflags", ldFlags)
 	}
 	if output != "" {
-		args = append(args, "-o", output)
+		// The output is relative to the working directory, but go build runs in the module directory
+		out := output
+		if mod.Dir != "." && !filepath.IsAbs(out) {
+			rel, err := filepath.Rel(mod.Dir, out)
+			if err != nil {
+				return false, err
+			}
+			out = rel
+		}
+		args = append(args, "-o", out)
 	}
-	args = append(args, mainPkg)
+	args = append(args, target.Main)
 
+	start := time.Now()
 	_, _, err := c.funcs.goBuild(ctx, opts, args...)
 	if err != nil {
-		return err
+		return false, err
+	}
+	duration := time.Since(start)
+
+	// Failing to store a binary in the cache should not fail the build
+	if key != "" {
+		if err := c.services.cache.Put(key, output); err != nil {
+			c.ui.Warnf(ui.Yellow, "Cannot cache %s: %s", output, err)
+		}
+	}
+
+	c.addArtifact(output, platformOS, platformArch, variant, target.Main, mod.version.String(), duration)
+
+	return false, nil
+}
+
+// verifyBuilds builds all targets again without the cache and compares the new binaries with the built ones.
+// It returns false if any binary is not reproduced identically.
+func (c *Command) verifyBuilds(ctx context.Context, modules []module) (bool, error) {
+	dir, err := os.MkdirTemp("", "basil-verify-")
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	// The cache is bypassed, so every binary is actually built again
+	artifacts, buildCache := c.outputs.artifacts, c.services.cache
+	c.outputs.artifacts, c.services.cache = nil, nil
+	defer func() {
+		c.outputs.artifacts, c.services.cache = artifacts, buildCache
+	}()
+
+	c.ui.Infof(ui.Cyan, "Building again for verifying reproducibility ...")
+
+	if err := c.buildModules(ctx, dir+string(filepath.Separator), modules); err != nil {
+		return false, err
+	}
+
+	mismatches := []string{}
+	for _, artifact := range artifacts {
+		sum, err := checksum.SumFile(checksum.SHA256, artifact.Path)
+		if err != nil {
+			return false, err
+		}
+
+		rebuiltSum, err := checksum.SumFile(checksum.SHA256, filepath.Join(dir, filepath.Base(artifact.Path)))
+		if err != nil {
+			return false, err
+		}
+
+		if sum != rebuiltSum {
+			mismatches = append(mismatches, artifact.Path)
+		}
+	}
+
+	if len(mismatches) > 0 {
+		sort.Strings(mismatches)
+		c.ui.Errorf(ui.Red, "Builds are not reproducible:\n  %s", strings.Join(mismatches, "\n  "))
+		return false, nil
+	}
+
+	c.ui.Infof(ui.Green, "Builds verified as reproducible")
+
+	return true, nil
+}
+
+// envInputs returns the environment variables that affect every built binary as cache inputs.
+func envInputs() []string {
+	inputs := make([]string, 0, len(cacheEnvVars))
+	for _, key := range cacheEnvVars {
+		inputs = append(inputs, key+"="+os.Getenv(key))
 	}
 
+	return inputs
+}
+
+// targetInputs returns the settings of a target that affect the built binary as cache inputs.
+func targetInputs(target spec.Target) []string {
+	cgo := ""
+	if target.CGOEnabled != nil {
+		cgo = fmt.Sprintf("%t", *target.CGOEnabled)
+	}
+
+	env := make([]string, 0, len(target.Env))
+	for key, val := range target.Env {
+		env = append(env, key+"="+val)
+	}
+	sort.Strings(env)
+
+	return []string{
+		target.Main,
+		strings.Join(target.Tags, ","),
+		cgo,
+		fmt.Sprintf("%t", target.TrimPath),
+		target.LDFlags,
+		strings.Join(env, "\n"),
+	}
+}
+
+func (c *Command) addArtifact(path, goos, goarch, variant, main, version string, duration time.Duration) {
 	c.Mutex.Lock()
 	c.outputs.artifacts = append(c.outputs.artifacts, Artifact{
-		Path: output,
+		Path:     path,
+		OS:       goos,
+		Arch:     goarch,
+		Variant:  variant,
+		Main:     main,
+		Version:  version,
+		Duration: duration,
 	})
 	c.Mutex.Unlock()
+}
+
+// progressUI returns the user interface for reporting the progress.
+// Only errors are reported when printing the manifest, so the standard output is valid JSON.
+func (c *Command) progressUI() ui.UI {
+	if c.flags.json {
+		return ui.NewErrorsOnly(c.ui)
+	}
+
+	return c.ui
+}
+
+// archiveAll archives every binary artifact together with the extra files in a directory.
+// The archives replace the binaries as the artifacts and are named with the versions of the artifacts.
+func (c *Command) archiveAll(dir string) error {
+	spec := c.spec.Project.Build.Archive
 
-	c.ui.Printf(output)
+	nameTemplate := spec.Name
+	if nameTemplate == "" {
+		nameTemplate = defaultArchiveName
+	}
+
+	tmpl, err := template.New("archive").Parse(nameTemplate)
+	if err != nil {
+		return fmt.Errorf("invalid archive name template: %s", err)
+	}
+
+	files, err := archiveFiles(spec.Files)
+	if err != nil {
+		return err
+	}
+
+	archives := make([]Artifact, 0, len(c.outputs.artifacts))
+	for _, artifact := range c.outputs.artifacts {
+		// The binary name without the platform suffix
+		p := platform{os: artifact.OS, arch: artifact.Arch, variant: artifact.Variant}
+		name := strings.TrimSuffix(filepath.Base(artifact.Path), "-"+p.String())
+
+		format := archiveFormat(spec.Formats, artifact.OS)
+		service := c.services.tarArchive
+		switch format {
+		case formatTarGz:
+		case formatZip:
+			service = c.services.zipArchive
+		default:
+			return fmt.Errorf("invalid archive format for %s: %s", artifact.OS, format)
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, struct {
+			Name, Version, OS, Arch string
+		}{
+			Name:    name,
+			Version: artifact.Version,
+			OS:      artifact.OS,
+			Arch:    artifact.Arch + artifact.Variant,
+		}); err != nil {
+			return fmt.Errorf("invalid archive name template: %s", err)
+		}
+
+		binaryName := name
+		if artifact.OS == "windows" {
+			binaryName += ".exe"
+		}
+
+		path := filepath.Join(dir, buf.String()+"."+format)
+		entries := append([]archive.File{{Path: artifact.Path, Name: binaryName}}, files...)
+
+		if err := createArchive(service, path, entries); err != nil {
+			return err
+		}
+
+		archives = append(archives, Artifact{
+			Path:     path,
+			Label:    artifact.Label,
+			OS:       artifact.OS,
+			Arch:     artifact.Arch,
+			Variant:  artifact.Variant,
+			Main:     artifact.Main,
+			Version:  artifact.Version,
+			Duration: artifact.Duration,
+		})
+
+		c.ui.Printf("%s", path)
+	}
+
+	c.outputs.artifacts = archives
 
 	return nil
 }
 
+// archiveFiles returns the extra files for archiving with binaries.
+// If no file is specified, the default files are included if they exist.
+func archiveFiles(paths []string) ([]archive.File, error) {
+	files := []archive.File{}
+
+	if len(paths) == 0 {
+		for _, path := range defaultArchiveFiles {
+			if _, err := os.Stat(path); err == nil {
+				files = append(files, archive.File{Path: path, Name: path})
+			}
+		}
+		return files, nil
+	}
+
+	for _, path := range paths {
+		if _, err := os.Stat(path); err != nil {
+			return nil, err
+		}
+		files = append(files, archive.File{Path: path, Name: filepath.ToSlash(path)})
+	}
+
+	return files, nil
+}
+
+// archiveFormat returns the archive format for an OS.
+func archiveFormat(formats map[string]string, goos string) string {
+	if format, ok := formats[goos]; ok {
+		return format
+	}
+
+	if format, ok := defaultArchiveFormats[goos]; ok {
+		return format
+	}
+
+	return defaultArchiveFormat
+}
+
+func createArchive(service archiveService, path string, files []archive.File) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := service.Create(f, files); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// writeChecksums computes the checksums of all artifacts and writes them to checksums files in a directory.
+// The checksums files are also added to the artifacts, so they are uploaded with the artifacts.
+func (c *Command) writeChecksums(dir string) error {
+	sha256Sums := checksum.Checksums{}
+	sha512Sums := checksum.Checksums{}
+
+	for i, artifact := range c.outputs.artifacts {
+		name := filepath.Base(artifact.Path)
+
+		sum, err := checksum.SumFile(checksum.SHA256, artifact.Path)
+		if err != nil {
+			return err
+		}
+
+		c.outputs.artifacts[i].SHA256 = sum
+		sha256Sums[name] = sum
+
+		if c.spec.Project.Build.SHA512 {
+			sum, err := checksum.SumFile(checksum.SHA512, artifact.Path)
+			if err != nil {
+				return err
+			}
+
+			c.outputs.artifacts[i].SHA512 = sum
+			sha512Sums[name] = sum
+		}
+	}
+
+	path := filepath.Join(dir, checksumsFile)
+	if err := sha256Sums.WriteFile(path); err != nil {
+		return err
+	}
+
+	c.outputs.artifacts = append(c.outputs.artifacts, Artifact{Path: path})
+	c.ui.Printf("%s", path)
+
+	if c.spec.Project.Build.SHA512 {
+		path := filepath.Join(dir, checksumsSHA512File)
+		if err := sha512Sums.WriteFile(path); err != nil {
+			return err
+		}
+
+		c.outputs.artifacts = append(c.outputs.artifacts, Artifact{Path: path})
+		c.ui.Printf("%s", path)
+	}
+
+	return nil
+}
+
+// writeManifest writes the manifest of all artifacts to a directory.
+// The checksums of the artifacts should be already computed.
+func (c *Command) writeManifest(dir, version, commit string) (Manifest, error) {
+	artifacts, err := manifestEntries(c.outputs.artifacts, version, commit)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	// The images are listed separately, so they are not released with the artifacts
+	var images []ManifestEntry
+	if len(c.outputs.images) > 0 {
+		if images, err = manifestEntries(c.outputs.images, version, commit); err != nil {
+			return Manifest{}, err
+		}
+	}
+
+	manifest := Manifest{
+		Version:   version,
+		Commit:    commit,
+		Artifacts: artifacts,
+		Images:    images,
+	}
+
+	path := filepath.Join(dir, manifestFile)
+	if err := manifest.WriteFile(path); err != nil {
+		return Manifest{}, err
+	}
+
+	c.outputs.manifest = path
+	c.ui.Printf("%s", path)
+
+	return manifest, nil
+}
+
+// manifestEntries returns the manifest entries for a list of artifacts.
+// The missing checksums and the sizes of the artifacts are set in place.
+func manifestEntries(artifacts []Artifact, version, commit string) ([]ManifestEntry, error) {
+	entries := make([]ManifestEntry, 0, len(artifacts))
+
+	for i, artifact := range artifacts {
+		sha256 := artifact.SHA256
+		if sha256 == "" {
+			sum, err := checksum.SumFile(checksum.SHA256, artifact.Path)
+			if err != nil {
+				return nil, err
+			}
+			sha256 = sum
+		}
+
+		info, err := os.Stat(artifact.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		artifacts[i].SHA256 = sha256
+		artifacts[i].Size = info.Size()
+
+		// The artifacts of a module in a sub-directory are versioned separately
+		artifactVersion := artifact.Version
+		if artifactVersion == "" {
+			artifactVersion = version
+		}
+
+		entries = append(entries, ManifestEntry{
+			Path:       artifact.Path,
+			Label:      artifact.Label,
+			OS:         artifact.OS,
+			Arch:       artifact.Arch,
+			Variant:    artifact.Variant,
+			Main:       artifact.Main,
+			Version:    artifactVersion,
+			Commit:     commit,
+			SHA256:     sha256,
+			SHA512:     artifact.SHA512,
+			Size:       info.Size(),
+			DurationMS: artifact.Duration.Milliseconds(),
+		})
+	}
+
+	return entries, nil
+}
+
+// Manifest reads the build manifest written after the command is run.
+// If no manifest is written (e.g. no main package is found), an empty manifest is returned.
+func (c *Command) Manifest() (Manifest, error) {
+	if c.outputs.manifest == "" {
+		return Manifest{}, nil
+	}
+
+	return ReadManifest(c.outputs.manifest)
+}
+
 // Artifacts returns the build artifacts after the command is run.
 func (c *Command) Artifacts() []Artifact {
 	return c.outputs.artifacts
@@ -2,13 +2,22 @@ package build
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/gardenbed/charm/shell"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/gardenbed/basil-cli/internal/archive"
 	"github.com/gardenbed/basil-cli/internal/command"
+	gatecmd "github.com/gardenbed/basil-cli/internal/command/project/gate"
+	"github.com/gardenbed/basil-cli/internal/gomod"
 	"github.com/gardenbed/basil-cli/internal/semver"
 	"github.com/gardenbed/basil-cli/internal/spec"
 	"github.com/gardenbed/basil-cli/internal/ui"
@@ -60,9 +69,18 @@ func TestCommand_Run(t *testing.T) {
 
 		assert.NotNil(t, c.funcs.gitRevSHA)
 		assert.NotNil(t, c.funcs.gitRevBranch)
+		assert.NotNil(t, c.funcs.gitStatus)
+		assert.NotNil(t, c.funcs.gitRemoteURL)
+		assert.NotNil(t, c.funcs.goDistList)
 		assert.NotNil(t, c.funcs.goList)
+		assert.NotNil(t, c.funcs.goListDir)
 		assert.NotNil(t, c.funcs.goBuild)
+		assert.NotNil(t, c.funcs.dockerBuild)
+		assert.NotNil(t, c.services.tarArchive)
+		assert.NotNil(t, c.services.zipArchive)
+		assert.NotNil(t, c.services.registry)
 		assert.NotNil(t, c.commands.semver)
+		assert.NotNil(t, c.commands.gate)
 	})
 }
 
@@ -87,6 +105,16 @@ func TestCommand_parseFlags(t *testing.T) {
 			args: []string{
 				"-cross-compile",
 				"-platforms", "linux-arm64,darwin-arm64,windows-arm64",
+				"-sha512",
+				"-image",
+				"-docker-id", "octocat",
+				"-registry", "ghcr.io",
+				"-push",
+				"-parallel", "4",
+				"-no-cache",
+				"-json",
+				"-module", "./tools/gen,github.com/foo/bar",
+				"-skip-gates",
 			},
 			expectedExitCode: command.Success,
 		},
@@ -100,6 +128,25 @@ func TestCommand_parseFlags(t *testing.T) {
 			assert.Equal(t, tc.expectedExitCode, exitCode)
 		})
 	}
+
+	t.Run("VerifyImpliesReproducible", func(t *testing.T) {
+		c := &Command{ui: ui.NewNop()}
+		exitCode := c.parseFlags([]string{"-verify"})
+
+		assert.Equal(t, command.Success, exitCode)
+		assert.True(t, c.flags.verify)
+		assert.True(t, c.spec.Project.Build.Reproducible)
+	})
+
+	t.Run("DockerID", func(t *testing.T) {
+		c := &Command{ui: ui.NewNop()}
+		c.spec.Project.DockerID = "octocat"
+		assert.Equal(t, command.Success, c.parseFlags([]string{}))
+		assert.Equal(t, "octocat", c.spec.Project.DockerID)
+
+		assert.Equal(t, command.Success, c.parseFlags([]string{"-docker-id", "hubot"}))
+		assert.Equal(t, "hubot", c.spec.Project.DockerID)
+	})
 }
 
 func TestCommand_exec(t *testing.T) {
@@ -108,11 +155,64 @@ func TestCommand_exec(t *testing.T) {
 		spec             spec.Spec
 		gitRevSHA        shell.RunnerFunc
 		gitRevBranch     shell.RunnerFunc
-		goList           shell.RunnerFunc
+		gitCommitTime    shell.RunnerFunc
+		sourceDateEpoch  string
+		gitStatus        shell.RunnerFunc
+		gitRemoteURL     shell.RunnerFunc
+		goDistList       shell.RunnerFunc
+		goList           shell.RunnerWithFunc
 		goBuild          shell.RunnerWithFunc
 		semver           *MockSemverCommand
+		gate             *MockGateCommand
+		skipGates        bool
+		modules          string
 		expectedExitCode int
+		expectedGateArgs []string
 	}{
+		{
+			name: "GatesFail",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Gates: spec.Gates{Vet: true},
+				},
+			},
+			gate: &MockGateCommand{
+				RunMocks: []RunMock{
+					{OutCode: command.GateError},
+				},
+			},
+			expectedExitCode: command.GateError,
+			expectedGateArgs: []string{},
+		},
+		{
+			name: "GatesFail_SelectedModules",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Gates: spec.Gates{Vet: true},
+				},
+			},
+			modules: "./tools/gen",
+			gate: &MockGateCommand{
+				RunMocks: []RunMock{
+					{OutCode: command.GateError},
+				},
+			},
+			expectedExitCode: command.GateError,
+			expectedGateArgs: []string{"-module", "./tools/gen"},
+		},
+		{
+			name: "SkipGates",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Gates: spec.Gates{Vet: true},
+				},
+			},
+			skipGates: true,
+			gitRevSHA: func(context.Context, ...string) (int, string, error) {
+				return 1, "", errors.New("git error")
+			},
+			expectedExitCode: command.GitError,
+		},
 		{
 			name: "GitRevSHAFails",
 			spec: spec.Spec{
@@ -153,7 +253,7 @@ func TestCommand_exec(t *testing.T) {
 			gitRevBranch: func(context.Context, ...string) (int, string, error) {
 				return 0, "main", nil
 			},
-			goList: func(context.Context, ...string) (int, string, error) {
+			goList: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
 				return 0, "github.com/foo/bar/metadata", nil
 			},
 			semver: &MockSemverCommand{
@@ -163,6 +263,254 @@ func TestCommand_exec(t *testing.T) {
 			},
 			expectedExitCode: command.GitError,
 		},
+		{
+			name: "Reproducible_InvalidSourceDateEpoch",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Build: spec.Build{
+						Reproducible: true,
+					},
+				},
+			},
+			gitRevSHA: func(context.Context, ...string) (int, string, error) {
+				return 0, "7813389d2b09cdf851665b7848daa212b27e4e82", nil
+			},
+			gitRevBranch: func(context.Context, ...string) (int, string, error) {
+				return 0, "main", nil
+			},
+			sourceDateEpoch: "yesterday",
+			goList: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+				return 0, "github.com/foo/bar/metadata", nil
+			},
+			semver: &MockSemverCommand{
+				RunMocks: []RunMock{
+					{OutCode: command.Success},
+				},
+				SemVerMocks: []SemVerMock{
+					{OutSemVer: semver.SemVer{Major: 1}},
+				},
+			},
+			expectedExitCode: command.GenericError,
+		},
+		{
+			name: "Reproducible_GitCommitTimeFails",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Build: spec.Build{
+						Reproducible: true,
+					},
+				},
+			},
+			gitRevSHA: func(context.Context, ...string) (int, string, error) {
+				return 0, "7813389d2b09cdf851665b7848daa212b27e4e82", nil
+			},
+			gitRevBranch: func(context.Context, ...string) (int, string, error) {
+				return 0, "main", nil
+			},
+			gitCommitTime: func(context.Context, ...string) (int, string, error) {
+				return 1, "", errors.New("git error")
+			},
+			goList: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+				return 0, "github.com/foo/bar/metadata", nil
+			},
+			semver: &MockSemverCommand{
+				RunMocks: []RunMock{
+					{OutCode: command.Success},
+				},
+				SemVerMocks: []SemVerMock{
+					{OutSemVer: semver.SemVer{Major: 1}},
+				},
+			},
+			expectedExitCode: command.GitError,
+		},
+		{
+			name: "Reproducible_Success_NoArtifact",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Build: spec.Build{
+						Reproducible: true,
+					},
+				},
+			},
+			gitRevSHA: func(context.Context, ...string) (int, string, error) {
+				return 0, "7813389d2b09cdf851665b7848daa212b27e4e82", nil
+			},
+			gitRevBranch: func(context.Context, ...string) (int, string, error) {
+				return 0, "main", nil
+			},
+			gitCommitTime: func(context.Context, ...string) (int, string, error) {
+				return 0, "1767225600", nil
+			},
+			goList: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+				return 0, "github.com/foo/bar/metadata", nil
+			},
+			semver: &MockSemverCommand{
+				RunMocks: []RunMock{
+					{OutCode: command.Success},
+				},
+				SemVerMocks: []SemVerMock{
+					{OutSemVer: semver.SemVer{Major: 1}},
+				},
+			},
+			expectedExitCode: command.Success,
+		},
+		{
+			name: "GoDistListFails",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Build: spec.Build{
+						CrossCompile: true,
+						Platforms:    []string{"linux-amd64"},
+					},
+				},
+			},
+			gitRevSHA: func(context.Context, ...string) (int, string, error) {
+				return 0, "7813389d2b09cdf851665b7848daa212b27e4e82", nil
+			},
+			gitRevBranch: func(context.Context, ...string) (int, string, error) {
+				return 0, "main", nil
+			},
+			goDistList: func(context.Context, ...string) (int, string, error) {
+				return 1, "", errors.New("go error")
+			},
+			semver: &MockSemverCommand{
+				RunMocks: []RunMock{
+					{OutCode: command.Success},
+				},
+				SemVerMocks: []SemVerMock{
+					{OutSemVer: semver.SemVer{Major: 1}},
+				},
+			},
+			expectedExitCode: command.GoError,
+		},
+		{
+			name: "InvalidPlatforms",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Build: spec.Build{
+						CrossCompile: true,
+						Platforms:    []string{"linux-amd64", "plan10-amd64", "linux-arm-v9"},
+					},
+				},
+			},
+			gitRevSHA: func(context.Context, ...string) (int, string, error) {
+				return 0, "7813389d2b09cdf851665b7848daa212b27e4e82", nil
+			},
+			gitRevBranch: func(context.Context, ...string) (int, string, error) {
+				return 0, "main", nil
+			},
+			goDistList: func(context.Context, ...string) (int, string, error) {
+				return 0, "darwin/arm64\nlinux/amd64\nlinux/arm\n", nil
+			},
+			semver: &MockSemverCommand{
+				RunMocks: []RunMock{
+					{OutCode: command.Success},
+				},
+				SemVerMocks: []SemVerMock{
+					{OutSemVer: semver.SemVer{Major: 1}},
+				},
+			},
+			expectedExitCode: command.SpecError,
+		},
+		{
+			name: "InvalidMetadata",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Build: spec.Build{
+						Metadata: map[string]string{
+							"Build-Number": "{{.Env.BUILD_NUMBER}}",
+						},
+					},
+				},
+			},
+			gitRevSHA: func(context.Context, ...string) (int, string, error) {
+				return 0, "7813389d2b09cdf851665b7848daa212b27e4e82", nil
+			},
+			gitRevBranch: func(context.Context, ...string) (int, string, error) {
+				return 0, "main", nil
+			},
+			gitStatus: func(context.Context, ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			gitRemoteURL: func(context.Context, ...string) (int, string, error) {
+				return 0, "git@github.com:foo/bar.git", nil
+			},
+			semver: &MockSemverCommand{
+				RunMocks: []RunMock{
+					{OutCode: command.Success},
+				},
+				SemVerMocks: []SemVerMock{
+					{OutSemVer: semver.SemVer{Major: 1}},
+				},
+			},
+			expectedExitCode: command.SpecError,
+		},
+		{
+			name: "GitStatusFails",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Build: spec.Build{
+						Metadata: map[string]string{
+							"RepoURL": "{{.Git.RemoteURL}}",
+						},
+					},
+				},
+			},
+			gitRevSHA: func(context.Context, ...string) (int, string, error) {
+				return 0, "7813389d2b09cdf851665b7848daa212b27e4e82", nil
+			},
+			gitRevBranch: func(context.Context, ...string) (int, string, error) {
+				return 0, "main", nil
+			},
+			gitStatus: func(context.Context, ...string) (int, string, error) {
+				return 1, "", errors.New("git error")
+			},
+			gitRemoteURL: func(context.Context, ...string) (int, string, error) {
+				return 0, "git@github.com:foo/bar.git", nil
+			},
+			semver: &MockSemverCommand{
+				RunMocks: []RunMock{
+					{OutCode: command.Success},
+				},
+				SemVerMocks: []SemVerMock{
+					{OutSemVer: semver.SemVer{Major: 1}},
+				},
+			},
+			expectedExitCode: command.GitError,
+		},
+		{
+			name: "InvalidMetadataTemplate",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Build: spec.Build{
+						Metadata: map[string]string{
+							"RepoURL": "{{.Git.Remote}}",
+						},
+					},
+				},
+			},
+			gitRevSHA: func(context.Context, ...string) (int, string, error) {
+				return 0, "7813389d2b09cdf851665b7848daa212b27e4e82", nil
+			},
+			gitRevBranch: func(context.Context, ...string) (int, string, error) {
+				return 0, "main", nil
+			},
+			gitStatus: func(context.Context, ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			gitRemoteURL: func(context.Context, ...string) (int, string, error) {
+				return 0, "git@github.com:foo/bar.git", nil
+			},
+			semver: &MockSemverCommand{
+				RunMocks: []RunMock{
+					{OutCode: command.Success},
+				},
+				SemVerMocks: []SemVerMock{
+					{OutSemVer: semver.SemVer{Major: 1}},
+				},
+			},
+			expectedExitCode: command.TemplateError,
+		},
 		{
 			name: "Success_NoArtifact",
 			spec: spec.Spec{
@@ -176,7 +524,7 @@ func TestCommand_exec(t *testing.T) {
 			gitRevBranch: func(context.Context, ...string) (int, string, error) {
 				return 0, "main", nil
 			},
-			goList: func(context.Context, ...string) (int, string, error) {
+			goList: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
 				return 0, "github.com/foo/bar/metadata", nil
 			},
 			semver: &MockSemverCommand{
@@ -204,6 +552,8 @@ func TestCommand_exec(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(sourceDateEpochEnv, tc.sourceDateEpoch)
+
 			c := &Command{
 				ui:   ui.NewNop(),
 				spec: tc.spec,
@@ -211,57 +561,310 @@ func TestCommand_exec(t *testing.T) {
 
 			c.funcs.gitRevSHA = tc.gitRevSHA
 			c.funcs.gitRevBranch = tc.gitRevBranch
+			c.funcs.gitCommitTime = tc.gitCommitTime
+			c.funcs.gitStatus = tc.gitStatus
+			c.funcs.gitRemoteURL = tc.gitRemoteURL
+			c.funcs.goDistList = tc.goDistList
 			c.funcs.goList = tc.goList
 			c.funcs.goBuild = tc.goBuild
 			c.commands.semver = tc.semver
+			c.commands.gate = tc.gate
+			c.flags.skipGates = tc.skipGates
+			c.flags.modules = tc.modules
 
 			exitCode := c.exec()
 
 			assert.Equal(t, tc.expectedExitCode, exitCode)
+
+			if tc.expectedGateArgs != nil {
+				assert.Equal(t, tc.expectedGateArgs, tc.gate.RunMocks[0].InArgs)
+			}
 		})
 	}
 }
 
-func TestCommand_buildAll(t *testing.T) {
-	tests := []struct {
-		name          string
-		buildSpec     spec.Build
-		goBuild       shell.RunnerWithFunc
-		ctx           context.Context
-		ldFlags       string
-		mainPkg       string
-		output        string
-		expectedError string
-	}{
-		{
-			name: "WithoutCrossCompile_BuildFails",
-			buildSpec: spec.Build{
-				CrossCompile: false,
-			},
-			goBuild: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
-				return 1, "", errors.New("go build error")
-			},
-			ctx:           context.Background(),
-			ldFlags:       `-X "github.com/foo/bar/metadata.Version=1.0.0"`,
-			mainPkg:       "./cmd/app",
-			output:        "./bin/app",
-			expectedError: "go build error",
-		},
-		{
-			name: "WithoutCrossCompile_BuildSucceeds",
-			buildSpec: spec.Build{
-				CrossCompile: false,
-			},
-			goBuild: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
-				return 0, "github.com/foo/bar/metadata", nil
+func TestCommand_exec_JSON(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/app\n\ngo 1.21\n"), 0644))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "cmd", "app"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "cmd", "app", "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644))
+	t.Chdir(dir)
+
+	u := NewMockUI()
+
+	c := &Command{
+		ui: u,
+		spec: spec.Spec{
+			Project: spec.Project{
+				DockerID: "octocat",
+				Gates:    spec.Gates{Vet: true, NoReplace: true},
+				Build: spec.Build{
+					CrossCompile: true,
+					Platforms:    []string{"linux-amd64", "linux-arm64"},
+					Image: spec.Image{
+						Enabled: true,
+						Push:    true,
+					},
+				},
 			},
-			ctx:           context.Background(),
-			ldFlags:       `-X "github.com/foo/bar/metadata.Version=1.0.0"`,
-			mainPkg:       "./cmd/app",
-			output:        "./bin/app",
-			expectedError: "",
 		},
-		{
+	}
+
+	c.flags.json = true
+	c.funcs.gitRevSHA = func(context.Context, ...string) (int, string, error) {
+		return 0, "7813389d2b09cdf851665b7848daa212b27e4e82", nil
+	}
+	c.funcs.gitRevBranch = func(context.Context, ...string) (int, string, error) {
+		return 0, "main", nil
+	}
+	c.funcs.goDistList = func(context.Context, ...string) (int, string, error) {
+		return 0, "linux/amd64\nlinux/arm64", nil
+	}
+	c.funcs.goList = func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+		return 0, "", nil
+	}
+	c.funcs.goBuild = func(_ context.Context, _ shell.RunOptions, args ...string) (int, string, error) {
+		for i, arg := range args {
+			if arg == "-o" {
+				if err := os.MkdirAll(filepath.Dir(args[i+1]), 0755); err != nil {
+					return 1, "", err
+				}
+				return 0, "", os.WriteFile(args[i+1], []byte("binary"), 0755)
+			}
+		}
+		return 0, "", nil
+	}
+	c.services.registry = &MockImageRegistry{
+		PushMocks: []PushMock{
+			{OutError: nil},
+		},
+	}
+	c.commands.semver = &MockSemverCommand{
+		RunMocks: []RunMock{
+			{OutCode: command.Success},
+		},
+		SemVerMocks: []SemVerMock{
+			{OutSemVer: semver.SemVer{Major: 0, Minor: 1, Patch: 0}},
+		},
+	}
+	c.commands.gate = gatecmd.New(c.progressUI(), c.spec)
+
+	exitCode := c.exec()
+
+	assert.Equal(t, command.Success, exitCode)
+	assert.Empty(t, u.Stderr.String())
+	assert.True(t, json.Valid([]byte(u.Stdout.String())), "the standard output is not valid JSON:\n%s", u.Stdout.String())
+	assert.Contains(t, u.Stdout.String(), "app_0.1.0_image.tar")
+
+	// The injected UI is not changed
+	assert.Equal(t, ui.Info, u.Level)
+	assert.Same(t, u, c.ui)
+}
+
+func TestCommand_exec_Modules(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/app\n\ngo 1.21\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "services", "billing"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "services", "billing", "go.mod"), []byte("module example.com/billing\n\ngo 1.21\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "services", "billing", "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644))
+	t.Chdir(dir)
+
+	u := NewMockUI()
+
+	c := &Command{
+		ui: u,
+		spec: spec.Spec{
+			Project: spec.Project{
+				Build: spec.Build{
+					Metadata: map[string]string{"Release": "v{{.Version}}"},
+				},
+			},
+		},
+	}
+
+	var ldFlags []string
+
+	c.flags.skipGates = true
+	c.funcs.gitRevSHA = func(context.Context, ...string) (int, string, error) {
+		return 0, "7813389d2b09cdf851665b7848daa212b27e4e82", nil
+	}
+	c.funcs.gitRevBranch = func(context.Context, ...string) (int, string, error) {
+		return 0, "main", nil
+	}
+	c.funcs.gitStatus = func(context.Context, ...string) (int, string, error) {
+		return 0, "", nil
+	}
+	c.funcs.gitRemoteURL = func(context.Context, ...string) (int, string, error) {
+		return 0, "", nil
+	}
+	c.funcs.goList = func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+		return 0, "example.com/metadata", nil
+	}
+	c.funcs.goListDir = func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+		return 1, "", errors.New("package not found")
+	}
+	c.funcs.goBuild = func(_ context.Context, opts shell.RunOptions, args ...string) (int, string, error) {
+		for i, arg := range args {
+			switch arg {
+			case "-ldflags":
+				ldFlags = append(ldFlags, args[i+1])
+			case "-o":
+				// The output path is relative to the module directory
+				output := filepath.Join(opts.WorkingDir, args[i+1])
+				if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+					return 1, "", err
+				}
+				return 0, "", os.WriteFile(output, []byte("binary"), 0755)
+			}
+		}
+		return 0, "", nil
+	}
+	semverCmd := &MockSemverCommand{
+		RunMocks: []RunMock{
+			{OutCode: command.Success},
+			{OutCode: command.Success},
+		},
+		SemVerMocks: []SemVerMock{
+			{OutSemVer: semver.SemVer{Major: 0, Minor: 1, Patch: 0}},
+			{OutSemVer: semver.SemVer{Major: 2, Minor: 3, Patch: 0}},
+		},
+	}
+	c.commands.semver = semverCmd
+
+	exitCode := c.exec()
+
+	assert.Equal(t, command.Success, exitCode, u.Stderr.String())
+	assert.Equal(t, []string{"-dir", "services/billing"}, semverCmd.RunMocks[1].InArgs)
+
+	assert.Len(t, ldFlags, 2)
+	assert.Contains(t, ldFlags[0], `-X "example.com/metadata.Version=0.1.0"`)
+	assert.Contains(t, ldFlags[0], `-X "example.com/metadata.Release=v0.1.0"`)
+	assert.Contains(t, ldFlags[1], `-X "example.com/metadata.Version=2.3.0"`)
+	assert.Contains(t, ldFlags[1], `-X "example.com/metadata.Release=v2.3.0"`)
+
+	manifest, err := c.Manifest()
+	assert.NoError(t, err)
+	assert.Equal(t, "0.1.0", manifest.Version)
+	for _, entry := range manifest.Artifacts {
+		if strings.Contains(entry.Path, "billing") {
+			assert.Equal(t, "2.3.0", entry.Version)
+		} else {
+			assert.Equal(t, "0.1.0", entry.Version)
+		}
+	}
+}
+
+func TestCommand_resolvePlatforms(t *testing.T) {
+	// A toolchain without windows/arm
+	supported := parseDistList("darwin/amd64\ndarwin/arm64\nlinux/386\nlinux/amd64\nlinux/arm\nlinux/arm64\nwindows/386\nwindows/amd64\nwindows/arm64\n")
+
+	tests := []struct {
+		name              string
+		build             spec.Build
+		expectedError     string
+		expectedPlatforms []string
+		expectedTargets   []spec.Target
+	}{
+		{
+			name:          "UnsupportedPlatform",
+			build:         spec.Build{Platforms: []string{"linux-amd64", "windows-arm"}},
+			expectedError: "invalid platforms: windows-arm\nvalid platforms: darwin-amd64, darwin-arm64, linux-386, linux-amd64, linux-arm, linux-arm64, windows-386, windows-amd64, windows-arm64",
+		},
+		{
+			name: "UnsupportedTargetPlatform",
+			build: spec.Build{
+				Platforms: []string{"linux-amd64"},
+				Targets: []spec.Target{
+					{Name: "app", Platforms: []string{"windows-arm"}},
+				},
+			},
+			expectedError: "target app: invalid platforms: windows-arm\nvalid platforms: darwin-amd64, darwin-arm64, linux-386, linux-amd64, linux-arm, linux-arm64, windows-386, windows-amd64, windows-arm64",
+		},
+		{
+			name:  "DefaultPlatforms",
+			build: spec.Build{}.WithDefaults(),
+			expectedPlatforms: []string{
+				"linux-386", "linux-amd64", "linux-arm", "linux-arm64",
+				"darwin-amd64", "darwin-arm64",
+				"windows-386", "windows-amd64", "windows-arm64",
+			},
+			expectedTargets: []spec.Target{},
+		},
+		{
+			name: "Wildcards",
+			build: spec.Build{
+				Platforms: []string{"darwin-*"},
+				Targets: []spec.Target{
+					{Name: "app"},
+					{Name: "tool", Platforms: []string{"*-arm64"}},
+				},
+			},
+			expectedPlatforms: []string{"darwin-amd64", "darwin-arm64"},
+			expectedTargets: []spec.Target{
+				{Name: "app"},
+				{Name: "tool", Platforms: []string{"darwin-arm64", "linux-arm64", "windows-arm64"}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Command{ui: ui.NewNop()}
+			c.spec.Project.Build = tc.build
+
+			err := c.resolvePlatforms(supported)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedPlatforms, c.spec.Project.Build.Platforms)
+				assert.Equal(t, tc.expectedTargets, c.spec.Project.Build.Targets)
+			}
+		})
+	}
+}
+
+func TestCommand_buildAll(t *testing.T) {
+	tests := []struct {
+		name          string
+		buildSpec     spec.Build
+		inputsHash    string
+		cache         *MockCacheService
+		goBuild       shell.RunnerWithFunc
+		ctx           context.Context
+		ldFlags       string
+		targets       []spec.Target
+		expectedError string
+	}{
+		{
+			name: "WithoutCrossCompile_BuildFails",
+			buildSpec: spec.Build{
+				CrossCompile: false,
+			},
+			goBuild: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+				return 1, "", errors.New("go build error")
+			},
+			ctx:           context.Background(),
+			ldFlags:       `-X "github.com/foo/bar/metadata.Version=1.0.0"`,
+			targets:       []spec.Target{{Main: "./cmd/app", Name: "app"}},
+			expectedError: "1 of 1 builds failed:\n  ./bin/app: go build error",
+		},
+		{
+			name: "WithoutCrossCompile_BuildSucceeds",
+			buildSpec: spec.Build{
+				CrossCompile: false,
+			},
+			goBuild: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+				return 0, "github.com/foo/bar/metadata", nil
+			},
+			ctx:           context.Background(),
+			ldFlags:       `-X "github.com/foo/bar/metadata.Version=1.0.0"`,
+			targets:       []spec.Target{{Main: "./cmd/app", Name: "app"}},
+			expectedError: "",
+		},
+		{
 			name: "WithCrossCompile_BuildFails",
 			buildSpec: spec.Build{
 				CrossCompile: true,
@@ -272,9 +875,8 @@ func TestCommand_buildAll(t *testing.T) {
 			},
 			ctx:           context.Background(),
 			ldFlags:       `-X "github.com/foo/bar/metadata.Version=1.0.0"`,
-			mainPkg:       "./cmd/app",
-			output:        "./bin/app",
-			expectedError: "go build error",
+			targets:       []spec.Target{{Main: "./cmd/app", Name: "app"}},
+			expectedError: "3 of 3 builds failed:\n  ./bin/app-darwin-arm64: go build error\n  ./bin/app-linux-arm64: go build error\n  ./bin/app-windows-arm64: go build error",
 		},
 		{
 			name: "WithCrossCompile_BuildSucceeds",
@@ -287,8 +889,92 @@ func TestCommand_buildAll(t *testing.T) {
 			},
 			ctx:           context.Background(),
 			ldFlags:       `-X "github.com/foo/bar/metadata.Version=1.0.0"`,
-			mainPkg:       "./cmd/app",
-			output:        "./bin/app",
+			targets:       []spec.Target{{Main: "./cmd/app", Name: "app"}},
+			expectedError: "",
+		},
+		{
+			name: "WithCache_GetFails",
+			buildSpec: spec.Build{
+				CrossCompile: false,
+			},
+			inputsHash: "0a1b2c3d",
+			cache: &MockCacheService{
+				GetMocks: []GetMock{
+					{OutError: errors.New("cache error")},
+				},
+			},
+			ctx:           context.Background(),
+			ldFlags:       `-X "github.com/foo/bar/metadata.Version=1.0.0"`,
+			targets:       []spec.Target{{Main: "./cmd/app", Name: "app"}},
+			expectedError: "1 of 1 builds failed:\n  ./bin/app: cache error",
+		},
+		{
+			name: "WithCache_Hit",
+			buildSpec: spec.Build{
+				CrossCompile: true,
+				Platforms:    []string{"linux-arm64", "darwin-arm64", "windows-arm64"},
+			},
+			inputsHash: "0a1b2c3d",
+			cache: &MockCacheService{
+				GetMocks: []GetMock{
+					{OutOK: true},
+					{OutOK: true},
+					{OutOK: true},
+				},
+			},
+			goBuild: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+				return 1, "", errors.New("go build should not be called")
+			},
+			ctx:           context.Background(),
+			ldFlags:       `-X "github.com/foo/bar/metadata.Version=1.0.0"`,
+			targets:       []spec.Target{{Main: "./cmd/app", Name: "app"}},
+			expectedError: "",
+		},
+		{
+			name: "WithCache_Miss_PutFails",
+			buildSpec: spec.Build{
+				CrossCompile: false,
+			},
+			inputsHash: "0a1b2c3d",
+			cache: &MockCacheService{
+				GetMocks: []GetMock{
+					{OutOK: false},
+				},
+				PutMocks: []PutMock{
+					{OutError: errors.New("cache error")},
+				},
+			},
+			goBuild: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			ctx:           context.Background(),
+			ldFlags:       `-X "github.com/foo/bar/metadata.Version=1.0.0"`,
+			targets:       []spec.Target{{Main: "./cmd/app", Name: "app"}},
+			expectedError: "",
+		},
+		{
+			name: "WithCache_Miss_PutSucceeds",
+			buildSpec: spec.Build{
+				CrossCompile: true,
+				Platforms:    []string{"linux-arm64", "darwin-arm64"},
+			},
+			inputsHash: "0a1b2c3d",
+			cache: &MockCacheService{
+				GetMocks: []GetMock{
+					{OutOK: false},
+					{OutOK: false},
+				},
+				PutMocks: []PutMock{
+					{OutError: nil},
+					{OutError: nil},
+				},
+			},
+			goBuild: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			ctx:           context.Background(),
+			ldFlags:       `-X "github.com/foo/bar/metadata.Version=1.0.0"`,
+			targets:       []spec.Target{{Main: "./cmd/app", Name: "app"}},
 			expectedError: "",
 		},
 	}
@@ -304,22 +990,778 @@ func TestCommand_buildAll(t *testing.T) {
 				},
 			}
 
+			c.inputs.hash = tc.inputsHash
 			c.funcs.goBuild = tc.goBuild
+			if tc.cache != nil {
+				c.services.cache = tc.cache
+			}
+
+			err := c.buildAll(tc.ctx, module{Module: gomod.Module{Dir: "."}, targets: tc.targets, ldFlags: tc.ldFlags}, binPath)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				if tc.cache != nil {
+					assert.Len(t, c.outputs.artifacts, tc.cache.GetIndex)
+				}
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestCommand_buildAll_Parallel(t *testing.T) {
+	var mu sync.Mutex
+	var running, maxRunning int
+
+	c := &Command{
+		ui: ui.NewNop(),
+		spec: spec.Spec{
+			Project: spec.Project{
+				Build: spec.Build{
+					CrossCompile: true,
+					Platforms:    []string{"linux-amd64", "linux-arm64", "darwin-amd64", "darwin-arm64", "windows-amd64"},
+					Parallel:     2,
+				},
+			},
+		},
+	}
+
+	c.funcs.goBuild = func(_ context.Context, opts shell.RunOptions, args ...string) (int, string, error) {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+
+		if args[len(args)-1] == "./cmd/client" && opts.Environment["GOOS"] == "windows" {
+			return 1, "", errors.New("go build error")
+		}
+
+		return 0, "", nil
+	}
+
+	targets := []spec.Target{
+		{Main: "./cmd/client", Name: "client"},
+		{Main: "./cmd/server", Name: "server", Platforms: []string{"linux-amd64", "linux-arm64"}},
+	}
+
+	err := c.buildAll(context.Background(), module{Module: gomod.Module{Dir: "."}, targets: targets}, binPath)
+
+	assert.EqualError(t, err, "1 of 7 builds failed:\n  ./bin/client-windows-amd64: go build error")
+	assert.Equal(t, 2, maxRunning)
+	assert.Len(t, c.outputs.artifacts, 6)
+}
+
+func TestCommand_moduleTargets(t *testing.T) {
+	tests := []struct {
+		name            string
+		targets         []spec.Target
+		module          module
+		dirs            []string
+		files           []string
+		expectedTargets []spec.Target
+	}{
+		{
+			name:            "NoMainPackage",
+			module:          module{Module: gomod.Module{Dir: "."}},
+			expectedTargets: []spec.Target{},
+		},
+		{
+			name:   "Convention",
+			module: module{Module: gomod.Module{Dir: "."}},
+			dirs:   []string{"cmd/client", "cmd/server"},
+			files:  []string{"main.go"},
+			expectedTargets: []spec.Target{
+				{Main: "./cmd/client", Name: "client"},
+				{Main: "./cmd/server", Name: "server"},
+				{Main: ".", Name: "app"},
+			},
+		},
+		{
+			name: "FromSpec",
+			targets: []spec.Target{
+				{Main: "./cmd/server", Name: "api", Tags: []string{"netgo"}},
+				{Main: "./cmd/client"},
+				{},
+				{Module: "tools/gen", Main: "."},
+			},
+			module: module{Module: gomod.Module{Dir: "."}},
+			dirs:   []string{"cmd/client", "cmd/server"},
+			files:  []string{"main.go"},
+			expectedTargets: []spec.Target{
+				{Main: "./cmd/server", Name: "api", Tags: []string{"netgo"}},
+				{Main: "./cmd/client", Name: "client"},
+				{Main: ".", Name: "app"},
+			},
+		},
+		{
+			name:   "NestedModule_Convention",
+			module: module{Module: gomod.Module{Dir: "tools/gen", Path: "github.com/foo/bar/tools/gen"}},
+			dirs:   []string{"cmd/app", "tools/gen/cmd/lint"},
+			files:  []string{"main.go", "tools/gen/main.go"},
+			expectedTargets: []spec.Target{
+				{Main: "./cmd/lint", Name: "lint"},
+				{Main: ".", Name: "gen"},
+			},
+		},
+		{
+			name: "NestedModule_FromSpec",
+			targets: []spec.Target{
+				{Main: "./cmd/server"},
+				{Module: "github.com/foo/bar/tools/gen"},
+				{Module: "tools/gen", Main: "./cmd/lint", Name: "linter"},
+			},
+			module: module{Module: gomod.Module{Dir: "tools/gen", Path: "github.com/foo/bar/tools/gen"}},
+			expectedTargets: []spec.Target{
+				{Module: "github.com/foo/bar/tools/gen", Main: ".", Name: "gen"},
+				{Module: "tools/gen", Main: "./cmd/lint", Name: "linter"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, d := range tc.dirs {
+				assert.NoError(t, os.MkdirAll(filepath.Join(dir, d), 0755))
+			}
+			for _, f := range tc.files {
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, f), []byte("package main\n"), 0644))
+			}
+
+			wd, err := os.Getwd()
+			assert.NoError(t, err)
+			assert.NoError(t, os.Chdir(dir))
+			defer func() {
+				assert.NoError(t, os.Chdir(wd))
+			}()
+
+			c := &Command{
+				ui: ui.NewNop(),
+				spec: spec.Spec{
+					Project: spec.Project{
+						Build: spec.Build{
+							Targets: tc.targets,
+						},
+					},
+				},
+			}
+
+			targets, err := c.moduleTargets("/path/to/app", tc.module)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedTargets, targets)
+		})
+	}
+}
+
+func TestCommand_build(t *testing.T) {
+	cgoDisabled := false
+
+	var opts shell.RunOptions
+	var args []string
+
+	c := &Command{ui: ui.NewNop()}
+	c.funcs.goBuild = func(_ context.Context, o shell.RunOptions, a ...string) (int, string, error) {
+		opts, args = o, a
+		return 0, "", nil
+	}
+
+	target := spec.Target{
+		Main:       "./cmd/server",
+		Name:       "server",
+		Tags:       []string{"netgo", "osusergo"},
+		CGOEnabled: &cgoDisabled,
+		TrimPath:   true,
+		LDFlags:    "-s -w",
+		Env: map[string]string{
+			"GOAMD64": "v3",
+		},
+	}
+
+	mod := module{
+		Module:  gomod.Module{Dir: "."},
+		ldFlags: `-X "github.com/foo/bar/metadata.Version=1.0.0"`,
+	}
+
+	job := buildJob{
+		target: target,
+		goos:   "linux",
+		goarch: "amd64",
+		output: "./bin/server-linux-amd64",
+	}
+
+	cached, err := c.build(context.Background(), mod, job)
+
+	assert.NoError(t, err)
+	assert.False(t, cached)
+	assert.Equal(t, map[string]string{
+		"GOOS":        "linux",
+		"GOARCH":      "amd64",
+		"GOAMD64":     "v3",
+		"CGO_ENABLED": "0",
+	}, opts.Environment)
+	assert.Equal(t, []string{
+		"-tags", "netgo,osusergo",
+		"-trimpath",
+		"-ldflags", `-X "github.com/foo/bar/metadata.Version=1.0.0" -s -w`,
+		"-o", "./bin/server-linux-amd64",
+		"./cmd/server",
+	}, args)
+	assert.Len(t, c.outputs.artifacts, 1)
+	assert.Equal(t, "./bin/server-linux-amd64", c.outputs.artifacts[0].Path)
+	assert.Equal(t, "linux", c.outputs.artifacts[0].OS)
+	assert.Equal(t, "amd64", c.outputs.artifacts[0].Arch)
+	assert.Equal(t, "./cmd/server", c.outputs.artifacts[0].Main)
+}
+
+func TestCommand_build_Variant(t *testing.T) {
+	var opts shell.RunOptions
+
+	c := &Command{ui: ui.NewNop()}
+	c.funcs.goBuild = func(_ context.Context, o shell.RunOptions, a ...string) (int, string, error) {
+		opts = o
+		return 0, "", nil
+	}
+
+	job := buildJob{
+		target:  spec.Target{Main: ".", Name: "app"},
+		goos:    "linux",
+		goarch:  "arm",
+		variant: "v7",
+		output:  "./bin/app-linux-arm-v7",
+	}
+
+	cached, err := c.build(context.Background(), module{Module: gomod.Module{Dir: "."}}, job)
 
-			err := c.buildAll(tc.ctx, tc.ldFlags, tc.mainPkg, tc.output)
+	assert.NoError(t, err)
+	assert.False(t, cached)
+	assert.Equal(t, map[string]string{
+		"GOOS":   "linux",
+		"GOARCH": "arm",
+		"GOARM":  "7",
+	}, opts.Environment)
+	assert.Len(t, c.outputs.artifacts, 1)
+	assert.Equal(t, "arm", c.outputs.artifacts[0].Arch)
+	assert.Equal(t, "v7", c.outputs.artifacts[0].Variant)
+}
+
+func TestCommand_build_Reproducible(t *testing.T) {
+	var opts shell.RunOptions
+	var args []string
+
+	c := &Command{
+		ui: ui.NewNop(),
+		spec: spec.Spec{
+			Project: spec.Project{
+				Build: spec.Build{
+					Reproducible: true,
+				},
+			},
+		},
+	}
+
+	c.funcs.goBuild = func(_ context.Context, o shell.RunOptions, a ...string) (int, string, error) {
+		opts, args = o, a
+		return 0, "", nil
+	}
+
+	job := buildJob{
+		target: spec.Target{Main: "./cmd/app", Name: "app"},
+		goos:   "linux",
+		goarch: "amd64",
+		output: "./bin/app-linux-amd64",
+	}
+
+	_, err := c.build(context.Background(), module{Module: gomod.Module{Dir: "."}}, job)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"GOOS":        "linux",
+		"GOARCH":      "amd64",
+		"GOFLAGS":     "",
+		"CGO_ENABLED": "0",
+	}, opts.Environment)
+	assert.Equal(t, []string{
+		"-trimpath",
+		"-buildvcs=false",
+		"-o", "./bin/app-linux-amd64",
+		"./cmd/app",
+	}, args)
+}
+
+func TestCommand_verifyBuilds(t *testing.T) {
+	// writeOutput returns a mock go build writing a content to the output file.
+	writeOutput := func(content string) shell.RunnerWithFunc {
+		return func(_ context.Context, _ shell.RunOptions, args ...string) (int, string, error) {
+			for i, arg := range args {
+				if arg == "-o" {
+					return 0, "", os.WriteFile(args[i+1], []byte(content), 0755)
+				}
+			}
+			return 0, "", nil
+		}
+	}
+
+	tests := []struct {
+		name          string
+		build         shell.RunnerWithFunc
+		rebuild       shell.RunnerWithFunc
+		expectedOK    bool
+		expectedError string
+	}{
+		{
+			name:  "RebuildFails",
+			build: writeOutput("binary"),
+			rebuild: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+				return 1, "", errors.New("go build error")
+			},
+			expectedError: "1 of 1 builds failed",
+		},
+		{
+			name:       "NotReproducible",
+			build:      writeOutput("binary"),
+			rebuild:    writeOutput("another binary"),
+			expectedOK: false,
+		},
+		{
+			name:       "Reproducible",
+			build:      writeOutput("binary"),
+			rebuild:    writeOutput("binary"),
+			expectedOK: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			targets := []spec.Target{{Main: "./cmd/app", Name: "app"}}
+
+			c := &Command{
+				ui: ui.NewNop(),
+				spec: spec.Spec{
+					Project: spec.Project{
+						Build: spec.Build{
+							Reproducible: true,
+						},
+					},
+				},
+			}
+
+			c.services.cache = &MockCacheService{}
+			c.funcs.goBuild = tc.build
+			modules := []module{{Module: gomod.Module{Dir: "."}, targets: targets}}
+			assert.NoError(t, c.buildModules(context.Background(), dir+string(filepath.Separator), modules))
+
+			c.funcs.goBuild = tc.rebuild
+			ok, err := c.verifyBuilds(context.Background(), modules)
 
 			if tc.expectedError == "" {
 				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedOK, ok)
 			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+			}
+
+			// The artifacts and the cache are restored
+			assert.Len(t, c.outputs.artifacts, 1)
+			assert.NotNil(t, c.services.cache)
+		})
+	}
+}
+
+func TestTargetInputs(t *testing.T) {
+	cgoEnabled := true
+
+	base := spec.Target{Main: "./cmd/app", Name: "app"}
+	inputs := targetInputs(base)
+
+	// The output name does not affect the binary
+	assert.Equal(t, inputs, targetInputs(spec.Target{Main: "./cmd/app", Name: "other"}))
+
+	for _, target := range []spec.Target{
+		{Main: "./cmd/app", Tags: []string{"netgo"}},
+		{Main: "./cmd/app", CGOEnabled: &cgoEnabled},
+		{Main: "./cmd/app", TrimPath: true},
+		{Main: "./cmd/app", LDFlags: "-s -w"},
+		{Main: "./cmd/app", Env: map[string]string{"GOAMD64": "v3"}},
+	} {
+		assert.NotEqual(t, inputs, targetInputs(target))
+	}
+}
+
+func TestEnvInputs(t *testing.T) {
+	for _, key := range cacheEnvVars {
+		t.Setenv(key, "")
+	}
+
+	inputs := envInputs()
+	assert.Equal(t, []string{"GOFLAGS=", "CGO_ENABLED=", "CC=", "GOEXPERIMENT="}, inputs)
+
+	for _, key := range cacheEnvVars {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, "1")
+			assert.NotEqual(t, inputs, envInputs())
+		})
+	}
+}
+
+func TestCommand_archiveAll(t *testing.T) {
+	tests := []struct {
+		name             string
+		archive          spec.Archive
+		artifacts        []Artifact
+		tarArchive       *MockArchiveService
+		zipArchive       *MockArchiveService
+		expectedError    string
+		expectedArchives []string
+		expectedFiles    [][]archive.File
+	}{
+		{
+			name: "InvalidNameTemplate",
+			archive: spec.Archive{
+				Name: "{{.Name",
+			},
+			expectedError: "invalid archive name template: template: archive:1: unclosed action",
+		},
+		{
+			name: "FileNotFound",
+			archive: spec.Archive{
+				Files: []string{"missing"},
+			},
+			expectedError: "stat missing: no such file or directory",
+		},
+		{
+			name: "InvalidFormat",
+			archive: spec.Archive{
+				Formats: map[string]string{"linux": "rar"},
+			},
+			artifacts: []Artifact{
+				{Path: "app-linux-amd64", OS: "linux", Arch: "amd64", Version: "0.1.0"},
+			},
+			expectedError: "invalid archive format for linux: rar",
+		},
+		{
+			name: "CreateFails",
+			artifacts: []Artifact{
+				{Path: "app-linux-amd64", OS: "linux", Arch: "amd64", Version: "0.1.0"},
+			},
+			tarArchive: &MockArchiveService{
+				CreateMocks: []CreateMock{
+					{OutError: errors.New("error on opening file")},
+				},
+			},
+			expectedError: "error on opening file",
+		},
+		{
+			name: "Success",
+			archive: spec.Archive{
+				Files: []string{"README.md"},
+			},
+			artifacts: []Artifact{
+				{Path: "app-linux-amd64", OS: "linux", Arch: "amd64", Version: "0.1.0"},
+				{Path: "app-windows-amd64", OS: "windows", Arch: "amd64", Version: "0.2.0"},
+			},
+			tarArchive: &MockArchiveService{
+				CreateMocks: []CreateMock{{}},
+			},
+			zipArchive: &MockArchiveService{
+				CreateMocks: []CreateMock{{}},
+			},
+			expectedArchives: []string{"app_0.1.0_linux_amd64.tar.gz", "app_0.2.0_windows_amd64.zip"},
+			expectedFiles: [][]archive.File{
+				{{Path: "app-linux-amd64", Name: "app"}, {Path: "README.md", Name: "README.md"}},
+				{{Path: "app-windows-amd64", Name: "app.exe"}, {Path: "README.md", Name: "README.md"}},
+			},
+		},
+		{
+			name: "CustomFormatAndName",
+			archive: spec.Archive{
+				Formats: map[string]string{"linux": "zip"},
+				Files:   []string{"README.md"},
+				Name:    "{{.Name}}-{{.OS}}-{{.Arch}}",
+			},
+			artifacts: []Artifact{
+				{Path: "app-linux-arm64", OS: "linux", Arch: "arm64", Version: "0.1.0"},
+			},
+			zipArchive: &MockArchiveService{
+				CreateMocks: []CreateMock{{}},
+			},
+			expectedArchives: []string{"app-linux-arm64.zip"},
+			expectedFiles: [][]archive.File{
+				{{Path: "app-linux-arm64", Name: "app"}, {Path: "README.md", Name: "README.md"}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			wd, err := os.Getwd()
+			assert.NoError(t, err)
+			assert.NoError(t, os.Chdir(dir))
+			defer func() {
+				assert.NoError(t, os.Chdir(wd))
+			}()
+
+			assert.NoError(t, os.WriteFile("README.md", []byte("readme"), 0644))
+
+			c := &Command{ui: ui.NewNop()}
+			c.spec.Project.Build.Archive = tc.archive
+			c.services.tarArchive = tc.tarArchive
+			c.services.zipArchive = tc.zipArchive
+			c.outputs.artifacts = tc.artifacts
+
+			err = c.archiveAll(dir)
+
+			if tc.expectedError != "" {
 				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, c.outputs.artifacts, len(tc.expectedArchives))
+
+			for i, name := range tc.expectedArchives {
+				assert.Equal(t, filepath.Join(dir, name), c.outputs.artifacts[i].Path)
+				assert.FileExists(t, c.outputs.artifacts[i].Path)
+				assert.Equal(t, tc.artifacts[i].Version, c.outputs.artifacts[i].Version)
+			}
+
+			var files [][]archive.File
+			for _, service := range []*MockArchiveService{tc.tarArchive, tc.zipArchive} {
+				if service != nil {
+					for _, m := range service.CreateMocks {
+						files = append(files, m.InFiles)
+					}
+				}
+			}
+			assert.Equal(t, tc.expectedFiles, files)
+		})
+	}
+}
+
+func TestCommand_writeChecksums(t *testing.T) {
+	const (
+		helloSHA256 = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+		helloSHA512 = "9b71d224bd62f3785d96d46ad3ea3d73319bfbc2890caadae2dff72519673ca72323c3d99ba5c11d7c7acc6e14b8c5da0c4663475c2e5c3adef46f73bcdec043"
+	)
+
+	tests := []struct {
+		name              string
+		sha512            bool
+		artifacts         []string
+		expectedError     bool
+		expectedChecksums map[string]string
+	}{
+		{
+			name:          "ArtifactNotFound",
+			artifacts:     []string{"missing"},
+			expectedError: true,
+		},
+		{
+			name:      "SHA256",
+			artifacts: []string{"app-linux-amd64", "app-darwin-arm64"},
+			expectedChecksums: map[string]string{
+				checksumsFile: helloSHA256 + "  app-darwin-arm64\n" + helloSHA256 + "  app-linux-amd64\n",
+			},
+		},
+		{
+			name:      "SHA512",
+			sha512:    true,
+			artifacts: []string{"app"},
+			expectedChecksums: map[string]string{
+				checksumsFile:       helloSHA256 + "  app\n",
+				checksumsSHA512File: helloSHA512 + "  app\n",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			c := &Command{ui: ui.NewNop()}
+			c.spec.Project.Build.SHA512 = tc.sha512
+
+			for _, name := range tc.artifacts {
+				path := filepath.Join(dir, name)
+				if name != "missing" {
+					assert.NoError(t, os.WriteFile(path, []byte("hello"), 0755))
+				}
+				c.outputs.artifacts = append(c.outputs.artifacts, Artifact{Path: path})
+			}
+
+			err := c.writeChecksums(dir)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, c.outputs.artifacts, len(tc.artifacts)+len(tc.expectedChecksums))
+
+			for i := range tc.artifacts {
+				assert.Equal(t, helloSHA256, c.outputs.artifacts[i].SHA256)
+				if tc.sha512 {
+					assert.Equal(t, helloSHA512, c.outputs.artifacts[i].SHA512)
+				}
+			}
+
+			for file, expectedContent := range tc.expectedChecksums {
+				b, err := os.ReadFile(filepath.Join(dir, file))
+				assert.NoError(t, err)
+				assert.Equal(t, expectedContent, string(b))
 			}
 		})
 	}
 }
 
+func TestCommand_writeManifest(t *testing.T) {
+	const helloSHA256 = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+	t.Run("ArtifactMissing", func(t *testing.T) {
+		dir := t.TempDir()
+
+		c := &Command{ui: ui.NewNop()}
+		c.outputs.artifacts = []Artifact{
+			{Path: filepath.Join(dir, "missing")},
+		}
+
+		_, err := c.writeManifest(dir, "0.1.0", "7813389d2b09cdf851665b7848daa212b27e4e82")
+
+		assert.Error(t, err)
+		assert.NoFileExists(t, filepath.Join(dir, manifestFile))
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		dir := t.TempDir()
+		app := filepath.Join(dir, "app-linux-amd64")
+		checksums := filepath.Join(dir, checksumsFile)
+		assert.NoError(t, os.WriteFile(app, []byte("hello"), 0755))
+		assert.NoError(t, os.WriteFile(checksums, []byte("hello"), 0644))
+
+		c := &Command{ui: ui.NewNop()}
+		c.outputs.artifacts = []Artifact{
+			{Path: app, OS: "linux", Arch: "amd64", Main: "./cmd/app", SHA256: helloSHA256, Duration: 1500 * time.Millisecond},
+			{Path: checksums},
+		}
+
+		manifest, err := c.writeManifest(dir, "0.1.0", "7813389d2b09cdf851665b7848daa212b27e4e82")
+
+		assert.NoError(t, err)
+		assert.Equal(t, Manifest{
+			Version: "0.1.0",
+			Commit:  "7813389d2b09cdf851665b7848daa212b27e4e82",
+			Artifacts: []ManifestEntry{
+				{
+					Path:       app,
+					OS:         "linux",
+					Arch:       "amd64",
+					Main:       "./cmd/app",
+					Version:    "0.1.0",
+					Commit:     "7813389d2b09cdf851665b7848daa212b27e4e82",
+					SHA256:     helloSHA256,
+					Size:       5,
+					DurationMS: 1500,
+				},
+				{
+					Path:    checksums,
+					Version: "0.1.0",
+					Commit:  "7813389d2b09cdf851665b7848daa212b27e4e82",
+					SHA256:  helloSHA256,
+					Size:    5,
+				},
+			},
+		}, manifest)
+
+		read, err := c.Manifest()
+
+		assert.NoError(t, err)
+		assert.Equal(t, manifest, read)
+		assert.Equal(t, int64(5), c.outputs.artifacts[0].Size)
+	})
+
+	t.Run("WithImages", func(t *testing.T) {
+		dir := t.TempDir()
+		app := filepath.Join(dir, "app-linux-amd64")
+		img := filepath.Join(dir, "app_0.1.0_image.tar")
+		assert.NoError(t, os.WriteFile(app, []byte("hello"), 0755))
+		assert.NoError(t, os.WriteFile(img, []byte("hello"), 0644))
+
+		c := &Command{ui: ui.NewNop()}
+		c.outputs.artifacts = []Artifact{
+			{Path: app, OS: "linux", Arch: "amd64", SHA256: helloSHA256},
+		}
+		c.outputs.images = []Artifact{
+			{Path: img, Label: "docker.io/octocat/app:0.1.0", OS: "linux"},
+		}
+
+		manifest, err := c.writeManifest(dir, "0.1.0", "7813389d2b09cdf851665b7848daa212b27e4e82")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []ManifestEntry{
+			{
+				Path:    app,
+				OS:      "linux",
+				Arch:    "amd64",
+				Version: "0.1.0",
+				Commit:  "7813389d2b09cdf851665b7848daa212b27e4e82",
+				SHA256:  helloSHA256,
+				Size:    5,
+			},
+		}, manifest.Artifacts)
+		assert.Equal(t, []ManifestEntry{
+			{
+				Path:    img,
+				Label:   "docker.io/octocat/app:0.1.0",
+				OS:      "linux",
+				Version: "0.1.0",
+				Commit:  "7813389d2b09cdf851665b7848daa212b27e4e82",
+				SHA256:  helloSHA256,
+				Size:    5,
+			},
+		}, manifest.Images)
+
+		// The images are not released with the artifacts
+		artifacts := manifest.ToArtifacts()
+		assert.Len(t, artifacts, 1)
+		assert.Equal(t, app, artifacts[0].Path)
+	})
+}
+
+func TestCommand_Manifest(t *testing.T) {
+	t.Run("NotWritten", func(t *testing.T) {
+		c := new(Command)
+		manifest, err := c.Manifest()
+
+		assert.NoError(t, err)
+		assert.Equal(t, Manifest{}, manifest)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		c := new(Command)
+		c.outputs.manifest = filepath.Join(t.TempDir(), manifestFile)
+		_, err := c.Manifest()
+
+		assert.Error(t, err)
+	})
+}
+
 func TestCommand_Artifacts(t *testing.T) {
 	artifacts := []Artifact{
-		{"bin/app", "linux"},
+		{Path: "bin/app", Label: "linux"},
 	}
 
 	c := new(Command)
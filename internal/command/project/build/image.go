@@ -0,0 +1,276 @@
+package build
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gardenbed/charm/shell"
+
+	"github.com/gardenbed/basil-cli/internal/image"
+	"github.com/gardenbed/basil-cli/internal/ui"
+)
+
+const (
+	defaultDockerfile = "Dockerfile"
+
+	registryUsernameEnv = "BASIL_REGISTRY_USERNAME"
+	registryPasswordEnv = "BASIL_REGISTRY_PASSWORD"
+)
+
+// imageVariants are the architectures with variants defined for images.
+// The variants of these architectures have the same names in Go and OCI images (e.g. arm/v7 and amd64/v3).
+var imageVariants = map[string]bool{
+	"amd64": true,
+	"arm":   true,
+}
+
+// imageTarget is an image built from the Linux binaries of a target.
+type imageTarget struct {
+	name     string
+	version  string
+	ref      image.Reference
+	binaries []image.Binary
+}
+
+// imagePlatform returns the image platform for a build platform.
+func imagePlatform(p platform) image.Platform {
+	ip := image.Platform{
+		OS:           p.os,
+		Architecture: p.arch,
+	}
+
+	if imageVariants[p.arch] {
+		ip.Variant = p.variant
+	}
+
+	return ip
+}
+
+// imageTargets groups the Linux binary artifacts by name into images.
+// The images are tagged as [registry/]dockerid/name:version with the version of the binaries.
+func (c *Command) imageTargets() ([]imageTarget, error) {
+	spec := c.spec.Project.Build.Image
+	dockerID := c.spec.Project.DockerID
+
+	if dockerID == "" {
+		return nil, errors.New("docker id is required for building images: set project.docker_id in the spec file or use -docker-id")
+	}
+
+	targets := []imageTarget{}
+	indices := map[string]int{}
+	platforms := map[string]string{}
+
+	for _, artifact := range c.outputs.artifacts {
+		if artifact.OS != "linux" {
+			continue
+		}
+
+		// The binary name without the platform suffix
+		p := platform{os: artifact.OS, arch: artifact.Arch, variant: artifact.Variant}
+		name := strings.TrimSuffix(filepath.Base(artifact.Path), "-"+p.String())
+
+		ip := imagePlatform(p)
+		key := name + " " + ip.String()
+		if other, ok := platforms[key]; ok {
+			return nil, fmt.Errorf("cannot build image for %s: %s and %s are both for %s", name, other, p, ip)
+		}
+		platforms[key] = p.String()
+
+		i, ok := indices[name]
+		if !ok {
+			repository := dockerID + "/" + strings.ToLower(name)
+			if spec.Registry != "" {
+				repository = spec.Registry + "/" + repository
+			}
+
+			ref, err := image.ParseReference(repository + ":" + image.Tag(artifact.Version))
+			if err != nil {
+				return nil, err
+			}
+
+			i = len(targets)
+			indices[name] = i
+			targets = append(targets, imageTarget{
+				name:    name,
+				version: artifact.Version,
+				ref:     ref,
+			})
+		}
+
+		targets[i].binaries = append(targets[i].binaries, image.Binary{
+			Path:     artifact.Path,
+			Platform: ip,
+		})
+	}
+
+	return targets, nil
+}
+
+// dockerfile returns the Dockerfile for building images.
+// If no Dockerfile is specified, the Dockerfile in the working directory is used if it exists.
+func (c *Command) dockerfile() string {
+	if path := c.spec.Project.Build.Image.Dockerfile; path != "" {
+		return path
+	}
+
+	if _, err := os.Stat(defaultDockerfile); err == nil {
+		return defaultDockerfile
+	}
+
+	return ""
+}
+
+// buildImages builds an image for every target with Linux binaries and writes it as an OCI image layout tarball to a directory.
+// If a Dockerfile is found, the images are built with docker buildx; otherwise, minimal images are assembled from the binaries.
+// If pushing is enabled, the images are pushed to the registry.
+func (c *Command) buildImages(ctx context.Context, dir, commit string, created time.Time) error {
+	targets, err := c.imageTargets()
+	if err != nil {
+		return err
+	}
+
+	if len(targets) == 0 {
+		c.ui.Warnf(ui.Yellow, "No Linux binary found for building images.")
+		return nil
+	}
+
+	dockerfile := c.dockerfile()
+
+	for _, target := range targets {
+		labels := map[string]string{
+			"org.opencontainers.image.version":  target.version,
+			"org.opencontainers.image.revision": commit,
+			"org.opencontainers.image.created":  created.UTC().Format(time.RFC3339),
+		}
+
+		path := filepath.Join(dir, fmt.Sprintf("%s_%s_image.tar", target.name, target.version))
+
+		if dockerfile != "" {
+			if err := c.dockerBuild(ctx, dockerfile, dir, path, target, labels); err != nil {
+				return err
+			}
+		} else {
+			l, err := image.Build(target.binaries, image.Options{
+				Name:    target.name,
+				Created: created,
+				Labels:  labels,
+			})
+
+			if err != nil {
+				return err
+			}
+
+			if err := l.WriteFile(path, target.ref.Tag); err != nil {
+				return err
+			}
+		}
+
+		c.outputs.images = append(c.outputs.images, Artifact{
+			Path:  path,
+			Label: target.ref.String(),
+			OS:    "linux",
+		})
+
+		c.ui.Printf("%s", path)
+	}
+
+	if c.spec.Project.Build.Image.Push {
+		return c.PushImages(ctx)
+	}
+
+	return nil
+}
+
+// PushImages pushes the images built by the command to the registry.
+// It is also used for pushing the images separately from building them (e.g. after a release is published).
+func (c *Command) PushImages(ctx context.Context) error {
+	for _, img := range c.outputs.images {
+		ref, err := image.ParseReference(img.Label)
+		if err != nil {
+			return err
+		}
+
+		l, err := image.ReadLayout(img.Path)
+		if err != nil {
+			return err
+		}
+
+		if err := c.services.registry.Push(ctx, l, ref); err != nil {
+			return err
+		}
+
+		c.ui.Printf("%s", ref)
+	}
+
+	return nil
+}
+
+// dockerBuild builds a multi-platform image from a Dockerfile using docker buildx.
+// The binaries are staged in a temporary directory of the build context by platform, since their names vary with cross-compiling and variants.
+// The name of the binary and the staging directory are passed as the BINARY and BINARY_DIR build arguments (e.g. ${BINARY_DIR}/${TARGETPLATFORM}/${BINARY}).
+func (c *Command) dockerBuild(ctx context.Context, dockerfile, dir, path string, target imageTarget, labels map[string]string) error {
+	stageDir, err := os.MkdirTemp(dir, target.name+"_image_")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = os.RemoveAll(stageDir)
+	}()
+
+	platforms := make([]string, 0, len(target.binaries))
+	for _, binary := range target.binaries {
+		dst := filepath.Join(stageDir, filepath.FromSlash(binary.Platform.String()), target.name)
+		if err := stageBinary(dst, binary.Path); err != nil {
+			return err
+		}
+
+		platforms = append(platforms, binary.Platform.String())
+	}
+
+	args := []string{
+		"--file", dockerfile,
+		"--tag", target.ref.String(),
+		"--platform", strings.Join(platforms, ","),
+		"--build-arg", "BINARY=" + target.name,
+		"--build-arg", "BINARY_DIR=" + filepath.ToSlash(stageDir),
+	}
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		args = append(args, "--label", key+"="+labels[key])
+	}
+
+	args = append(args, "--output", "type=oci,dest="+path, ".")
+
+	if _, _, err := c.funcs.dockerBuild(ctx, shell.RunOptions{}, args...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// stageBinary copies a binary to a path for building an image.
+func stageBinary(dst, src string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(dst, data, 0755)
+}
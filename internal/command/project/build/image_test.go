@@ -0,0 +1,335 @@
+package build
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gardenbed/charm/shell"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/basil-cli/internal/image"
+	"github.com/gardenbed/basil-cli/internal/spec"
+	"github.com/gardenbed/basil-cli/internal/ui"
+)
+
+func TestImagePlatform(t *testing.T) {
+	tests := []struct {
+		platform         platform
+		expectedPlatform image.Platform
+	}{
+		{platform{os: "linux", arch: "amd64"}, image.Platform{OS: "linux", Architecture: "amd64"}},
+		{platform{os: "linux", arch: "amd64", variant: "v3"}, image.Platform{OS: "linux", Architecture: "amd64", Variant: "v3"}},
+		{platform{os: "linux", arch: "arm", variant: "v7"}, image.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}},
+		{platform{os: "linux", arch: "arm64", variant: "v8.2"}, image.Platform{OS: "linux", Architecture: "arm64"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.platform.String(), func(t *testing.T) {
+			assert.Equal(t, tc.expectedPlatform, imagePlatform(tc.platform))
+		})
+	}
+}
+
+func TestCommand_imageTargets(t *testing.T) {
+	tests := []struct {
+		name            string
+		dockerID        string
+		image           spec.Image
+		artifacts       []Artifact
+		expectedError   string
+		expectedTargets []imageTarget
+	}{
+		{
+			name:          "NoDockerID",
+			image:         spec.Image{},
+			expectedError: "docker id is required for building images: set project.docker_id in the spec file or use -docker-id",
+		},
+		{
+			name:     "InvalidReference",
+			dockerID: "octo cat",
+			artifacts: []Artifact{
+				{Path: "bin/app-linux-amd64", OS: "linux", Arch: "amd64", Version: "0.1.0"},
+			},
+			expectedError: `invalid image reference "octo cat/app:0.1.0": invalid repository "octo cat/app"`,
+		},
+		{
+			name:     "SamePlatform",
+			dockerID: "octocat",
+			artifacts: []Artifact{
+				{Path: "bin/app-linux-arm64-v8.0", OS: "linux", Arch: "arm64", Variant: "v8.0", Version: "0.1.0"},
+				{Path: "bin/app-linux-arm64-v9.0", OS: "linux", Arch: "arm64", Variant: "v9.0", Version: "0.1.0"},
+			},
+			expectedError: "cannot build image for app: linux-arm64-v8.0 and linux-arm64-v9.0 are both for linux/arm64",
+		},
+		{
+			name:     "NoLinuxBinary",
+			dockerID: "octocat",
+			artifacts: []Artifact{
+				{Path: "bin/app-darwin-arm64", OS: "darwin", Arch: "arm64", Version: "0.1.0"},
+			},
+			expectedTargets: []imageTarget{},
+		},
+		{
+			name:     "Success",
+			dockerID: "octocat",
+			image:    spec.Image{Registry: "localhost:5000"},
+			artifacts: []Artifact{
+				{Path: "bin/App-linux-amd64", OS: "linux", Arch: "amd64", Version: "0.1.0"},
+				{Path: "bin/App-darwin-arm64", OS: "darwin", Arch: "arm64", Version: "0.1.0"},
+				{Path: "bin/App-linux-arm-v7", OS: "linux", Arch: "arm", Variant: "v7", Version: "0.1.0"},
+				{Path: "bin/tool-linux-amd64", OS: "linux", Arch: "amd64", Version: "0.2.0"},
+			},
+			expectedTargets: []imageTarget{
+				{
+					name:    "App",
+					version: "0.1.0",
+					ref:     image.Reference{Registry: "localhost:5000", Repository: "octocat/app", Tag: "0.1.0"},
+					binaries: []image.Binary{
+						{Path: "bin/App-linux-amd64", Platform: image.Platform{OS: "linux", Architecture: "amd64"}},
+						{Path: "bin/App-linux-arm-v7", Platform: image.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}},
+					},
+				},
+				{
+					name:    "tool",
+					version: "0.2.0",
+					ref:     image.Reference{Registry: "localhost:5000", Repository: "octocat/tool", Tag: "0.2.0"},
+					binaries: []image.Binary{
+						{Path: "bin/tool-linux-amd64", Platform: image.Platform{OS: "linux", Architecture: "amd64"}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Command{ui: ui.NewNop()}
+			c.spec.Project.DockerID = tc.dockerID
+			c.spec.Project.Build.Image = tc.image
+			c.outputs.artifacts = tc.artifacts
+
+			targets, err := c.imageTargets()
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedTargets, targets)
+			}
+		})
+	}
+}
+
+func TestCommand_dockerfile(t *testing.T) {
+	c := &Command{ui: ui.NewNop()}
+	assert.Equal(t, "", c.dockerfile())
+
+	c.spec.Project.Build.Image.Dockerfile = "build/Dockerfile"
+	assert.Equal(t, "build/Dockerfile", c.dockerfile())
+}
+
+func TestCommand_buildImages(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		dockerID       string
+		image          spec.Image
+		artifacts      []string
+		dockerBuild    shell.RunnerWithFunc
+		registry       *MockImageRegistry
+		expectedError  string
+		expectedStaged []string
+		expectedImages []Artifact
+		expectedArgs   []string
+	}{
+		{
+			name:          "NoDockerID",
+			image:         spec.Image{},
+			artifacts:     []string{"app-linux-amd64"},
+			expectedError: "docker id is required for building images: set project.docker_id in the spec file or use -docker-id",
+		},
+		{
+			name:           "NoLinuxBinary",
+			dockerID:       "octocat",
+			artifacts:      []string{"app-darwin-arm64"},
+			expectedImages: nil,
+		},
+		{
+			name:      "DockerBuildFails",
+			dockerID:  "octocat",
+			image:     spec.Image{Dockerfile: "Dockerfile"},
+			artifacts: []string{"app-linux-amd64"},
+			dockerBuild: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+				return 1, "", errors.New("docker error")
+			},
+			expectedError: "docker error",
+		},
+		{
+			name:      "DockerBuild",
+			dockerID:  "octocat",
+			image:     spec.Image{Dockerfile: "Dockerfile"},
+			artifacts: []string{"app-linux-amd64", "app-linux-arm-v7"},
+			dockerBuild: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			expectedStaged: []string{
+				"linux/amd64/app",
+				"linux/arm/v7/app",
+			},
+			expectedImages: []Artifact{
+				{Path: "app_0.1.0_image.tar", Label: "docker.io/octocat/app:0.1.0", OS: "linux"},
+			},
+			expectedArgs: []string{
+				"--file", "Dockerfile",
+				"--tag", "docker.io/octocat/app:0.1.0",
+				"--platform", "linux/amd64,linux/arm/v7",
+				"--build-arg", "BINARY=app",
+				"--build-arg", "BINARY_DIR=app_image_*",
+				"--label", "org.opencontainers.image.created=2026-01-01T00:00:00Z",
+				"--label", "org.opencontainers.image.revision=7813389d2b09cdf851665b7848daa212b27e4e82",
+				"--label", "org.opencontainers.image.version=0.1.0",
+				"--output", "type=oci,dest=app_0.1.0_image.tar",
+				".",
+			},
+		},
+		{
+			name:      "PushFails",
+			dockerID:  "octocat",
+			image:     spec.Image{Push: true},
+			artifacts: []string{"app-linux-amd64"},
+			registry: &MockImageRegistry{
+				PushMocks: []PushMock{
+					{OutError: errors.New("registry error")},
+				},
+			},
+			expectedError: "registry error",
+		},
+		{
+			name:      "Success",
+			dockerID:  "octocat",
+			image:     spec.Image{Registry: "localhost:5000", Push: true},
+			artifacts: []string{"app-linux-amd64", "app-linux-arm64", "app-windows-amd64"},
+			registry: &MockImageRegistry{
+				PushMocks: []PushMock{
+					{OutError: nil},
+				},
+			},
+			expectedImages: []Artifact{
+				{Path: "app_0.1.0_image.tar", Label: "localhost:5000/octocat/app:0.1.0", OS: "linux"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			var args, staged []string
+
+			c := &Command{ui: ui.NewNop()}
+			c.spec.Project.DockerID = tc.dockerID
+			c.spec.Project.Build.Image = tc.image
+			c.services.registry = tc.registry
+
+			if tc.dockerBuild != nil {
+				c.funcs.dockerBuild = func(ctx context.Context, opts shell.RunOptions, a ...string) (int, string, error) {
+					args = a
+					for _, arg := range a {
+						if stageDir, ok := strings.CutPrefix(arg, "BINARY_DIR="); ok {
+							_ = filepath.WalkDir(stageDir, func(path string, d fs.DirEntry, err error) error {
+								if err == nil && !d.IsDir() {
+									rel, _ := filepath.Rel(stageDir, path)
+									staged = append(staged, filepath.ToSlash(rel))
+								}
+								return err
+							})
+						}
+					}
+					return tc.dockerBuild(ctx, opts, a...)
+				}
+			}
+
+			for _, name := range tc.artifacts {
+				path := filepath.Join(dir, name)
+				assert.NoError(t, os.WriteFile(path, []byte("binary"), 0755))
+
+				p, err := parsePlatform(name[len("app-"):])
+				assert.NoError(t, err)
+
+				c.outputs.artifacts = append(c.outputs.artifacts, Artifact{Path: path, OS: p.os, Arch: p.arch, Variant: p.variant, Version: "0.1.0"})
+			}
+
+			err := c.buildImages(context.Background(), dir, "7813389d2b09cdf851665b7848daa212b27e4e82", created)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			for i := range tc.expectedImages {
+				tc.expectedImages[i].Path = filepath.Join(dir, tc.expectedImages[i].Path)
+			}
+			assert.Equal(t, tc.expectedImages, c.outputs.images)
+
+			if tc.expectedArgs != nil {
+				for i, arg := range tc.expectedArgs {
+					switch arg {
+					case "BINARY_DIR=app_image_*":
+						// The staging directory has a random suffix
+						assert.Regexp(t, "^BINARY_DIR="+regexp.QuoteMeta(filepath.ToSlash(dir))+"/app_image_[0-9]+$", args[i])
+						tc.expectedArgs[i] = args[i]
+					case "type=oci,dest=app_0.1.0_image.tar":
+						tc.expectedArgs[i] = "type=oci,dest=" + filepath.Join(dir, "app_0.1.0_image.tar")
+					}
+				}
+				assert.Equal(t, tc.expectedArgs, args)
+			}
+
+			// The binaries are staged only while building the images
+			assert.Equal(t, tc.expectedStaged, staged)
+			matches, _ := filepath.Glob(filepath.Join(dir, "*_image_*"))
+			assert.Empty(t, matches)
+
+			if tc.registry != nil {
+				for _, m := range tc.registry.PushMocks {
+					assert.Equal(t, tc.expectedImages[0].Label, m.InReference.String())
+					assert.NotNil(t, m.InLayout)
+				}
+			}
+		})
+	}
+}
+
+func TestCommand_PushImages(t *testing.T) {
+	t.Run("NoImage", func(t *testing.T) {
+		c := &Command{ui: ui.NewNop()}
+		assert.NoError(t, c.PushImages(context.Background()))
+	})
+
+	t.Run("InvalidReference", func(t *testing.T) {
+		c := &Command{ui: ui.NewNop()}
+		c.outputs.images = []Artifact{
+			{Path: "app_0.1.0_image.tar", Label: "octo cat/app:0.1.0", OS: "linux"},
+		}
+
+		assert.Error(t, c.PushImages(context.Background()))
+	})
+
+	t.Run("LayoutMissing", func(t *testing.T) {
+		c := &Command{ui: ui.NewNop()}
+		c.outputs.images = []Artifact{
+			{Path: filepath.Join(t.TempDir(), "app_0.1.0_image.tar"), Label: "docker.io/octocat/app:0.1.0", OS: "linux"},
+		}
+
+		assert.Error(t, c.PushImages(context.Background()))
+	})
+}
@@ -0,0 +1,102 @@
+package build
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+)
+
+const manifestFile = "manifest.json"
+
+// Manifest is the machine-readable list of all build artifacts.
+// The container images are listed separately from the artifacts, since they are published to registries and not released.
+type Manifest struct {
+	Version   string          `json:"version"`
+	Commit    string          `json:"commit"`
+	Artifacts []ManifestEntry `json:"artifacts"`
+	Images    []ManifestEntry `json:"images,omitempty"`
+}
+
+// ManifestEntry describes a build artifact in the manifest.
+// Target OS/arch/variant and main package are empty for artifacts not built for a target (e.g. checksums files).
+type ManifestEntry struct {
+	Path       string `json:"path"`
+	Label      string `json:"label,omitempty"`
+	OS         string `json:"os,omitempty"`
+	Arch       string `json:"arch,omitempty"`
+	Variant    string `json:"variant,omitempty"`
+	Main       string `json:"main,omitempty"`
+	Version    string `json:"version"`
+	Commit     string `json:"commit"`
+	SHA256     string `json:"sha256"`
+	SHA512     string `json:"sha512,omitempty"`
+	Size       int64  `json:"size"`
+	DurationMS int64  `json:"durationMs"`
+}
+
+// ReadManifest reads a manifest from a file.
+func ReadManifest(path string) (Manifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var m Manifest
+	if err := json.NewDecoder(f).Decode(&m); err != nil {
+		return Manifest{}, err
+	}
+
+	return m, nil
+}
+
+// Write writes the manifest in JSON format.
+func (m Manifest) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(m)
+}
+
+// WriteFile writes the manifest to a file.
+func (m Manifest) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := m.Write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// ToArtifacts returns the build artifacts listed in the manifest.
+func (m Manifest) ToArtifacts() []Artifact {
+	if len(m.Artifacts) == 0 {
+		return nil
+	}
+
+	artifacts := make([]Artifact, 0, len(m.Artifacts))
+	for _, e := range m.Artifacts {
+		artifacts = append(artifacts, Artifact{
+			Path:     e.Path,
+			Label:    e.Label,
+			OS:       e.OS,
+			Arch:     e.Arch,
+			Variant:  e.Variant,
+			Main:     e.Main,
+			SHA256:   e.SHA256,
+			SHA512:   e.SHA512,
+			Size:     e.Size,
+			Duration: time.Duration(e.DurationMS) * time.Millisecond,
+		})
+	}
+
+	return artifacts
+}
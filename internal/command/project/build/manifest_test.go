@@ -0,0 +1,139 @@
+package build
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testManifest = Manifest{
+	Version: "0.1.0",
+	Commit:  "7813389d2b09cdf851665b7848daa212b27e4e82",
+	Artifacts: []ManifestEntry{
+		{
+			Path:       "bin/app-linux-amd64",
+			OS:         "linux",
+			Arch:       "amd64",
+			Main:       "./cmd/app",
+			Version:    "0.1.0",
+			Commit:     "7813389d2b09cdf851665b7848daa212b27e4e82",
+			SHA256:     "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+			Size:       5,
+			DurationMS: 1500,
+		},
+		{
+			Path:    "bin/checksums.txt",
+			Version: "0.1.0",
+			Commit:  "7813389d2b09cdf851665b7848daa212b27e4e82",
+			SHA256:  "9f2f4d9a1b6a3d8e2c1b0a9f8e7d6c5b4a39281706f5e4d3c2b1a09f8e7d6c5b",
+			Size:    86,
+		},
+	},
+}
+
+func TestReadManifest(t *testing.T) {
+	tests := []struct {
+		name             string
+		content          string
+		expectedManifest Manifest
+		expectedError    bool
+	}{
+		{
+			name:          "NoFile",
+			expectedError: true,
+		},
+		{
+			name:          "InvalidJSON",
+			content:       "{",
+			expectedError: true,
+		},
+		{
+			name:             "Success",
+			content:          `{"version": "0.1.0", "artifacts": [{"path": "bin/app", "os": "linux", "arch": "amd64", "size": 5}]}`,
+			expectedManifest: Manifest{Version: "0.1.0", Artifacts: []ManifestEntry{{Path: "bin/app", OS: "linux", Arch: "amd64", Size: 5}}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), manifestFile)
+			if tc.content != "" {
+				assert.NoError(t, os.WriteFile(path, []byte(tc.content), 0644))
+			}
+
+			manifest, err := ReadManifest(path)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedManifest, manifest)
+			}
+		})
+	}
+}
+
+func TestManifest_Write(t *testing.T) {
+	var buf bytes.Buffer
+	err := Manifest{Version: "0.1.0", Artifacts: []ManifestEntry{{Path: "bin/app", Size: 5}}}.Write(&buf)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{
+  "version": "0.1.0",
+  "commit": "",
+  "artifacts": [
+    {
+      "path": "bin/app",
+      "version": "",
+      "commit": "",
+      "sha256": "",
+      "size": 5,
+      "durationMs": 0
+    }
+  ]
+}
+`, buf.String())
+}
+
+func TestManifest_WriteFile(t *testing.T) {
+	t.Run("InvalidPath", func(t *testing.T) {
+		err := testManifest.WriteFile(filepath.Join(t.TempDir(), "missing", manifestFile))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), manifestFile)
+
+		assert.NoError(t, testManifest.WriteFile(path))
+
+		manifest, err := ReadManifest(path)
+		assert.NoError(t, err)
+		assert.Equal(t, testManifest, manifest)
+	})
+}
+
+func TestManifest_ToArtifacts(t *testing.T) {
+	assert.Nil(t, Manifest{}.ToArtifacts())
+
+	assert.Equal(t, []Artifact{
+		{
+			Path:     "bin/app-linux-amd64",
+			OS:       "linux",
+			Arch:     "amd64",
+			Main:     "./cmd/app",
+			SHA256:   "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+			Size:     5,
+			Duration: 1500 * time.Millisecond,
+		},
+		{
+			Path:   "bin/checksums.txt",
+			SHA256: "9f2f4d9a1b6a3d8e2c1b0a9f8e7d6c5b4a39281706f5e4d3c2b1a09f8e7d6c5b",
+			Size:   86,
+		},
+	}, testManifest.ToArtifacts())
+}
@@ -0,0 +1,212 @@
+package build
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/gardenbed/basil-cli/internal/semver"
+)
+
+// metadataVar is a custom metadata variable injected into binaries at build time.
+type metadataVar struct {
+	// pkg is the import path of the package declaring the variable (empty for the metadata package of a module).
+	pkg   string
+	name  string
+	tmpl  string
+	value string
+	// timed is whether or not the value depends on the build time (e.g. {{.Time.Year}}).
+	timed bool
+}
+
+// gitData is the git information available to metadata templates.
+type gitData struct {
+	Commit      string
+	ShortCommit string
+	Branch      string
+	RemoteURL   string
+	Dirty       bool
+}
+
+// metadataData is the data available to metadata templates.
+type metadataData struct {
+	Version   string
+	SemVer    semver.SemVer
+	Git       gitData
+	GoVersion string
+	Env       map[string]string
+	Time      time.Time
+}
+
+// environ returns the environment variables as a map.
+func environ() map[string]string {
+	env := map[string]string{}
+	for _, kv := range os.Environ() {
+		if key, val, ok := strings.Cut(kv, "="); ok {
+			env[key] = val
+		}
+	}
+
+	return env
+}
+
+// parseMetadataVars parses the metadata section of the spec.
+// A key is either a variable name in the metadata package or a fully-qualified name in the form of import/path.Name.
+// The variables are sorted by their keys, so the ld flags are deterministic.
+func parseMetadataVars(metadata map[string]string) ([]metadataVar, error) {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	vars := make([]metadataVar, 0, len(keys))
+	for _, key := range keys {
+		var pkg, name string
+		if i := strings.LastIndex(key, "."); i > strings.LastIndex(key, "/") {
+			pkg, name = key[:i], key[i+1:]
+		} else {
+			name = key
+		}
+
+		if !token.IsIdentifier(name) || (pkg == "" && strings.Contains(key, "/")) {
+			return nil, fmt.Errorf("invalid metadata variable: %s", key)
+		}
+
+		vars = append(vars, metadataVar{
+			pkg:  pkg,
+			name: name,
+			tmpl: metadata[key],
+		})
+	}
+
+	return vars, nil
+}
+
+// renderMetadata renders the templated values of metadata variables.
+func renderMetadata(vars []metadataVar, data metadataData) ([]metadataVar, error) {
+	rendered := make([]metadataVar, 0, len(vars))
+	for _, v := range vars {
+		tmpl, err := template.New(v.name).Option("missingkey=zero").Parse(v.tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid metadata template for %s: %s", v.name, err)
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("invalid metadata template for %s: %s", v.name, err)
+		}
+
+		v.value = buf.String()
+		v.timed = referencesTime(tmpl.Root)
+		rendered = append(rendered, v)
+	}
+
+	return rendered, nil
+}
+
+// referencesTime determines whether or not a template node references the build time (.Time or $.Time).
+func referencesTime(node parse.Node) bool {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return false
+		}
+		for _, child := range n.Nodes {
+			if referencesTime(child) {
+				return true
+			}
+		}
+	case *parse.PipeNode:
+		if n == nil {
+			return false
+		}
+		for _, cmd := range n.Cmds {
+			if referencesTime(cmd) {
+				return true
+			}
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			if referencesTime(arg) {
+				return true
+			}
+		}
+	case *parse.ActionNode:
+		return referencesTime(n.Pipe)
+	case *parse.TemplateNode:
+		return referencesTime(n.Pipe)
+	case *parse.IfNode:
+		return referencesTime(n.Pipe) || referencesTime(n.List) || referencesTime(n.ElseList)
+	case *parse.RangeNode:
+		return referencesTime(n.Pipe) || referencesTime(n.List) || referencesTime(n.ElseList)
+	case *parse.WithNode:
+		return referencesTime(n.Pipe) || referencesTime(n.List) || referencesTime(n.ElseList)
+	case *parse.ChainNode:
+		return referencesTime(n.Node)
+	case *parse.FieldNode:
+		return len(n.Ident) > 0 && n.Ident[0] == "Time"
+	case *parse.VariableNode:
+		return len(n.Ident) > 1 && n.Ident[0] == "$" && n.Ident[1] == "Time"
+	}
+
+	return false
+}
+
+// xFlag returns the -X ld flag for setting a string variable in a package.
+// The value is quoted with single quotes if it contains double quotes, since the go command does not support escaping quotes in flags.
+func xFlag(pkg, name, value string) (string, error) {
+	quote := `"`
+	if strings.Contains(value, `"`) {
+		if strings.Contains(value, `'`) {
+			return "", fmt.Errorf("metadata value for %s.%s cannot contain both single and double quotes", pkg, name)
+		}
+		quote = `'`
+	}
+
+	return fmt.Sprintf(`-X %s%s.%s=%s%s`, quote, pkg, name, value, quote), nil
+}
+
+// packageVars returns the names of the package-level variables declared in a package directory.
+func packageVars(dir string) (map[string]bool, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	vars := map[string]bool{}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, decl := range file.Decls {
+			if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.VAR {
+				for _, spec := range gen.Specs {
+					for _, ident := range spec.(*ast.ValueSpec).Names {
+						vars[ident.Name] = true
+					}
+				}
+			}
+		}
+	}
+
+	return vars, nil
+}
@@ -0,0 +1,316 @@
+package build
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/gardenbed/charm/shell"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/basil-cli/internal/gomod"
+	"github.com/gardenbed/basil-cli/internal/semver"
+	"github.com/gardenbed/basil-cli/internal/ui"
+)
+
+func TestParseMetadataVars(t *testing.T) {
+	tests := []struct {
+		name          string
+		metadata      map[string]string
+		expectedVars  []metadataVar
+		expectedError string
+	}{
+		{
+			name:         "Empty",
+			metadata:     nil,
+			expectedVars: []metadataVar{},
+		},
+		{
+			name:          "InvalidName",
+			metadata:      map[string]string{"Build-Number": "1"},
+			expectedError: "invalid metadata variable: Build-Number",
+		},
+		{
+			name:          "NoName",
+			metadata:      map[string]string{"github.com/octocat/app/info": "1"},
+			expectedError: "invalid metadata variable: github.com/octocat/app/info",
+		},
+		{
+			name: "Success",
+			metadata: map[string]string{
+				"RepoURL": "{{.Git.RemoteURL}}",
+				"github.com/octocat/app/internal/info.BuildNumber": "{{.Env.BUILD_NUMBER}}",
+				"main.Dirty": "{{.Git.Dirty}}",
+			},
+			expectedVars: []metadataVar{
+				{pkg: "", name: "RepoURL", tmpl: "{{.Git.RemoteURL}}"},
+				{pkg: "github.com/octocat/app/internal/info", name: "BuildNumber", tmpl: "{{.Env.BUILD_NUMBER}}"},
+				{pkg: "main", name: "Dirty", tmpl: "{{.Git.Dirty}}"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			vars, err := parseMetadataVars(tc.metadata)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedVars, vars)
+			}
+		})
+	}
+}
+
+func TestRenderMetadata(t *testing.T) {
+	data := metadataData{
+		Version: "1.2.0",
+		SemVer:  semver.SemVer{Major: 1, Minor: 2},
+		Git: gitData{
+			Commit:      "7813389d2b09cdf851665b7848daa212b27e4e82",
+			ShortCommit: "7813389",
+			Branch:      "main",
+			RemoteURL:   "git@github.com:octocat/app.git",
+			Dirty:       true,
+		},
+		GoVersion: "go1.22.0",
+		Env:       map[string]string{"BUILD_NUMBER": "42"},
+		Time:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name          string
+		vars          []metadataVar
+		expectedVars  []metadataVar
+		expectedError string
+	}{
+		{
+			name:          "InvalidTemplate",
+			vars:          []metadataVar{{name: "RepoURL", tmpl: "{{.Git.RemoteURL"}},
+			expectedError: `invalid metadata template for RepoURL: template: RepoURL:1: unclosed action`,
+		},
+		{
+			name:          "ExecuteFails",
+			vars:          []metadataVar{{name: "RepoURL", tmpl: "{{.Git.Remote}}"}},
+			expectedError: `invalid metadata template for RepoURL: template: RepoURL:1:6: executing "RepoURL" at <.Git.Remote>: can't evaluate field Remote in type build.gitData`,
+		},
+		{
+			name: "Success",
+			vars: []metadataVar{
+				{name: "RepoURL", tmpl: "{{.Git.RemoteURL}}"},
+				{name: "Release", tmpl: "v{{.SemVer.Major}}.{{.SemVer.Minor}}-{{.Git.ShortCommit}}"},
+				{name: "Dirty", tmpl: "{{.Git.Dirty}}"},
+				{pkg: "github.com/octocat/app/internal/info", name: "BuildNumber", tmpl: "{{.Env.BUILD_NUMBER}}"},
+				{pkg: "github.com/octocat/app/internal/info", name: "JobID", tmpl: "{{.Env.JOB_ID}}"},
+				{pkg: "github.com/octocat/app/internal/info", name: "BuildDate", tmpl: `{{.Time.Format "2006-01-02"}}`},
+			},
+			expectedVars: []metadataVar{
+				{name: "RepoURL", tmpl: "{{.Git.RemoteURL}}", value: "git@github.com:octocat/app.git"},
+				{name: "Release", tmpl: "v{{.SemVer.Major}}.{{.SemVer.Minor}}-{{.Git.ShortCommit}}", value: "v1.2-7813389"},
+				{name: "Dirty", tmpl: "{{.Git.Dirty}}", value: "true"},
+				{pkg: "github.com/octocat/app/internal/info", name: "BuildNumber", tmpl: "{{.Env.BUILD_NUMBER}}", value: "42"},
+				{pkg: "github.com/octocat/app/internal/info", name: "JobID", tmpl: "{{.Env.JOB_ID}}", value: ""},
+				{pkg: "github.com/octocat/app/internal/info", name: "BuildDate", tmpl: `{{.Time.Format "2006-01-02"}}`, value: "2026-01-01", timed: true},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			vars, err := renderMetadata(tc.vars, data)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedVars, vars)
+			}
+		})
+	}
+}
+
+func TestReferencesTime(t *testing.T) {
+	tests := []struct {
+		tmpl     string
+		expected bool
+	}{
+		{tmpl: "v{{.Version}}-{{.Git.ShortCommit}}", expected: false},
+		{tmpl: `{{.Env.TIME}}`, expected: false},
+		{tmpl: `{{.Time.Format "2006-01-02"}}`, expected: true},
+		{tmpl: `{{.Time.Year}}`, expected: true},
+		{tmpl: `{{$.Time.Unix}}`, expected: true},
+		{tmpl: `{{if .Git.Dirty}}dev{{else}}{{.Time.Year}}{{end}}`, expected: true},
+		{tmpl: `{{with .Time}}{{.Year}}{{end}}`, expected: true},
+		{tmpl: `{{printf "%d" (.Time.Year)}}`, expected: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.tmpl, func(t *testing.T) {
+			tmpl, err := template.New("test").Parse(tc.tmpl)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, referencesTime(tmpl.Root))
+		})
+	}
+}
+
+func TestXFlag(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		expectedFlag  string
+		expectedError string
+	}{
+		{
+			name:         "Plain",
+			value:        "hello world",
+			expectedFlag: `-X "main.Greeting=hello world"`,
+		},
+		{
+			name:         "DoubleQuotes",
+			value:        `say "hello"`,
+			expectedFlag: `-X 'main.Greeting=say "hello"'`,
+		},
+		{
+			name:          "BothQuotes",
+			value:         `it's "hello"`,
+			expectedError: "metadata value for main.Greeting cannot contain both single and double quotes",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			flag, err := xFlag("main", "Greeting", tc.value)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedFlag, flag)
+			}
+		})
+	}
+}
+
+func TestPackageVars(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"info.go":      "package info\n\nvar BuildNumber string\n\nvar (\n\tRepoURL, Dirty string\n)\n\nconst Name = \"app\"\n\nfunc Get() string { var local string; return local }\n",
+		"info_test.go": "package info\n\nvar TestOnly string\n",
+		"README.md":    "# info\n",
+	})
+
+	vars, err := packageVars(dir)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		"BuildNumber": true,
+		"RepoURL":     true,
+		"Dirty":       true,
+	}, vars)
+
+	_, err = packageVars(dir + "/missing")
+	assert.Error(t, err)
+}
+
+func TestCommand_metadataFlags(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"info.go": "package info\n\nvar BuildNumber string\n",
+	})
+
+	vars := []metadataVar{
+		{name: "RepoURL", value: "https://github.com/octocat/app"},
+		{name: "BuildYear", value: "2026", timed: true},
+		{pkg: "github.com/octocat/app/internal/info", name: "BuildNumber", value: "42"},
+		{pkg: "github.com/octocat/app/internal/info", name: "JobID", value: "7"},
+		{pkg: "github.com/octocat/app/internal/missing", name: "Value", value: "x"},
+		{pkg: "main", name: "Dirty", value: "false"},
+	}
+
+	tests := []struct {
+		name           string
+		metadataPkg    string
+		vars           []metadataVar
+		expectedFlags  []string
+		expectedTimed  []string
+		expectedListed []string
+		expectedError  string
+	}{
+		{
+			name:          "InvalidValue",
+			metadataPkg:   "github.com/octocat/app/metadata",
+			vars:          []metadataVar{{name: "Quote", value: `it's "quoted"`}},
+			expectedError: "metadata value for github.com/octocat/app/metadata.Quote cannot contain both single and double quotes",
+		},
+		{
+			name:        "NoMetadataPackage",
+			metadataPkg: "",
+			vars:        vars,
+			expectedFlags: []string{
+				`-X "github.com/octocat/app/internal/info.BuildNumber=42"`,
+				`-X "github.com/octocat/app/internal/info.JobID=7"`,
+				`-X "github.com/octocat/app/internal/missing.Value=x"`,
+				`-X "main.Dirty=false"`,
+			},
+			expectedTimed: []string{},
+			expectedListed: []string{
+				"github.com/octocat/app/internal/info",
+				"github.com/octocat/app/internal/missing",
+			},
+		},
+		{
+			name:        "Success",
+			metadataPkg: "github.com/octocat/app/metadata",
+			vars:        vars,
+			expectedFlags: []string{
+				`-X "github.com/octocat/app/metadata.RepoURL=https://github.com/octocat/app"`,
+				`-X "github.com/octocat/app/internal/info.BuildNumber=42"`,
+				`-X "github.com/octocat/app/internal/info.JobID=7"`,
+				`-X "github.com/octocat/app/internal/missing.Value=x"`,
+				`-X "main.Dirty=false"`,
+			},
+			expectedTimed: []string{
+				`-X "github.com/octocat/app/metadata.BuildYear=2026"`,
+			},
+			expectedListed: []string{
+				"github.com/octocat/app/metadata",
+				"github.com/octocat/app/internal/info",
+				"github.com/octocat/app/internal/missing",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			listed := []string{}
+
+			c := &Command{ui: ui.NewNop()}
+			c.funcs.goListDir = func(_ context.Context, opts shell.RunOptions, args ...string) (int, string, error) {
+				listed = append(listed, args[0])
+				switch args[0] {
+				case "github.com/octocat/app/internal/info":
+					return 0, dir, nil
+				case "github.com/octocat/app/metadata":
+					return 0, dir, nil
+				default:
+					return 1, "", errors.New("package not found")
+				}
+			}
+
+			flags, timed, err := c.metadataFlags(context.Background(), module{Module: gomod.Module{Dir: "."}}, tc.metadataPkg, tc.vars)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedFlags, flags)
+				assert.Equal(t, tc.expectedTimed, timed)
+				assert.Equal(t, tc.expectedListed, listed)
+			}
+		})
+	}
+}
@@ -1,6 +1,69 @@
 package build
 
-import "github.com/gardenbed/basil-cli/internal/semver"
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	charmui "github.com/gardenbed/charm/ui"
+
+	"github.com/gardenbed/basil-cli/internal/archive"
+	"github.com/gardenbed/basil-cli/internal/image"
+	"github.com/gardenbed/basil-cli/internal/semver"
+	"github.com/gardenbed/basil-cli/internal/ui"
+)
+
+// MockUI records the messages written to the standard output and the standard error.
+type MockUI struct {
+	ui.UI
+	sync.Mutex
+	Stdout strings.Builder
+	Stderr strings.Builder
+	Level  charmui.Level
+}
+
+func NewMockUI() *MockUI {
+	return &MockUI{
+		UI:    ui.NewNop(),
+		Level: ui.Info,
+	}
+}
+
+func (m *MockUI) write(w *strings.Builder, format string, a ...interface{}) {
+	m.Lock()
+	defer m.Unlock()
+	fmt.Fprintf(w, format+"\n", a...)
+}
+
+func (m *MockUI) SetLevel(l charmui.Level) {
+	m.Level = l
+}
+
+func (m *MockUI) Printf(format string, a ...interface{}) {
+	m.write(&m.Stdout, format, a...)
+}
+
+func (m *MockUI) Tracef(_ charmui.Style, format string, a ...interface{}) {
+	m.write(&m.Stdout, format, a...)
+}
+
+func (m *MockUI) Debugf(_ charmui.Style, format string, a ...interface{}) {
+	m.write(&m.Stdout, format, a...)
+}
+
+func (m *MockUI) Infof(_ charmui.Style, format string, a ...interface{}) {
+	m.write(&m.Stdout, format, a...)
+}
+
+func (m *MockUI) Warnf(_ charmui.Style, format string, a ...interface{}) {
+	m.write(&m.Stdout, format, a...)
+}
+
+func (m *MockUI) Errorf(_ charmui.Style, format string, a ...interface{}) {
+	m.write(&m.Stderr, format, a...)
+}
 
 type (
 	RunMock struct {
@@ -24,6 +87,7 @@ type (
 func (m *MockSemverCommand) Run(args []string) int {
 	i := m.RunIndex
 	m.RunIndex++
+	m.RunMocks[i].InArgs = args
 	return m.RunMocks[i].OutCode
 }
 
@@ -32,3 +96,104 @@ func (m *MockSemverCommand) SemVer() semver.SemVer {
 	m.SemVerIndex++
 	return m.SemVerMocks[i].OutSemVer
 }
+
+type MockGateCommand struct {
+	RunIndex int
+	RunMocks []RunMock
+}
+
+func (m *MockGateCommand) Run(args []string) int {
+	i := m.RunIndex
+	m.RunIndex++
+	m.RunMocks[i].InArgs = args
+	return m.RunMocks[i].OutCode
+}
+
+type (
+	CreateMock struct {
+		InWriter io.Writer
+		InFiles  []archive.File
+		OutError error
+	}
+
+	MockArchiveService struct {
+		CreateIndex int
+		CreateMocks []CreateMock
+	}
+)
+
+func (m *MockArchiveService) Create(w io.Writer, files []archive.File) error {
+	i := m.CreateIndex
+	m.CreateIndex++
+	m.CreateMocks[i].InWriter = w
+	m.CreateMocks[i].InFiles = files
+	return m.CreateMocks[i].OutError
+}
+
+type (
+	GetMock struct {
+		InKey    string
+		InDst    string
+		OutOK    bool
+		OutError error
+	}
+
+	PutMock struct {
+		InKey    string
+		InSrc    string
+		OutError error
+	}
+
+	MockCacheService struct {
+		sync.Mutex
+
+		GetIndex int
+		GetMocks []GetMock
+
+		PutIndex int
+		PutMocks []PutMock
+	}
+)
+
+func (m *MockCacheService) Get(key, dst string) (bool, error) {
+	m.Lock()
+	defer m.Unlock()
+	i := m.GetIndex
+	m.GetIndex++
+	m.GetMocks[i].InKey = key
+	m.GetMocks[i].InDst = dst
+	return m.GetMocks[i].OutOK, m.GetMocks[i].OutError
+}
+
+func (m *MockCacheService) Put(key, src string) error {
+	m.Lock()
+	defer m.Unlock()
+	i := m.PutIndex
+	m.PutIndex++
+	m.PutMocks[i].InKey = key
+	m.PutMocks[i].InSrc = src
+	return m.PutMocks[i].OutError
+}
+
+type (
+	PushMock struct {
+		InContext   context.Context
+		InLayout    *image.Layout
+		InReference image.Reference
+		OutError    error
+	}
+
+	MockImageRegistry struct {
+		PushIndex int
+		PushMocks []PushMock
+	}
+)
+
+func (m *MockImageRegistry) Push(ctx context.Context, l *image.Layout, ref image.Reference) error {
+	i := m.PushIndex
+	m.PushIndex++
+	m.PushMocks[i].InContext = ctx
+	m.PushMocks[i].InLayout = l
+	m.PushMocks[i].InReference = ref
+	return m.PushMocks[i].OutError
+}
@@ -0,0 +1,19 @@
+package build
+
+import (
+	"github.com/gardenbed/basil-cli/internal/gomod"
+	"github.com/gardenbed/basil-cli/internal/semver"
+	"github.com/gardenbed/basil-cli/internal/spec"
+)
+
+// module is a Go module with main packages to build.
+type module struct {
+	gomod.Module
+	targets []spec.Target
+	// version is the semantic version of the module.
+	version semver.SemVer
+	// ldFlags are the LD flags for injecting metadata into the binaries of the module.
+	ldFlags string
+	// stableLDFlags are the LD flags excluding the build time and the metadata values depending on it.
+	stableLDFlags string
+}
@@ -0,0 +1,99 @@
+package build
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gardenbed/charm/shell"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/basil-cli/internal/gomod"
+	"github.com/gardenbed/basil-cli/internal/spec"
+	"github.com/gardenbed/basil-cli/internal/ui"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestCommand_checkTargetModules(t *testing.T) {
+	modules := []gomod.Module{
+		{Dir: ".", Path: "github.com/foo/bar"},
+		{Dir: "tools/gen", Path: "github.com/foo/bar/tools/gen"},
+	}
+
+	c := new(Command)
+	c.spec.Project.Build.Targets = []spec.Target{
+		{Main: "./cmd/app"},
+		{Module: "tools/gen", Main: "."},
+	}
+
+	assert.NoError(t, c.checkTargetModules(modules))
+
+	c.spec.Project.Build.Targets = append(c.spec.Project.Build.Targets, spec.Target{Module: "services/api", Main: "./cmd/api"})
+
+	assert.EqualError(t, c.checkTargetModules(modules), "invalid target ./cmd/api: no module found for services/api")
+}
+
+func TestCheckTargetNames(t *testing.T) {
+	modules := []module{
+		{Module: gomod.Module{Dir: ".", Path: "github.com/foo/bar"}, targets: []spec.Target{{Name: "app"}, {Name: "gen"}}},
+		{Module: gomod.Module{Dir: "tools/gen", Path: "github.com/foo/bar/tools/gen"}, targets: []spec.Target{{Name: "lint"}}},
+	}
+
+	assert.NoError(t, checkTargetNames(modules))
+
+	modules[1].targets = append(modules[1].targets, spec.Target{Name: "gen"})
+
+	assert.EqualError(t, checkTargetNames(modules), "binary gen is built in both github.com/foo/bar and github.com/foo/bar/tools/gen modules")
+}
+
+func TestCommand_buildModules(t *testing.T) {
+	type call struct {
+		workingDir string
+		output     string
+		main       string
+	}
+
+	var calls []call
+
+	c := &Command{ui: ui.NewNop()}
+	c.funcs.goBuild = func(_ context.Context, opts shell.RunOptions, args ...string) (int, string, error) {
+		calls = append(calls, call{
+			workingDir: opts.WorkingDir,
+			output:     args[len(args)-2],
+			main:       args[len(args)-1],
+		})
+		return 0, "", nil
+	}
+
+	modules := []module{
+		{Module: gomod.Module{Dir: "."}, targets: []spec.Target{{Main: "./cmd/app", Name: "app"}}},
+		{Module: gomod.Module{Dir: "services/api"}, targets: nil},
+		{Module: gomod.Module{Dir: "tools/gen"}, targets: []spec.Target{{Main: ".", Name: "gen"}}},
+	}
+
+	err := c.buildModules(context.Background(), binPath, modules)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []call{
+		{workingDir: ".", output: "./bin/app", main: "./cmd/app"},
+		{workingDir: "tools/gen", output: "../../bin/gen", main: "."},
+	}, calls)
+	assert.Len(t, c.outputs.artifacts, 2)
+	assert.Equal(t, "./bin/gen", c.outputs.artifacts[1].Path)
+}
+
+func TestCommand_moduleSelectors(t *testing.T) {
+	c := new(Command)
+	assert.Equal(t, []string{}, c.moduleSelectors())
+
+	c.flags.modules = "./tools/gen, github.com/foo/bar,,"
+	assert.Equal(t, []string{"./tools/gen", "github.com/foo/bar"}, c.moduleSelectors())
+}
@@ -0,0 +1,200 @@
+package build
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const wildcard = "*"
+
+// variants are the valid sub-architecture variants for architectures and the environment variables for setting them.
+// A variant is mapped to the value of its environment variable.
+var variants = map[string]struct {
+	env    string
+	values map[string]string
+}{
+	"386":      {"GO386", map[string]string{"sse2": "sse2", "softfloat": "softfloat"}},
+	"amd64":    {"GOAMD64", map[string]string{"v1": "v1", "v2": "v2", "v3": "v3", "v4": "v4"}},
+	"arm":      {"GOARM", map[string]string{"v5": "5", "v6": "6", "v7": "7"}},
+	"arm64":    {"GOARM64", map[string]string{"v8.0": "v8.0", "v8.1": "v8.1", "v8.2": "v8.2", "v8.3": "v8.3", "v8.4": "v8.4", "v8.5": "v8.5", "v9.0": "v9.0", "v9.1": "v9.1", "v9.2": "v9.2", "v9.3": "v9.3", "v9.4": "v9.4", "v9.5": "v9.5"}},
+	"mips":     {"GOMIPS", map[string]string{"hardfloat": "hardfloat", "softfloat": "softfloat"}},
+	"mipsle":   {"GOMIPS", map[string]string{"hardfloat": "hardfloat", "softfloat": "softfloat"}},
+	"mips64":   {"GOMIPS64", map[string]string{"hardfloat": "hardfloat", "softfloat": "softfloat"}},
+	"mips64le": {"GOMIPS64", map[string]string{"hardfloat": "hardfloat", "softfloat": "softfloat"}},
+	"ppc64":    {"GOPPC64", map[string]string{"power8": "power8", "power9": "power9", "power10": "power10"}},
+	"ppc64le":  {"GOPPC64", map[string]string{"power8": "power8", "power9": "power9", "power10": "power10"}},
+	"riscv64":  {"GORISCV64", map[string]string{"rva20u64": "rva20u64", "rva22u64": "rva22u64"}},
+	"wasm":     {"GOWASM", map[string]string{"satconv": "satconv", "signext": "signext"}},
+}
+
+// platform is a target platform for cross-compiling in the form of os-arch or os-arch-variant (e.g. linux-arm-v7).
+type platform struct {
+	os      string
+	arch    string
+	variant string
+}
+
+// parsePlatform parses a platform string.
+func parsePlatform(s string) (platform, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return platform{}, fmt.Errorf("invalid platform %q: expected os-arch or os-arch-variant", s)
+	}
+
+	p := platform{
+		os:   parts[0],
+		arch: parts[1],
+	}
+
+	if len(parts) == 3 {
+		p.variant = parts[2]
+	}
+
+	return p, nil
+}
+
+// String returns the platform in the form of os-arch or os-arch-variant.
+func (p platform) String() string {
+	if p.variant == "" {
+		return p.os + "-" + p.arch
+	}
+
+	return p.os + "-" + p.arch + "-" + p.variant
+}
+
+// env returns the environment variables for building a binary for the platform.
+func (p platform) env() map[string]string {
+	env := map[string]string{
+		"GOOS":   p.os,
+		"GOARCH": p.arch,
+	}
+
+	if p.variant != "" {
+		if v, ok := variants[p.arch]; ok {
+			if val, ok := v.values[p.variant]; ok {
+				env[v.env] = val
+			}
+		}
+	}
+
+	return env
+}
+
+// validVariants returns the valid sub-architecture variants for an architecture.
+func validVariants(arch string) []string {
+	v, ok := variants[arch]
+	if !ok {
+		return nil
+	}
+
+	values := make([]string, 0, len(v.values))
+	for variant := range v.values {
+		values = append(values, variant)
+	}
+	sort.Strings(values)
+
+	return values
+}
+
+// parseDistList parses the output of the go tool dist list command into platforms.
+func parseDistList(out string) []platform {
+	platforms := []platform{}
+	for _, line := range strings.Split(out, "\n") {
+		if goos, goarch, ok := strings.Cut(strings.TrimSpace(line), "/"); ok {
+			platforms = append(platforms, platform{os: goos, arch: goarch})
+		}
+	}
+
+	return platforms
+}
+
+// supportedPlatforms returns the platforms supported by the toolchain from a list of platforms.
+func supportedPlatforms(entries []string, supported []platform) []string {
+	platforms := []string{}
+	for _, entry := range entries {
+		p, err := parsePlatform(entry)
+		if err != nil {
+			continue
+		}
+
+		for _, s := range supported {
+			if p.os == s.os && p.arch == s.arch {
+				platforms = append(platforms, entry)
+				break
+			}
+		}
+	}
+
+	return platforms
+}
+
+// resolvePlatforms validates a list of platforms against the platforms supported by the toolchain.
+// Wildcards (e.g. linux-* and *-arm64) are expanded to all supported platforms matching them.
+// All invalid entries are reported together with the list of valid platforms.
+func resolvePlatforms(entries []string, supported []platform) ([]string, error) {
+	resolved := []string{}
+	seen := map[string]bool{}
+	invalid := []string{}
+
+	add := func(p platform) {
+		if s := p.String(); !seen[s] {
+			seen[s] = true
+			resolved = append(resolved, s)
+		}
+	}
+
+	for _, entry := range entries {
+		p, err := parsePlatform(entry)
+		if err != nil {
+			invalid = append(invalid, entry)
+			continue
+		}
+
+		matched := []platform{}
+		for _, s := range supported {
+			if (p.os == wildcard || p.os == s.os) && (p.arch == wildcard || p.arch == s.arch) {
+				matched = append(matched, platform{os: s.os, arch: s.arch, variant: p.variant})
+			}
+		}
+
+		if len(matched) == 0 {
+			invalid = append(invalid, entry)
+			continue
+		}
+
+		if p.variant != "" {
+			ok := true
+			for _, m := range matched {
+				if _, valid := variants[m.arch].values[p.variant]; !valid {
+					if values := validVariants(m.arch); len(values) > 0 {
+						invalid = append(invalid, fmt.Sprintf("%s (valid variants for %s: %s)", entry, m.arch, strings.Join(values, ", ")))
+					} else {
+						invalid = append(invalid, fmt.Sprintf("%s (no variant for %s)", entry, m.arch))
+					}
+					ok = false
+					break
+				}
+			}
+
+			if !ok {
+				continue
+			}
+		}
+
+		for _, m := range matched {
+			add(m)
+		}
+	}
+
+	if len(invalid) > 0 {
+		valid := make([]string, 0, len(supported))
+		for _, s := range supported {
+			valid = append(valid, s.String())
+		}
+
+		return nil, fmt.Errorf("invalid platforms: %s\nvalid platforms: %s", strings.Join(invalid, ", "), strings.Join(valid, ", "))
+	}
+
+	return resolved, nil
+}
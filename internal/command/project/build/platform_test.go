@@ -0,0 +1,195 @@
+package build
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePlatform(t *testing.T) {
+	tests := []struct {
+		name             string
+		s                string
+		expectedPlatform platform
+		expectedError    string
+	}{
+		{
+			name:          "Invalid",
+			s:             "linux",
+			expectedError: `invalid platform "linux": expected os-arch or os-arch-variant`,
+		},
+		{
+			name:          "TooManyParts",
+			s:             "linux-arm-v7-extra",
+			expectedError: `invalid platform "linux-arm-v7-extra": expected os-arch or os-arch-variant`,
+		},
+		{
+			name:             "OSArch",
+			s:                "linux-amd64",
+			expectedPlatform: platform{os: "linux", arch: "amd64"},
+		},
+		{
+			name:             "OSArchVariant",
+			s:                "linux-arm-v7",
+			expectedPlatform: platform{os: "linux", arch: "arm", variant: "v7"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := parsePlatform(tc.s)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedPlatform, p)
+				assert.Equal(t, tc.s, p.String())
+			}
+		})
+	}
+}
+
+func TestPlatform_env(t *testing.T) {
+	tests := []struct {
+		name        string
+		p           platform
+		expectedEnv map[string]string
+	}{
+		{
+			name: "NoVariant",
+			p:    platform{os: "linux", arch: "amd64"},
+			expectedEnv: map[string]string{
+				"GOOS":   "linux",
+				"GOARCH": "amd64",
+			},
+		},
+		{
+			name: "ARM",
+			p:    platform{os: "linux", arch: "arm", variant: "v6"},
+			expectedEnv: map[string]string{
+				"GOOS":   "linux",
+				"GOARCH": "arm",
+				"GOARM":  "6",
+			},
+		},
+		{
+			name: "AMD64",
+			p:    platform{os: "linux", arch: "amd64", variant: "v3"},
+			expectedEnv: map[string]string{
+				"GOOS":    "linux",
+				"GOARCH":  "amd64",
+				"GOAMD64": "v3",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedEnv, tc.p.env())
+		})
+	}
+}
+
+func TestParseDistList(t *testing.T) {
+	out := "darwin/arm64\nlinux/amd64\n\nlinux/arm\n"
+
+	assert.Equal(t, []platform{
+		{os: "darwin", arch: "arm64"},
+		{os: "linux", arch: "amd64"},
+		{os: "linux", arch: "arm"},
+	}, parseDistList(out))
+}
+
+func TestSupportedPlatforms(t *testing.T) {
+	supported := []platform{
+		{os: "linux", arch: "amd64"},
+		{os: "linux", arch: "arm"},
+		{os: "windows", arch: "arm64"},
+	}
+
+	platforms := supportedPlatforms([]string{"linux-amd64", "linux-arm-v7", "windows-arm", "windows-arm64", "linux"}, supported)
+
+	assert.Equal(t, []string{"linux-amd64", "linux-arm-v7", "windows-arm64"}, platforms)
+}
+
+func TestResolvePlatforms(t *testing.T) {
+	supported := []platform{
+		{os: "darwin", arch: "amd64"},
+		{os: "darwin", arch: "arm64"},
+		{os: "js", arch: "wasm"},
+		{os: "linux", arch: "amd64"},
+		{os: "linux", arch: "arm"},
+		{os: "linux", arch: "arm64"},
+		{os: "linux", arch: "s390x"},
+		{os: "windows", arch: "amd64"},
+	}
+
+	valid := "valid platforms: darwin-amd64, darwin-arm64, js-wasm, linux-amd64, linux-arm, linux-arm64, linux-s390x, windows-amd64"
+
+	tests := []struct {
+		name              string
+		entries           []string
+		expectedPlatforms []string
+		expectedError     string
+	}{
+		{
+			name:              "Empty",
+			entries:           []string{},
+			expectedPlatforms: []string{},
+		},
+		{
+			name:              "Exact",
+			entries:           []string{"linux-amd64", "js-wasm"},
+			expectedPlatforms: []string{"linux-amd64", "js-wasm"},
+		},
+		{
+			name:              "OSWildcard",
+			entries:           []string{"linux-*"},
+			expectedPlatforms: []string{"linux-amd64", "linux-arm", "linux-arm64", "linux-s390x"},
+		},
+		{
+			name:              "ArchWildcard",
+			entries:           []string{"*-arm64"},
+			expectedPlatforms: []string{"darwin-arm64", "linux-arm64"},
+		},
+		{
+			name:              "Duplicates",
+			entries:           []string{"linux-amd64", "*-amd64", "linux-*"},
+			expectedPlatforms: []string{"linux-amd64", "darwin-amd64", "windows-amd64", "linux-arm", "linux-arm64", "linux-s390x"},
+		},
+		{
+			name:              "Variants",
+			entries:           []string{"linux-arm-v7", "linux-amd64-v3", "linux-arm-v6"},
+			expectedPlatforms: []string{"linux-arm-v7", "linux-amd64-v3", "linux-arm-v6"},
+		},
+		{
+			name:          "Unknown",
+			entries:       []string{"linux-amd64", "plan10-amd64", "linux"},
+			expectedError: "invalid platforms: plan10-amd64, linux\n" + valid,
+		},
+		{
+			name:          "InvalidVariant",
+			entries:       []string{"linux-arm-v9"},
+			expectedError: "invalid platforms: linux-arm-v9 (valid variants for arm: v5, v6, v7)\n" + valid,
+		},
+		{
+			name:          "NoVariant",
+			entries:       []string{"linux-s390x-v1"},
+			expectedError: "invalid platforms: linux-s390x-v1 (no variant for s390x)\n" + valid,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			platforms, err := resolvePlatforms(tc.entries, supported)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedPlatforms, platforms)
+			}
+		})
+	}
+}
@@ -35,7 +35,7 @@ const (
     -name        the name of the new project
     -owner       the owner id for the new project (team name, id, email, etc.)
     -profile     the profile (template) name for creating the new project based off it
-    -dockerid    the Docker ID for building container images for the new project
+    -dockerid    the Docker ID for building container images for the new project (written to project.docker_id in the spec file)
 
   Examples:
     basil project create
@@ -143,6 +143,9 @@ type Command struct {
 		owner    string
 		dockerid string
 	}
+	funcs struct {
+		setDockerID func(string, string) error
+	}
 	services struct {
 		repo     repoService
 		archive  archiveService
@@ -187,6 +190,7 @@ func (c *Command) Run(args []string) int {
 	c.services.repo = github.NewClient(token).Repo(templateOwner, templateRepo)
 	c.services.archive = archive.NewTarArchive(c.ui)
 	c.services.template = template.NewService(c.ui)
+	c.funcs.setDockerID = spec.SetDockerID
 
 	return c.exec()
 }
@@ -317,6 +321,16 @@ func (c *Command) exec() int {
 		return command.TemplateError
 	}
 
+	// ==============================> WRITE SPEC FILE <==============================
+
+	// The Docker ID is kept in the spec file, so the project can build container images
+	if c.flags.dockerid != "" {
+		if err := c.funcs.setDockerID(projectPath, c.flags.dockerid); err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.SpecError
+		}
+	}
+
 	// ==============================> DONE <==============================
 
 	return command.Success
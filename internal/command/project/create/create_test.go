@@ -2,6 +2,7 @@ package create
 
 import (
 	"errors"
+	"path/filepath"
 	"testing"
 
 	"github.com/gardenbed/go-github"
@@ -65,6 +66,7 @@ func TestCommand_Run(t *testing.T) {
 		assert.NotNil(t, c.services.repo)
 		assert.NotNil(t, c.services.archive)
 		assert.NotNil(t, c.services.template)
+		assert.NotNil(t, c.funcs.setDockerID)
 	})
 }
 
@@ -114,7 +116,9 @@ func TestCommand_exec(t *testing.T) {
 		repo             *MockRepoService
 		archive          *MockArchiveService
 		template         *MockTemplateService
+		setDockerID      func(string, string) error
 		expectedExitCode int
+		expectedDockerID string
 	}{
 		{
 			name: "SelectProfileFails",
@@ -385,6 +389,59 @@ func TestCommand_exec(t *testing.T) {
 			},
 			expectedExitCode: command.TemplateError,
 		},
+		{
+			name: "SetDockerIDFails",
+			ui: &MockUI{
+				UI: ui.NewNop(),
+				SelectMocks: []SelectMock{
+					{
+						OutItem: ui.Item{
+							Key: "test-project",
+						},
+					},
+				},
+				AskMocks: []AskMock{
+					{OutValue: "test-project"},
+					{OutValue: "my-team"},
+					{OutValue: "orca"},
+				},
+			},
+			repo: &MockRepoService{
+				DownloadTarArchiveMocks: []DownloadTarArchiveMock{
+					{OutResponse: &github.Response{}},
+				},
+			},
+			archive: &MockArchiveService{
+				ExtractMocks: []ExtractMock{
+					{OutError: nil},
+				},
+			},
+			template: &MockTemplateService{
+				LoadMocks: []LoadMock{
+					{OutError: nil},
+				},
+				ParamsMocks: []ParamsMock{
+					{OutParams: template.Params{"Name", "Owner", "DockerID"}},
+				},
+				TemplateMocks: []TemplateMock{
+					{
+						OutTemplate: &template.Template{
+							Edits: template.Edits{
+								Deletes:  template.Deletes{},
+								Moves:    template.Moves{},
+								Appends:  template.Appends{},
+								Replaces: template.Replaces{},
+							},
+						},
+					},
+				},
+			},
+			setDockerID: func(string, string) error {
+				return errors.New("spec error")
+			},
+			expectedExitCode: command.SpecError,
+			expectedDockerID: "orca",
+		},
 		{
 			name: "Success",
 			ui: &MockUI{
@@ -432,7 +489,11 @@ func TestCommand_exec(t *testing.T) {
 					},
 				},
 			},
+			setDockerID: func(string, string) error {
+				return nil
+			},
 			expectedExitCode: command.Success,
+			expectedDockerID: "orca",
 		},
 	}
 
@@ -446,9 +507,17 @@ func TestCommand_exec(t *testing.T) {
 			c.services.archive = tc.archive
 			c.services.template = tc.template
 
+			var dockerID string
+			c.funcs.setDockerID = func(dir, id string) error {
+				dockerID = id
+				assert.Equal(t, "test-project", filepath.Base(dir))
+				return tc.setDockerID(dir, id)
+			}
+
 			exitCode := c.exec()
 
 			assert.Equal(t, tc.expectedExitCode, exitCode)
+			assert.Equal(t, tc.expectedDockerID, dockerID)
 		})
 	}
 }
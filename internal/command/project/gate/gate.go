@@ -0,0 +1,344 @@
+// Package gate implements the command for running the quality gates of a project.
+package gate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+
+	"github.com/gardenbed/charm/flagit"
+	"github.com/gardenbed/charm/shell"
+	"github.com/mitchellh/cli"
+	"golang.org/x/mod/modfile"
+
+	"github.com/gardenbed/basil-cli/internal/command"
+	"github.com/gardenbed/basil-cli/internal/gomod"
+	"github.com/gardenbed/basil-cli/internal/spec"
+	"github.com/gardenbed/basil-cli/internal/ui"
+)
+
+const (
+	timeout  = 30 * time.Minute
+	synopsis = `Run quality gates`
+	help     = `
+  Use this command for running the quality gates of a project.
+  The same quality gates run before building and releasing, unless they are skipped with -skip-gates.
+
+  The quality gates are configured under project.gates in the spec file and all of them are disabled by default.
+  All enabled gates run even if one fails and the results are reported as a table.
+  The gates run in every module of the project (the modules of the go.work file or every go.mod file).
+
+  Gates:
+    vet           go vet ./... reports no issue
+    test          go test ./... passes (with the race detector if race is enabled)
+    tidy          go mod tidy -diff reports no change to go.mod and go.sum
+    no_replace    go.mod has no replace directive
+
+  Usage:  basil project gate [flags]
+
+  Flags:
+    -vet           run go vet (default: {{.Project.Gates.Vet}})
+    -test          run go test (default: {{.Project.Gates.Test}})
+    -race          run go test with the race detector (default: {{.Project.Gates.Race}})
+    -tidy          check go.mod and go.sum are tidy (default: {{.Project.Gates.Tidy}})
+    -no-replace    check go.mod has no replace directive (default: {{.Project.Gates.NoReplace}})
+    -module        run the gates only in the given modules (comma-separated directories or module paths)
+
+  Examples:
+    basil project gate
+    basil project gate -vet -test -race
+    basil project gate -module ./tools/gen
+  `
+)
+
+// Result is the result of running a quality gate.
+type Result struct {
+	Name string
+	// Module is the module the gate ran in.
+	Module   string
+	Passed   bool
+	Duration time.Duration
+	// Output is the output of a failed gate.
+	Output string
+}
+
+// Command is the cli.Command implementation for gate command.
+type Command struct {
+	ui    ui.UI
+	spec  spec.Spec
+	flags struct {
+		modules string
+	}
+	funcs struct {
+		goVet     shell.RunnerWithFunc
+		goTest    shell.RunnerWithFunc
+		goModTidy shell.RunnerWithFunc
+		readFile  func(string) ([]byte, error)
+	}
+	outputs struct {
+		results []Result
+	}
+}
+
+// New creates a new command.
+func New(ui ui.UI, spec spec.Spec) *Command {
+	return &Command{
+		ui:   ui,
+		spec: spec,
+	}
+}
+
+// NewFactory returns a cli.CommandFactory for creating a new command.
+func NewFactory(ui ui.UI, spec spec.Spec) cli.CommandFactory {
+	return func() (cli.Command, error) {
+		return New(ui, spec), nil
+	}
+}
+
+// Synopsis returns a short one-line synopsis for the command.
+func (c *Command) Synopsis() string {
+	return synopsis
+}
+
+// Help returns a long help text including usage, description, and list of flags for the command.
+func (c *Command) Help() string {
+	var buf bytes.Buffer
+	t := template.Must(template.New("help").Parse(help))
+	_ = t.Execute(&buf, c.spec)
+	return buf.String()
+}
+
+// Run runs the actual command with the given command-line arguments.
+// This method is used as a proxy for creating dependencies and the actual command execution is delegated to the run method for testing purposes.
+func (c *Command) Run(args []string) int {
+	if code := c.parseFlags(args); code != command.Success {
+		return code
+	}
+
+	c.funcs.goVet = combinedRunner("go", "vet", "./...")
+	c.funcs.goTest = combinedRunner("go", "test")
+	c.funcs.goModTidy = combinedRunner("go", "mod", "tidy", "-diff")
+	c.funcs.readFile = os.ReadFile
+
+	return c.exec()
+}
+
+func (c *Command) parseFlags(args []string) int {
+	fs := flag.NewFlagSet("gate", flag.ContinueOnError)
+
+	fs.Usage = func() {
+		c.ui.Printf(c.Help())
+	}
+
+	if err := flagit.Register(fs, &c.spec.Project.Gates, false); err != nil {
+		return command.GenericError
+	}
+
+	fs.StringVar(&c.flags.modules, "module", "", "")
+
+	if err := fs.Parse(args); err != nil {
+		// In case of error, the error and help will be printed by the Parse method
+		return command.FlagError
+	}
+
+	return command.Success
+}
+
+// exec in an auxiliary method, so we can test the business logic with mock dependencies.
+func (c *Command) exec() int {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	gates := c.spec.Project.Gates
+	if !gates.Enabled() {
+		c.ui.Warnf(ui.Yellow, "No quality gate is enabled.")
+		return command.Success
+	}
+
+	// The gates run in the same modules as the build
+	modules, err := gomod.Find(".")
+	if err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.OSError
+	}
+
+	modules, err = gomod.Select(modules, gomod.ParseSelectors(c.flags.modules))
+	if err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.FlagError
+	}
+
+	c.ui.Infof(ui.Green, "Running quality gates ...")
+
+	c.outputs.results = nil
+
+	for _, mod := range modules {
+		opts := shell.RunOptions{WorkingDir: mod.Dir}
+
+		if gates.Vet {
+			c.run("vet", mod, func() error {
+				_, _, err := c.funcs.goVet(ctx, opts)
+				return err
+			})
+		}
+
+		if gates.Test {
+			c.run("test", mod, func() error {
+				args := []string{"./..."}
+				if gates.Race {
+					args = append([]string{"-race"}, args...)
+				}
+
+				_, _, err := c.funcs.goTest(ctx, opts, args...)
+				return err
+			})
+		}
+
+		if gates.Tidy {
+			c.run("tidy", mod, func() error {
+				_, _, err := c.funcs.goModTidy(ctx, opts)
+				return err
+			})
+		}
+
+		if gates.NoReplace {
+			c.run("no_replace", mod, func() error {
+				return c.checkNoReplace(mod.Dir)
+			})
+		}
+	}
+
+	c.printResults()
+
+	failed := 0
+	for _, result := range c.outputs.results {
+		if !result.Passed {
+			failed++
+			c.ui.Errorf(ui.Red, "Quality gate %s failed in %s:\n%s", result.Name, result.Module, result.Output)
+		}
+	}
+
+	if failed > 0 {
+		c.ui.Errorf(ui.Red, "%d of %d quality gates failed.", failed, len(c.outputs.results))
+		return command.GateError
+	}
+
+	return command.Success
+}
+
+// combinedRunner returns a runner that reports the combined standard output and error of a failed command.
+// go test and go mod tidy -diff report failures on the standard output, which is not included in the errors of shell runners.
+// The errors have the same format as the errors of shell runners.
+func combinedRunner(command string, args ...string) shell.RunnerWithFunc {
+	return func(ctx context.Context, opts shell.RunOptions, a ...string) (int, string, error) {
+		all := append(append([]string{}, args...), a...)
+
+		cmd := exec.CommandContext(ctx, command, all...)
+		cmd.Dir = opts.WorkingDir
+
+		cmd.Env = os.Environ()
+		for key, val := range opts.Environment {
+			cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, val))
+		}
+
+		out, err := cmd.CombinedOutput()
+		output := strings.Trim(string(out), "\n")
+
+		if err != nil {
+			code := -1 // Unknown exit code
+			if exitErr, ok := err.(*exec.ExitError); ok {
+				code = exitErr.ExitCode()
+			}
+
+			return code, "", fmt.Errorf("error on running %s: %s: %s", strings.Join(append([]string{command}, all...), " "), err, output)
+		}
+
+		return 0, output, nil
+	}
+}
+
+// run runs a quality gate in a module and records its result.
+func (c *Command) run(name string, mod gomod.Module, gate func() error) {
+	start := time.Now()
+	err := gate()
+
+	result := Result{
+		Name:     name,
+		Module:   mod.Name(),
+		Passed:   err == nil,
+		Duration: time.Since(start),
+	}
+
+	if err != nil {
+		result.Output = err.Error()
+	}
+
+	c.outputs.results = append(c.outputs.results, result)
+}
+
+// checkNoReplace verifies the go.mod file of a module has no replace directive.
+func (c *Command) checkNoReplace(dir string) error {
+	data, err := c.funcs.readFile(filepath.Join(dir, gomod.ModFile))
+	if err != nil {
+		return err
+	}
+
+	f, err := modfile.Parse(gomod.ModFile, data, nil)
+	if err != nil {
+		return err
+	}
+
+	if len(f.Replace) == 0 {
+		return nil
+	}
+
+	replaces := make([]string, 0, len(f.Replace))
+	for _, r := range f.Replace {
+		replaces = append(replaces, fmt.Sprintf("  %s => %s", strings.TrimSpace(r.Old.Path+" "+r.Old.Version), strings.TrimSpace(r.New.Path+" "+r.New.Version)))
+	}
+
+	return errors.New("replace directives in go.mod:\n" + strings.Join(replaces, "\n"))
+}
+
+// printResults prints the results of the quality gates as a table.
+// The table is printed at the info level, so it can be silenced together with other progress messages.
+func (c *Command) printResults() {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "GATE\tMODULE\tRESULT\tDURATION")
+	for _, result := range c.outputs.results {
+		status := "PASS"
+		if !result.Passed {
+			status = "FAIL"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", result.Name, result.Module, status, result.Duration.Round(time.Millisecond))
+	}
+
+	_ = w.Flush()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	c.ui.Infof(ui.Cyan, "%s", lines[0])
+
+	for i, result := range c.outputs.results {
+		style := ui.Green
+		if !result.Passed {
+			style = ui.Red
+		}
+		c.ui.Infof(style, "%s", lines[i+1])
+	}
+}
+
+// Results returns the results of the last run of quality gates.
+func (c *Command) Results() []Result {
+	return c.outputs.results
+}
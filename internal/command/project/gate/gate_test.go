@@ -0,0 +1,364 @@
+package gate
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gardenbed/charm/shell"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/basil-cli/internal/command"
+	"github.com/gardenbed/basil-cli/internal/spec"
+	"github.com/gardenbed/basil-cli/internal/ui"
+)
+
+func TestNew(t *testing.T) {
+	ui := ui.NewNop()
+	c := New(ui, spec.Spec{})
+
+	assert.NotNil(t, c)
+}
+
+func TestNewFactory(t *testing.T) {
+	ui := ui.NewNop()
+	c, err := NewFactory(ui, spec.Spec{})()
+
+	assert.NoError(t, err)
+	assert.NotNil(t, c)
+}
+
+func TestCommand_Synopsis(t *testing.T) {
+	c := new(Command)
+	synopsis := c.Synopsis()
+
+	assert.NotEmpty(t, synopsis)
+}
+
+func TestCommand_Help(t *testing.T) {
+	c := new(Command)
+	help := c.Help()
+
+	assert.NotEmpty(t, help)
+}
+
+func TestCommand_Run(t *testing.T) {
+	t.Run("InvalidFlag", func(t *testing.T) {
+		c := &Command{ui: ui.NewNop()}
+		exitCode := c.Run([]string{"-undefined"})
+
+		assert.Equal(t, command.FlagError, exitCode)
+	})
+
+	t.Run("OK", func(t *testing.T) {
+		c := &Command{ui: ui.NewNop()}
+		exitCode := c.Run([]string{})
+
+		assert.Equal(t, command.Success, exitCode)
+		assert.NotNil(t, c.funcs.goVet)
+		assert.NotNil(t, c.funcs.goTest)
+		assert.NotNil(t, c.funcs.goModTidy)
+		assert.NotNil(t, c.funcs.readFile)
+	})
+}
+
+func TestCommand_parseFlags(t *testing.T) {
+	tests := []struct {
+		name             string
+		args             []string
+		expectedExitCode int
+		expectedGates    spec.Gates
+		expectedModules  string
+	}{
+		{
+			name:             "InvalidFlag",
+			args:             []string{"-undefined"},
+			expectedExitCode: command.FlagError,
+		},
+		{
+			name:             "NoFlag",
+			args:             []string{},
+			expectedExitCode: command.Success,
+		},
+		{
+			name:             "ValidFlags",
+			args:             []string{"-vet", "-test", "-race", "-tidy", "-no-replace", "-module", "./tools/gen"},
+			expectedExitCode: command.Success,
+			expectedGates: spec.Gates{
+				Vet:       true,
+				Test:      true,
+				Race:      true,
+				Tidy:      true,
+				NoReplace: true,
+			},
+			expectedModules: "./tools/gen",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Command{ui: ui.NewNop()}
+			exitCode := c.parseFlags(tc.args)
+
+			assert.Equal(t, tc.expectedExitCode, exitCode)
+			if exitCode == command.Success {
+				assert.Equal(t, tc.expectedGates, c.spec.Project.Gates)
+				assert.Equal(t, tc.expectedModules, c.flags.modules)
+			}
+		})
+	}
+}
+
+func TestCommand_exec(t *testing.T) {
+	allGates := spec.Gates{
+		Vet:       true,
+		Test:      true,
+		Race:      true,
+		Tidy:      true,
+		NoReplace: true,
+	}
+
+	tests := []struct {
+		name             string
+		gates            spec.Gates
+		goVet            shell.RunnerWithFunc
+		goTest           shell.RunnerWithFunc
+		goModTidy        shell.RunnerWithFunc
+		readFile         func(string) ([]byte, error)
+		expectedExitCode int
+		expectedResults  map[string]bool
+	}{
+		{
+			name:             "NoGate",
+			gates:            spec.Gates{},
+			expectedExitCode: command.Success,
+			expectedResults:  map[string]bool{},
+		},
+		{
+			name:  "AllGatesFail",
+			gates: allGates,
+			goVet: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+				return 1, "", errors.New("vet error")
+			},
+			goTest: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+				return 1, "", errors.New("test error")
+			},
+			goModTidy: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+				return 1, "", errors.New("tidy error")
+			},
+			readFile: func(string) ([]byte, error) {
+				return nil, errors.New("file error")
+			},
+			expectedExitCode: command.GateError,
+			expectedResults: map[string]bool{
+				"vet":        false,
+				"test":       false,
+				"tidy":       false,
+				"no_replace": false,
+			},
+		},
+		{
+			name:  "SomeGatesFail",
+			gates: allGates,
+			goVet: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			goTest: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+				return 1, "", errors.New("test error")
+			},
+			goModTidy: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			readFile: func(string) ([]byte, error) {
+				return []byte("module github.com/foo/bar\n\nreplace github.com/foo/baz => ../baz\n"), nil
+			},
+			expectedExitCode: command.GateError,
+			expectedResults: map[string]bool{
+				"vet":        true,
+				"test":       false,
+				"tidy":       true,
+				"no_replace": false,
+			},
+		},
+		{
+			name:  "Success",
+			gates: allGates,
+			goVet: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			goTest: func(_ context.Context, _ shell.RunOptions, args ...string) (int, string, error) {
+				if len(args) != 2 || args[0] != "-race" {
+					return 1, "", errors.New("race detector not enabled")
+				}
+				return 0, "", nil
+			},
+			goModTidy: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			readFile: func(string) ([]byte, error) {
+				return []byte("module github.com/foo/bar\n\nrequire github.com/foo/baz v1.0.0\n"), nil
+			},
+			expectedExitCode: command.Success,
+			expectedResults: map[string]bool{
+				"vet":        true,
+				"test":       true,
+				"tidy":       true,
+				"no_replace": true,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Command{ui: ui.NewNop()}
+			c.spec.Project.Gates = tc.gates
+			c.funcs.goVet = tc.goVet
+			c.funcs.goTest = tc.goTest
+			c.funcs.goModTidy = tc.goModTidy
+			c.funcs.readFile = tc.readFile
+
+			exitCode := c.exec()
+
+			assert.Equal(t, tc.expectedExitCode, exitCode)
+
+			results := map[string]bool{}
+			for _, result := range c.Results() {
+				results[result.Name] = result.Passed
+			}
+			assert.Equal(t, tc.expectedResults, results)
+		})
+	}
+}
+
+func TestCommand_exec_Modules(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module github.com/foo/bar\n"), 0644))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "tools", "gen"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "tools", "gen", "go.mod"), []byte("module github.com/foo/bar/tools/gen\n\nreplace github.com/foo/bar => ../..\n"), 0644))
+	t.Chdir(dir)
+
+	tests := []struct {
+		name             string
+		modules          string
+		expectedExitCode int
+		expectedDirs     []string
+		expectedResults  []Result
+	}{
+		{
+			name:             "ModuleNotFound",
+			modules:          "services/api",
+			expectedExitCode: command.FlagError,
+		},
+		{
+			name:             "AllModules",
+			expectedExitCode: command.GateError,
+			expectedDirs:     []string{".", "tools/gen"},
+			expectedResults: []Result{
+				{Name: "vet", Module: "github.com/foo/bar", Passed: true},
+				{Name: "no_replace", Module: "github.com/foo/bar", Passed: true},
+				{Name: "vet", Module: "github.com/foo/bar/tools/gen", Passed: true},
+				{Name: "no_replace", Module: "github.com/foo/bar/tools/gen", Passed: false, Output: "replace directives in go.mod:\n  github.com/foo/bar => ../.."},
+			},
+		},
+		{
+			name:             "SelectedModules",
+			modules:          ".",
+			expectedExitCode: command.Success,
+			expectedDirs:     []string{"."},
+			expectedResults: []Result{
+				{Name: "vet", Module: "github.com/foo/bar", Passed: true},
+				{Name: "no_replace", Module: "github.com/foo/bar", Passed: true},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var dirs []string
+
+			c := &Command{ui: ui.NewNop()}
+			c.spec.Project.Gates = spec.Gates{Vet: true, NoReplace: true}
+			c.flags.modules = tc.modules
+			c.funcs.goVet = func(_ context.Context, opts shell.RunOptions, _ ...string) (int, string, error) {
+				dirs = append(dirs, opts.WorkingDir)
+				return 0, "", nil
+			}
+			c.funcs.readFile = os.ReadFile
+
+			exitCode := c.exec()
+
+			assert.Equal(t, tc.expectedExitCode, exitCode)
+			assert.Equal(t, tc.expectedDirs, dirs)
+
+			results := c.Results()
+			for i := range results {
+				results[i].Duration = 0
+			}
+			assert.Equal(t, tc.expectedResults, results)
+		})
+	}
+}
+
+func TestCommand_checkNoReplace(t *testing.T) {
+	tests := []struct {
+		name          string
+		goMod         string
+		expectedError string
+	}{
+		{
+			name:          "InvalidGoMod",
+			goMod:         "module",
+			expectedError: "go.mod:1: usage: module module/path",
+		},
+		{
+			name:  "NoReplace",
+			goMod: "module github.com/foo/bar\n",
+		},
+		{
+			name:          "Replace",
+			goMod:         "module github.com/foo/bar\n\nreplace (\n\tgithub.com/foo/baz => ../baz\n\tgithub.com/foo/qux v1.0.0 => github.com/bar/qux v1.1.0\n)\n",
+			expectedError: "replace directives in go.mod:\n  github.com/foo/baz => ../baz\n  github.com/foo/qux v1.0.0 => github.com/bar/qux v1.1.0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := new(Command)
+			c.funcs.readFile = func(string) ([]byte, error) {
+				return []byte(tc.goMod), nil
+			}
+
+			err := c.checkNoReplace(".")
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCombinedRunner(t *testing.T) {
+	run := combinedRunner("sh", "-c")
+
+	code, _, err := run(context.Background(), shell.RunOptions{}, "echo passed")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, code)
+
+	code, _, err = run(context.Background(), shell.RunOptions{}, "echo failed; echo error >&2; exit 3")
+	assert.EqualError(t, err, "error on running sh -c echo failed; echo error >&2; exit 3: exit status 3: failed\nerror")
+	assert.Equal(t, 3, code)
+
+	dir := t.TempDir()
+	_, _, err = run(context.Background(), shell.RunOptions{WorkingDir: dir}, "pwd; exit 1")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), dir)
+
+	code, out, err := run(context.Background(), shell.RunOptions{Environment: map[string]string{"GATE": "vet"}}, "echo $GATE")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "vet", out)
+}
@@ -0,0 +1,313 @@
+package release
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	changelogspec "github.com/gardenbed/changelog/spec"
+	"github.com/gardenbed/charm/shell"
+	"github.com/gardenbed/go-github"
+
+	"github.com/gardenbed/basil-cli/internal/notify"
+	"github.com/gardenbed/basil-cli/internal/semver"
+	"github.com/gardenbed/basil-cli/internal/ui"
+)
+
+// releasePlan records the mutating steps of a release in dry-run mode instead of executing them.
+type releasePlan struct {
+	sync.Mutex
+	steps     []string
+	changelog string
+}
+
+func (p *releasePlan) record(format string, args ...interface{}) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.steps = append(p.steps, fmt.Sprintf(format, args...))
+}
+
+// runner returns a shell.RunnerFunc that records a command instead of running it.
+func (p *releasePlan) runner(command string) shell.RunnerFunc {
+	return func(_ context.Context, args ...string) (int, string, error) {
+		cmd := command
+		for _, arg := range args {
+			if arg == "" || strings.ContainsAny(arg, " \t\n\"'") {
+				arg = strconv.Quote(arg)
+			}
+			cmd += " " + arg
+		}
+
+		p.record("%s", cmd)
+
+		return 0, "", nil
+	}
+}
+
+// runnerWith returns a shell.RunnerWithFunc that records a command instead of running it.
+func (p *releasePlan) runnerWith(command string) shell.RunnerWithFunc {
+	runner := p.runner(command)
+	return func(ctx context.Context, _ shell.RunOptions, args ...string) (int, string, error) {
+		return runner(ctx, args...)
+	}
+}
+
+// print prints the resolved version, the recorded steps, and the generated changelog.
+func (p *releasePlan) print(u ui.UI, version semver.SemVer) {
+	p.Lock()
+	defer p.Unlock()
+
+	u.Infof(ui.Cyan, "Dry-run plan for release %s (nothing was pushed or changed remotely):", version)
+
+	for i, step := range p.steps {
+		u.Printf("  %2d. %s", i+1, step)
+	}
+
+	if p.changelog != "" {
+		// The generated changelog is the section for the new release, so it is printed as is rather than as a diff
+		u.Infof(ui.Cyan, "Changelog (generated):")
+		for _, line := range strings.Split(strings.TrimRight(p.changelog, "\n"), "\n") {
+			u.Printf("  %s", line)
+		}
+	}
+}
+
+// dryRunRepoService records repository mutations instead of executing them.
+type dryRunRepoService struct {
+	repoService
+	plan *releasePlan
+}
+
+func (s *dryRunRepoService) BranchProtection(_ context.Context, branch string, enabled bool) (*github.Response, error) {
+	if enabled {
+		s.plan.record("Re-enable branch protection for %s", branch)
+	} else {
+		s.plan.record("Temporarily disable branch protection for %s", branch)
+	}
+
+	return &github.Response{}, nil
+}
+
+// dryRunReleaseService records release mutations instead of executing them.
+type dryRunReleaseService struct {
+	releaseService
+	plan *releasePlan
+	tags sync.Map // Release ids to tag names
+}
+
+func (s *dryRunReleaseService) describe(params github.ReleaseParams) string {
+	if params.Draft {
+		return "draft release " + params.TagName
+	}
+	return "release " + params.TagName
+}
+
+func (s *dryRunReleaseService) Create(_ context.Context, params github.ReleaseParams) (*github.Release, *github.Response, error) {
+	s.plan.record("Create %s targeting %s", s.describe(params), params.Target)
+	s.tags.Store(0, params.TagName)
+
+	return &github.Release{
+		Name:       params.Name,
+		TagName:    params.TagName,
+		Target:     params.Target,
+		Draft:      params.Draft,
+		Prerelease: params.Prerelease,
+		Body:       params.Body,
+	}, &github.Response{}, nil
+}
+
+func (s *dryRunReleaseService) Update(_ context.Context, id int, params github.ReleaseParams) (*github.Release, *github.Response, error) {
+	if params.Draft {
+		s.plan.record("Update %s", s.describe(params))
+	} else {
+		s.plan.record("Publish %s", s.describe(params))
+	}
+	s.tags.Store(id, params.TagName)
+
+	return &github.Release{
+		ID:         id,
+		Name:       params.Name,
+		TagName:    params.TagName,
+		Target:     params.Target,
+		Draft:      params.Draft,
+		Prerelease: params.Prerelease,
+		Body:       params.Body,
+	}, &github.Response{}, nil
+}
+
+func (s *dryRunReleaseService) UploadAsset(_ context.Context, id int, assetFile, assetLabel string) (*github.ReleaseAsset, *github.Response, error) {
+	target := fmt.Sprintf("#%d", id)
+	if tag, ok := s.tags.Load(id); ok {
+		target = tag.(string)
+	}
+
+	s.plan.record("Upload %s to release %s", assetFile, target)
+
+	return &github.ReleaseAsset{
+		Name:  assetFile,
+		Label: assetLabel,
+	}, &github.Response{}, nil
+}
+
+func (s *dryRunReleaseService) Delete(_ context.Context, id int) (*github.Response, error) {
+	target := fmt.Sprintf("#%d", id)
+	if tag, ok := s.tags.Load(id); ok {
+		target = tag.(string)
+	}
+
+	s.plan.record("Delete draft release %s", target)
+
+	return &github.Response{}, nil
+}
+
+// dryRunPullService records pull request mutations instead of executing them.
+type dryRunPullService struct {
+	pullService
+	plan *releasePlan
+}
+
+func (s *dryRunPullService) Create(_ context.Context, params github.CreatePullParams) (*github.Pull, *github.Response, error) {
+	s.plan.record("Create pull request %q from %s into %s", params.Title, params.Head, params.Base)
+
+	return &github.Pull{
+		State: "open",
+		Title: params.Title,
+		Body:  params.Body,
+		Head:  github.PullBranch{Ref: params.Head},
+		Base:  github.PullBranch{Ref: params.Base},
+	}, &github.Response{}, nil
+}
+
+func (s *dryRunPullService) Update(_ context.Context, number int, params github.UpdatePullParams) (*github.Pull, *github.Response, error) {
+	s.plan.record("Update pull request #%d %q into %s", number, params.Title, params.Base)
+
+	return &github.Pull{
+		Number: number,
+		State:  "open",
+		Title:  params.Title,
+		Body:   params.Body,
+		Base:   github.PullBranch{Ref: params.Base},
+	}, &github.Response{}, nil
+}
+
+// dryRunChangelogService generates the changelog for real, but restores the changelog file afterwards.
+type dryRunChangelogService struct {
+	changelogService
+	plan *releasePlan
+}
+
+func (s *dryRunChangelogService) Generate(ctx context.Context, spec changelogspec.Spec) (string, error) {
+	file := spec.General.File
+
+	original, err := os.ReadFile(file)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+	existed := err == nil
+
+	changelog, genErr := s.changelogService.Generate(ctx, spec)
+
+	// Restore the changelog file regardless of the generation result
+	if existed {
+		err = os.WriteFile(file, original, 0644)
+	} else {
+		err = os.Remove(file)
+	}
+
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+
+	if genErr != nil {
+		return "", genErr
+	}
+
+	s.plan.record("Update %s", file)
+
+	s.plan.Lock()
+	s.plan.changelog = changelog
+	s.plan.Unlock()
+
+	return changelog, nil
+}
+
+// dryRunNotifyService records notifications instead of sending them.
+type dryRunNotifyService struct {
+	notifyService
+	plan *releasePlan
+}
+
+func (s *dryRunNotifyService) Notify(_ context.Context, release notify.Release) error {
+	for _, endpoint := range s.Endpoints() {
+		s.plan.record("Notify %s of release %s", endpoint, release.Tag)
+	}
+
+	return nil
+}
+
+// dryRunBuildCommand builds the artifacts for real, but records pushing the images instead of pushing them.
+type dryRunBuildCommand struct {
+	buildCommand
+	plan *releasePlan
+}
+
+func (b *dryRunBuildCommand) PushImages(context.Context) error {
+	manifest, err := b.Manifest()
+	if err != nil {
+		return err
+	}
+
+	for _, image := range manifest.Images {
+		b.plan.record("Push image %s", image.Label)
+	}
+
+	return nil
+}
+
+// dryRunJournalService loads the release journal, but does not change it.
+type dryRunJournalService struct {
+	journalService
+}
+
+func (s *dryRunJournalService) Save(*releaseJournal) error {
+	return nil
+}
+
+func (s *dryRunJournalService) Delete() error {
+	return nil
+}
+
+// enableDryRun replaces all mutating runners and services with the ones recording a release plan.
+// All read-only steps are still executed for real.
+func (c *Command) enableDryRun() {
+	p := new(releasePlan)
+	c.outputs.plan = p
+
+	c.funcs.gitPull = p.runner("git pull")
+	c.funcs.gitBranch = p.runner("git branch")
+	c.funcs.gitCheckout = p.runner("git checkout")
+	c.funcs.gitAdd = p.runner("git add " + c.data.changelogSpec.General.File)
+	c.funcs.gitAddAll = p.runner("git add --all")
+	c.funcs.gitCommit = p.runner("git commit -m")
+	c.funcs.gitTag = p.runner("git tag")
+	c.funcs.gitReset = p.runner("git reset --hard")
+	c.funcs.gitPush = p.runner("git push")
+	c.funcs.gitPushTag = p.runner("git push " + remoteName)
+	c.funcs.gitPushBranch = p.runner("git push -u " + remoteName)
+	c.funcs.gpgSign = p.runner("gpg --yes --armor --detach-sign")
+	c.funcs.hook = p.runnerWith("sh -c")
+
+	c.services.repo = &dryRunRepoService{repoService: c.services.repo, plan: p}
+	c.services.releases = &dryRunReleaseService{releaseService: c.services.releases, plan: p}
+	c.services.pulls = &dryRunPullService{pullService: c.services.pulls, plan: p}
+	c.services.changelog = &dryRunChangelogService{changelogService: c.services.changelog, plan: p}
+	c.services.journal = &dryRunJournalService{journalService: c.services.journal}
+	c.services.notify = &dryRunNotifyService{notifyService: c.services.notify, plan: p}
+	c.commands.build = &dryRunBuildCommand{buildCommand: c.commands.build, plan: p}
+}
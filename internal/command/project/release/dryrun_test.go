@@ -0,0 +1,361 @@
+package release
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	changelogspec "github.com/gardenbed/changelog/spec"
+	"github.com/gardenbed/charm/shell"
+	"github.com/gardenbed/go-github"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/basil-cli/internal/command"
+	buildcmd "github.com/gardenbed/basil-cli/internal/command/project/build"
+	"github.com/gardenbed/basil-cli/internal/spec"
+	"github.com/gardenbed/basil-cli/internal/ui"
+)
+
+func TestReleasePlan_runner(t *testing.T) {
+	p := new(releasePlan)
+
+	_, _, err := p.runner("git tag")(context.Background(), "-a", "v0.1.0", "-m", "Release 0.1.0")
+	assert.NoError(t, err)
+
+	_, _, err = p.runner("git push")(context.Background())
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		`git tag -a v0.1.0 -m "Release 0.1.0"`,
+		`git push`,
+	}, p.steps)
+}
+
+func TestReleasePlan_runnerWith(t *testing.T) {
+	p := new(releasePlan)
+
+	_, _, err := p.runnerWith("sh -c")(context.Background(), shell.RunOptions{}, "go test ./...")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		`sh -c "go test ./..."`,
+	}, p.steps)
+}
+
+func TestDryRunChangelogService_Generate(t *testing.T) {
+	tests := []struct {
+		name              string
+		existing          string
+		changelog         *MockChangelogService
+		expectedError     string
+		expectedChangelog string
+	}{
+		{
+			name:     "GenerateFails",
+			existing: "# Changelog\n",
+			changelog: &MockChangelogService{
+				GenerateMocks: []GenerateMock{
+					{OutError: errors.New("changelog error")},
+				},
+			},
+			expectedError: "changelog error",
+		},
+		{
+			name: "NewFile",
+			changelog: &MockChangelogService{
+				GenerateMocks: []GenerateMock{
+					{OutContent: "## v0.1.0\n"},
+				},
+			},
+			expectedChangelog: "## v0.1.0\n",
+		},
+		{
+			name:     "ExistingFile",
+			existing: "# Changelog\n",
+			changelog: &MockChangelogService{
+				GenerateMocks: []GenerateMock{
+					{OutContent: "## v0.1.0\n"},
+				},
+			},
+			expectedChangelog: "## v0.1.0\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "CHANGELOG.md")
+			if tc.existing != "" {
+				assert.NoError(t, os.WriteFile(file, []byte(tc.existing), 0644))
+			}
+
+			// The changelog service overwrites the changelog file just like the real one.
+			generator := &fileWritingChangelogService{MockChangelogService: tc.changelog, file: file}
+
+			p := new(releasePlan)
+			s := &dryRunChangelogService{changelogService: generator, plan: p}
+
+			changelogSpec := changelogspec.Spec{}
+			changelogSpec.General.File = file
+			changelog, err := s.Generate(context.Background(), changelogSpec)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedChangelog, changelog)
+				assert.Equal(t, tc.expectedChangelog, p.changelog)
+				assert.Equal(t, []string{"Update " + file}, p.steps)
+			}
+
+			// Verify the changelog file is restored
+			b, err := os.ReadFile(file)
+			if tc.existing == "" {
+				assert.True(t, os.IsNotExist(err))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.existing, string(b))
+			}
+		})
+	}
+}
+
+type fileWritingChangelogService struct {
+	*MockChangelogService
+	file string
+}
+
+func (s *fileWritingChangelogService) Generate(ctx context.Context, spec changelogspec.Spec) (string, error) {
+	_ = os.WriteFile(s.file, []byte("modified"), 0644)
+	return s.MockChangelogService.Generate(ctx, spec)
+}
+
+func TestCommand_exec_DryRun(t *testing.T) {
+	tests := []struct {
+		name             string
+		mode             spec.ReleaseMode
+		image            spec.Image
+		repo             *MockRepoService
+		users            *MockUserService
+		releases         *MockReleaseService
+		pulls            *MockPullService
+		search           *MockSearchService
+		changelog        *MockChangelogService
+		build            *MockBuildCommand
+		notify           *MockNotifyService
+		expectedExitCode int
+		expectedSteps    []string
+	}{
+		{
+			name: "DirectMode",
+			mode: spec.ReleaseModeDirect,
+			repo: &MockRepoService{
+				GetMocks: []GetMock{
+					{OutRepository: &repo, OutResponse: &github.Response{}},
+				},
+				PermissionMocks: []PermissionMock{
+					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				},
+			},
+			users: &MockUserService{
+				UserMocks: []UserMock{
+					{OutUser: &user, OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{},
+			changelog: &MockChangelogService{
+				GenerateMocks: []GenerateMock{
+					{OutContent: "changelog"},
+				},
+			},
+			build: &MockBuildCommand{
+				RunMocks: []BuildRunMock{
+					{OutCode: command.Success},
+				},
+				ManifestMocks: []ManifestMock{
+					{OutManifest: manifest},
+				},
+			},
+			notify: &MockNotifyService{
+				EndpointsMocks: []EndpointsMock{
+					{OutEndpoints: []string{"https://hooks.slack.com"}},
+					{OutEndpoints: []string{"https://hooks.slack.com"}},
+				},
+			},
+			expectedExitCode: command.Success,
+			expectedSteps: []string{
+				"git pull",
+				"Create draft release v0.1.0 targeting main",
+				"Update CHANGELOG.md",
+				"git add CHANGELOG.md",
+				`git commit -m "Release 0.1.0"`,
+				`git tag -a v0.1.0 -m "Release 0.1.0"`,
+				"Upload bin/app to release v0.1.0",
+				"Temporarily disable branch protection for main",
+				"git push",
+				"git push origin v0.1.0",
+				"Publish release v0.1.0",
+				"Re-enable branch protection for main",
+				"Notify https://hooks.slack.com of release v0.1.0",
+			},
+		},
+		{
+			name:  "DirectMode_PushImages",
+			mode:  spec.ReleaseModeDirect,
+			image: spec.Image{Enabled: true, Push: true},
+			repo: &MockRepoService{
+				GetMocks: []GetMock{
+					{OutRepository: &repo, OutResponse: &github.Response{}},
+				},
+				PermissionMocks: []PermissionMock{
+					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				},
+			},
+			users: &MockUserService{
+				UserMocks: []UserMock{
+					{OutUser: &user, OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{},
+			changelog: &MockChangelogService{
+				GenerateMocks: []GenerateMock{
+					{OutContent: "changelog"},
+				},
+			},
+			build: &MockBuildCommand{
+				RunMocks: []BuildRunMock{
+					{OutCode: command.Success},
+				},
+				ManifestMocks: []ManifestMock{
+					{OutManifest: manifest},
+					{OutManifest: buildcmd.Manifest{
+						Images: []buildcmd.ManifestEntry{
+							{Path: "bin/app_0.1.0_image.tar", Label: "docker.io/octocat/app:0.1.0", OS: "linux"},
+						},
+					}},
+				},
+			},
+			notify: &MockNotifyService{
+				EndpointsMocks: []EndpointsMock{{}},
+			},
+			expectedExitCode: command.Success,
+			expectedSteps: []string{
+				"git pull",
+				"Create draft release v0.1.0 targeting main",
+				"Update CHANGELOG.md",
+				"git add CHANGELOG.md",
+				`git commit -m "Release 0.1.0"`,
+				`git tag -a v0.1.0 -m "Release 0.1.0"`,
+				"Upload bin/app to release v0.1.0",
+				"Temporarily disable branch protection for main",
+				"git push",
+				"git push origin v0.1.0",
+				"Publish release v0.1.0",
+				"Re-enable branch protection for main",
+				"Push image docker.io/octocat/app:0.1.0",
+			},
+		},
+		{
+			name: "IndirectMode",
+			mode: spec.ReleaseModeIndirect,
+			repo: &MockRepoService{
+				GetMocks: []GetMock{
+					{OutRepository: &repo, OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{},
+			pulls:    &MockPullService{},
+			search: &MockSearchService{
+				SearchIssuesMocks: []SearchIssuesMock{
+					{OutResult: emptySearchResult, OutResponse: &github.Response{}},
+					{OutResult: emptySearchResult, OutResponse: &github.Response{}},
+				},
+			},
+			changelog: &MockChangelogService{
+				GenerateMocks: []GenerateMock{
+					{OutContent: "changelog"},
+				},
+			},
+			expectedExitCode: command.Success,
+			expectedSteps: []string{
+				"git pull",
+				"Update CHANGELOG.md",
+				"git checkout -b release-0.1.0",
+				"git add CHANGELOG.md",
+				`git commit -m "Release 0.1.0"`,
+				"git push -u origin -f release-0.1.0",
+				"git checkout main",
+				"git branch -D release-0.1.0",
+				`Create pull request "RELEASE 0.1.0" from release-0.1.0 into main`,
+				"Create draft release v0.1.0 targeting main",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Command{
+				ui: ui.NewNop(),
+				spec: spec.Spec{
+					Project: spec.Project{
+						Build: spec.Build{
+							Image: tc.image,
+						},
+						Release: spec.Release{
+							Mode: tc.mode,
+						},
+					},
+				},
+			}
+
+			c.flags.patch = true
+			c.flags.dryRun = true
+
+			c.data.owner = "octocat"
+			c.data.repo = "Hello-World"
+			c.data.changelogSpec.General.File = "CHANGELOG.md"
+
+			c.funcs.gitRevBranch = func(context.Context, ...string) (int, string, error) {
+				return 0, "main", nil
+			}
+			c.funcs.gitStatus = successRunnerFunc
+			c.funcs.gitRevParse = successRunnerFunc
+			c.funcs.goList = successRunnerFunc
+			c.services.repo = tc.repo
+			c.services.users = tc.users
+			c.services.releases = tc.releases
+			c.services.pulls = tc.pulls
+			c.services.search = tc.search
+			c.services.changelog = tc.changelog
+			c.services.notify = tc.notify
+			c.services.journal = &MockJournalService{
+				LoadMocks: []JournalLoadMock{{}},
+			}
+			c.commands.semver = &MockSemverCommand{
+				RunMocks: []SemverRunMock{
+					{OutCode: command.Success},
+				},
+				SemVerMocks: []SemVerMock{
+					{OutSemVer: version},
+				},
+				TagPrefixMocks: []TagPrefixMock{
+					{OutTagPrefix: ""},
+				},
+			}
+			c.commands.build = tc.build
+
+			exitCode := c.exec()
+
+			assert.Equal(t, tc.expectedExitCode, exitCode)
+			assert.Equal(t, tc.expectedSteps, c.outputs.plan.steps)
+
+			// The images are not pushed in dry-run mode
+			if tc.build != nil {
+				assert.Equal(t, 0, tc.build.PushImagesIndex)
+				assert.Equal(t, []string{"-skip-gates", "-push=false", "-module", "."}, tc.build.RunMocks[0].InArgs)
+			}
+			assert.Equal(t, "changelog", c.outputs.plan.changelog)
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package release
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/gardenbed/charm/flagit"
+	"github.com/mitchellh/cli"
+
+	"github.com/gardenbed/basil-cli/internal/command"
+	"github.com/gardenbed/basil-cli/internal/config"
+	"github.com/gardenbed/basil-cli/internal/semver"
+	"github.com/gardenbed/basil-cli/internal/spec"
+	"github.com/gardenbed/basil-cli/internal/ui"
+)
+
+const (
+	finishSynopsis = `Finish an indirect release`
+	finishHelp     = `
+  Use this command for finishing an indirect release after its pull request is merged.
+  It is meant to be run non-interactively in a CI pipeline (e.g. a GitHub action running when the release pull request is merged).
+
+  The release is identified either by the number of the merged pull request or by the release commit SHA.
+  The release version is read from the pull request title (RELEASE <version>) or the release commit message.
+  The release commit is tagged, the artifacts are built and uploaded to the draft release, and the release is published.
+  The after_tag and after_publish hooks run and the release notifications are sent as configured in the spec file.
+  No branch is checked and no change is pulled, so it can run in a detached HEAD checkout of the release commit.
+  The artifacts are built from the current checkout, so the release commit should be checked out.
+  For a module in a sub-directory of a repository (monorepo), run this command from the module directory.
+
+  The access token is read from the GITHUB_TOKEN or GITLAB_TOKEN environment variable.
+  If the environment variable is not set, the access token from the config file is used.
+
+  Usage:  basil project release finish [flags] <pull request number | commit sha>
+
+  Flags:
+    -sign         sign the artifacts and checksums with gpg (default: {{.Project.Release.Sign}})
+    -signing-key  the gpg key for signing the artifacts (default: the gpg default key)
+
+  Examples:
+    basil project release finish 1001
+    basil project release finish e9e71afc9382f03807042fd2e1bda25bf4f099fb
+    basil project release finish -sign "$GITHUB_SHA"
+  `
+)
+
+const (
+	githubTokenEnv = "GITHUB_TOKEN"
+	gitlabTokenEnv = "GITLAB_TOKEN"
+)
+
+var (
+	shaRegex         = regexp.MustCompile(`^[0-9A-Fa-f]{7,40}$`)
+	releaseNameRegex = regexp.MustCompile(`(?mi)^release (\S+)`)
+)
+
+// FinishCommand is the cli.Command implementation for release finish command.
+// It shares the dependencies and the business logic of the release command.
+type FinishCommand struct {
+	*Command
+	args struct {
+		number int
+		sha    string
+	}
+}
+
+// NewFinish creates a new command.
+func NewFinish(ui ui.UI, config config.Config, spec spec.Spec) *FinishCommand {
+	return &FinishCommand{
+		Command: New(ui, config, spec),
+	}
+}
+
+// NewFinishFactory returns a cli.CommandFactory for creating a new command.
+func NewFinishFactory(ui ui.UI, config config.Config, spec spec.Spec) cli.CommandFactory {
+	return func() (cli.Command, error) {
+		return NewFinish(ui, config, spec), nil
+	}
+}
+
+// Synopsis returns a short one-line synopsis for the command.
+func (c *FinishCommand) Synopsis() string {
+	return finishSynopsis
+}
+
+// Help returns a long help text including usage, description, and list of flags for the command.
+func (c *FinishCommand) Help() string {
+	var buf bytes.Buffer
+	t := template.Must(template.New("help").Parse(finishHelp))
+	_ = t.Execute(&buf, c.spec)
+	return buf.String()
+}
+
+// Run runs the actual command with the given command-line arguments.
+// This method is used as a proxy for creating dependencies and the actual command execution is delegated to the run method for testing purposes.
+func (c *FinishCommand) Run(args []string) int {
+	if code := c.parseFlags(args); code != command.Success {
+		return code
+	}
+
+	// In CI pipelines, the access tokens are provided through environment variables
+	if token := os.Getenv(githubTokenEnv); token != "" {
+		c.config.GitHub.AccessToken = token
+	}
+
+	if token := os.Getenv(gitlabTokenEnv); token != "" {
+		c.config.GitLab.AccessToken = token
+	}
+
+	if code := c.setup(); code != command.Success {
+		return code
+	}
+
+	return c.exec()
+}
+
+func (c *FinishCommand) parseFlags(args []string) int {
+	fs := flag.NewFlagSet("release finish", flag.ContinueOnError)
+
+	fs.Usage = func() {
+		c.ui.Printf(c.Help())
+	}
+
+	if err := flagit.Register(fs, &c.spec.Project.Release, false); err != nil {
+		return command.GenericError
+	}
+
+	if err := fs.Parse(args); err != nil {
+		// In case of error, the error and help will be printed by the Parse method
+		return command.FlagError
+	}
+
+	if fs.NArg() != 1 {
+		c.ui.Errorf(ui.Red, "A pull request number or a commit SHA is required.")
+		return command.FlagError
+	}
+
+	ref := fs.Arg(0)
+
+	if number, err := strconv.Atoi(strings.TrimPrefix(ref, "#")); err == nil && number > 0 {
+		c.args.number = number
+	} else if shaRegex.MatchString(ref) {
+		c.args.sha = ref
+	} else {
+		c.ui.Errorf(ui.Red, "Invalid pull request number or commit SHA: %s", ref)
+		return command.FlagError
+	}
+
+	return command.Success
+}
+
+// exec in an auxiliary method, so we can test the business logic with mock dependencies.
+func (c *FinishCommand) exec() int {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// ==============================> RUN PREFLIGHT CHECKS <==============================
+
+	c.ui.Printf("Running preflight checks ...")
+
+	checklist := command.PreflightChecklist{
+		GPG: c.spec.Project.Release.Sign,
+		Git: true,
+		Go:  true,
+	}
+
+	if _, err := command.RunPreflightChecks(ctx, checklist); err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.PreflightError
+	}
+
+	// ==============================> RESOLVE THE RELEASE COMMIT <==============================
+
+	var commit, message string
+
+	if c.args.number > 0 {
+		c.ui.Printf("Getting the pull request #%d ...", c.args.number)
+
+		pull, _, err := c.services.pulls.Get(ctx, c.args.number)
+		if err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GitHubError
+		}
+
+		if !pull.Merged {
+			c.ui.Errorf(ui.Red, "The pull request #%d is not merged.", c.args.number)
+			return command.GitHubError
+		}
+
+		commit, message = pull.MergeCommitSHA, pull.Title
+	} else {
+		_, sha, err := c.funcs.gitRevParse(ctx, "--verify", c.args.sha+"^{commit}")
+		if err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GitError
+		}
+
+		_, msg, err := c.funcs.gitShow(ctx, sha)
+		if err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GitError
+		}
+
+		commit, message = sha, msg
+	}
+
+	prefix, version, ok := parseReleaseName(message)
+	if !ok {
+		c.ui.Errorf(ui.Red, "No release version found for %s", commit)
+		return command.GenericError
+	}
+
+	c.outputs.version = version
+	c.data.tagPrefix = prefix
+
+	_, head, err := c.funcs.gitRevParse(ctx, "HEAD")
+	if err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.GitError
+	}
+
+	if head != commit {
+		c.ui.Warnf(ui.Yellow, "The release commit %s is not checked out and the artifacts are built from %s.", commit, head)
+	}
+
+	// ==============================> FIND THE DRAFT RELEASE <==============================
+
+	c.ui.Printf("Finishing the release %s of %s/%s ...", c.releaseName(), c.data.owner, c.data.repo)
+
+	repo, _, err := c.services.repo.Get(ctx)
+	if err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.GitHubError
+	}
+
+	release, code := c.findDraftRelease(ctx, c.tagName())
+	if code != command.Success {
+		return code
+	}
+
+	// A release not targeting the default branch is a maintenance release
+	c.data.maintenance = release.Target != repo.DefaultBranch
+
+	// ==============================> TAG AND PUBLISH THE RELEASE <==============================
+
+	return c.publishDraftRelease(ctx, release, commit)
+}
+
+// parseReleaseName finds the release name in a pull request title or a commit message (e.g. RELEASE 0.1.0).
+// For a module in a sub-directory, the release name is prefixed with the module path (e.g. RELEASE services/billing/0.1.0).
+func parseReleaseName(message string) (string, semver.SemVer, bool) {
+	for _, m := range releaseNameRegex.FindAllStringSubmatch(message, -1) {
+		i := strings.LastIndex(m[1], "/")
+		prefix, name := m[1][:i+1], m[1][i+1:]
+
+		if version, ok := semver.Parse(name); ok {
+			return prefix, version, true
+		}
+	}
+
+	return "", semver.SemVer{}, false
+}
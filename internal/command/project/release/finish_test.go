@@ -0,0 +1,578 @@
+package release
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gardenbed/charm/shell"
+	"github.com/gardenbed/go-github"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/basil-cli/internal/command"
+	"github.com/gardenbed/basil-cli/internal/config"
+	"github.com/gardenbed/basil-cli/internal/semver"
+	"github.com/gardenbed/basil-cli/internal/spec"
+	"github.com/gardenbed/basil-cli/internal/ui"
+)
+
+var (
+	releaseSHA = "e9e71afc9382f03807042fd2e1bda25bf4f099fb"
+
+	releasePull = &github.Pull{
+		ID:             1,
+		Number:         1001,
+		Title:          "RELEASE 0.1.0",
+		Merged:         true,
+		MergeCommitSHA: releaseSHA,
+		HTMLURL:        "https://github.com/octocat/Hello-World/pull/1001",
+	}
+
+	maintenanceDraftRelease = github.Release{
+		Name:    "0.1.0",
+		TagName: "v0.1.0",
+		Target:  "release/0.1",
+		Draft:   true,
+	}
+)
+
+func gitRevParseFunc(head, commit string) shell.RunnerFunc {
+	return func(_ context.Context, args ...string) (int, string, error) {
+		if len(args) > 0 && args[0] == "HEAD" {
+			return 0, head, nil
+		}
+		return 0, commit, nil
+	}
+}
+
+func TestNewFinish(t *testing.T) {
+	ui := ui.NewNop()
+	config := config.Config{}
+	spec := spec.Spec{}
+	c := NewFinish(ui, config, spec)
+
+	assert.NotNil(t, c)
+}
+
+func TestNewFinishFactory(t *testing.T) {
+	ui := ui.NewNop()
+	config := config.Config{}
+	spec := spec.Spec{}
+	c, err := NewFinishFactory(ui, config, spec)()
+
+	assert.NoError(t, err)
+	assert.NotNil(t, c)
+}
+
+func TestFinishCommand_Synopsis(t *testing.T) {
+	c := NewFinish(ui.NewNop(), config.Config{}, spec.Spec{})
+	synopsis := c.Synopsis()
+
+	assert.NotEmpty(t, synopsis)
+}
+
+func TestFinishCommand_Help(t *testing.T) {
+	c := NewFinish(ui.NewNop(), config.Config{}, spec.Spec{})
+	help := c.Help()
+
+	assert.NotEmpty(t, help)
+}
+
+func TestFinishCommand_Run(t *testing.T) {
+	t.Run("InvalidFlag", func(t *testing.T) {
+		c := NewFinish(ui.NewNop(), config.Config{}, spec.Spec{})
+		exitCode := c.Run([]string{"-undefined"})
+
+		assert.Equal(t, command.FlagError, exitCode)
+	})
+
+	t.Run("AccessTokenFromEnv", func(t *testing.T) {
+		t.Setenv(githubTokenEnv, "github-token")
+		t.Setenv(gitlabTokenEnv, "gitlab-token")
+
+		c := NewFinish(ui.NewNop(), config.Config{}, spec.Spec{})
+		c.Run([]string{"0000000"})
+
+		assert.Equal(t, "github-token", c.config.GitHub.AccessToken)
+		assert.Equal(t, "gitlab-token", c.config.GitLab.AccessToken)
+		assert.Equal(t, "gardenbed", c.data.owner)
+		assert.Equal(t, "basil-cli", c.data.repo)
+		assert.NotNil(t, c.funcs.gitRevParse)
+		assert.NotNil(t, c.funcs.gitShow)
+		assert.NotNil(t, c.funcs.gitTag)
+		assert.NotNil(t, c.funcs.gitPushTag)
+		assert.NotNil(t, c.services.repo)
+		assert.NotNil(t, c.services.releases)
+		assert.NotNil(t, c.services.pulls)
+		assert.NotNil(t, c.services.notify)
+		assert.NotNil(t, c.commands.build)
+	})
+}
+
+func TestFinishCommand_parseFlags(t *testing.T) {
+	tests := []struct {
+		name             string
+		args             []string
+		expectedExitCode int
+		expectedNumber   int
+		expectedSHA      string
+	}{
+		{
+			name:             "InvalidFlag",
+			args:             []string{"-undefined"},
+			expectedExitCode: command.FlagError,
+		},
+		{
+			name:             "NoArg",
+			args:             []string{},
+			expectedExitCode: command.FlagError,
+		},
+		{
+			name:             "TooManyArgs",
+			args:             []string{"1001", releaseSHA},
+			expectedExitCode: command.FlagError,
+		},
+		{
+			name:             "InvalidArg",
+			args:             []string{"main"},
+			expectedExitCode: command.FlagError,
+		},
+		{
+			name:             "PullRequestNumber",
+			args:             []string{"-sign", "1001"},
+			expectedExitCode: command.Success,
+			expectedNumber:   1001,
+		},
+		{
+			name:             "PullRequestNumberWithHash",
+			args:             []string{"#1001"},
+			expectedExitCode: command.Success,
+			expectedNumber:   1001,
+		},
+		{
+			name:             "ShortCommitSHA",
+			args:             []string{"e9e71af"},
+			expectedExitCode: command.Success,
+			expectedSHA:      "e9e71af",
+		},
+		{
+			name:             "CommitSHA",
+			args:             []string{releaseSHA},
+			expectedExitCode: command.Success,
+			expectedSHA:      releaseSHA,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewFinish(ui.NewNop(), config.Config{}, spec.Spec{})
+			exitCode := c.parseFlags(tc.args)
+
+			assert.Equal(t, tc.expectedExitCode, exitCode)
+			assert.Equal(t, tc.expectedNumber, c.args.number)
+			assert.Equal(t, tc.expectedSHA, c.args.sha)
+		})
+	}
+}
+
+func TestFinishCommand_exec(t *testing.T) {
+	tests := []struct {
+		name                string
+		number              int
+		sha                 string
+		gitRevParse         shell.RunnerFunc
+		gitShow             shell.RunnerFunc
+		gitTag              shell.RunnerFunc
+		gitPushTag          shell.RunnerFunc
+		goList              shell.RunnerFunc
+		image               spec.Image
+		repo                *MockRepoService
+		pulls               *MockPullService
+		releases            *MockReleaseService
+		build               *MockBuildCommand
+		notify              *MockNotifyService
+		expectedExitCode    int
+		expectedVersion     semver.SemVer
+		expectedMaintenance bool
+	}{
+		{
+			name:   "GetPullFails",
+			number: 1001,
+			pulls: &MockPullService{
+				GetMocks: []PullGetMock{
+					{OutError: errors.New("github error")},
+				},
+			},
+			expectedExitCode: command.GitHubError,
+		},
+		{
+			name:   "PullNotMerged",
+			number: 1001,
+			pulls: &MockPullService{
+				GetMocks: []PullGetMock{
+					{OutPull: openPull, OutResponse: &github.Response{}},
+				},
+			},
+			expectedExitCode: command.GitHubError,
+		},
+		{
+			name:   "PullNotRelease",
+			number: 1001,
+			pulls: &MockPullService{
+				GetMocks: []PullGetMock{
+					{OutPull: mergedPull, OutResponse: &github.Response{}},
+				},
+			},
+			expectedExitCode: command.GenericError,
+		},
+		{
+			name: "GitRevParseFails",
+			sha:  "e9e71af",
+			gitRevParse: func(context.Context, ...string) (int, string, error) {
+				return 128, "", errors.New("unknown revision")
+			},
+			expectedExitCode: command.GitError,
+		},
+		{
+			name:        "GitShowFails",
+			sha:         "e9e71af",
+			gitRevParse: gitRevParseFunc(releaseSHA, releaseSHA),
+			gitShow: func(context.Context, ...string) (int, string, error) {
+				return 128, "", errors.New("git error")
+			},
+			expectedExitCode: command.GitError,
+		},
+		{
+			name:   "GitRevParseHeadFails",
+			number: 1001,
+			gitRevParse: func(context.Context, ...string) (int, string, error) {
+				return 128, "", errors.New("git error")
+			},
+			pulls: &MockPullService{
+				GetMocks: []PullGetMock{
+					{OutPull: releasePull, OutResponse: &github.Response{}},
+				},
+			},
+			expectedExitCode: command.GitError,
+		},
+		{
+			name:        "GetRepoFails",
+			number:      1001,
+			gitRevParse: gitRevParseFunc(releaseSHA, releaseSHA),
+			repo: &MockRepoService{
+				GetMocks: []GetMock{
+					{OutError: errors.New("github error")},
+				},
+			},
+			pulls: &MockPullService{
+				GetMocks: []PullGetMock{
+					{OutPull: releasePull, OutResponse: &github.Response{}},
+				},
+			},
+			expectedExitCode: command.GitHubError,
+		},
+		{
+			name:        "FindDraftReleaseFails",
+			number:      1001,
+			gitRevParse: gitRevParseFunc(releaseSHA, releaseSHA),
+			repo: &MockRepoService{
+				GetMocks: []GetMock{
+					{OutRepository: &repo, OutResponse: &github.Response{}},
+				},
+			},
+			pulls: &MockPullService{
+				GetMocks: []PullGetMock{
+					{OutPull: releasePull, OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				ListMocks: []ReleaseListMock{
+					{OutError: errors.New("github error")},
+				},
+			},
+			expectedExitCode: command.GitHubError,
+		},
+		{
+			name:        "GitTagFails",
+			number:      1001,
+			gitRevParse: gitRevParseFunc(releaseSHA, releaseSHA),
+			gitTag: func(context.Context, ...string) (int, string, error) {
+				return 128, "", errors.New("git error")
+			},
+			repo: &MockRepoService{
+				GetMocks: []GetMock{
+					{OutRepository: &repo, OutResponse: &github.Response{}},
+				},
+			},
+			pulls: &MockPullService{
+				GetMocks: []PullGetMock{
+					{OutPull: releasePull, OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				ListMocks: []ReleaseListMock{
+					{OutReleases: []github.Release{draftRelease}, OutResponse: &github.Response{}},
+				},
+			},
+			expectedExitCode: command.GitError,
+		},
+		{
+			name:        "PullRequest_Success",
+			number:      1001,
+			gitRevParse: gitRevParseFunc(releaseSHA, releaseSHA),
+			gitTag:      successRunnerFunc,
+			gitPushTag:  successRunnerFunc,
+			goList:      successRunnerFunc,
+			repo: &MockRepoService{
+				GetMocks: []GetMock{
+					{OutRepository: &repo, OutResponse: &github.Response{}},
+				},
+			},
+			pulls: &MockPullService{
+				GetMocks: []PullGetMock{
+					{OutPull: releasePull, OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				ListMocks: []ReleaseListMock{
+					{OutReleases: []github.Release{draftRelease}, OutResponse: &github.Response{}},
+				},
+				UploadAssetMocks: []ReleaseUploadAssetMock{
+					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
+				},
+				UpdateMocks: []ReleaseUpdateMock{
+					{OutRelease: &release, OutResponse: &github.Response{}},
+				},
+			},
+			build: &MockBuildCommand{
+				RunMocks: []BuildRunMock{
+					{OutCode: command.Success},
+				},
+				ManifestMocks: []ManifestMock{
+					{OutManifest: manifest},
+				},
+			},
+			notify: &MockNotifyService{
+				EndpointsMocks: []EndpointsMock{
+					{OutEndpoints: []string{"https://hooks.slack.com"}},
+				},
+				NotifyMocks: []NotifyMock{
+					{OutError: errors.New("notification to https://hooks.slack.com failed: unexpected status code: 500")},
+				},
+			},
+			expectedExitCode: command.Success,
+			expectedVersion:  version,
+		},
+		{
+			name:        "PullRequest_PushImagesFails",
+			number:      1001,
+			gitRevParse: gitRevParseFunc(releaseSHA, releaseSHA),
+			gitTag:      successRunnerFunc,
+			gitPushTag:  successRunnerFunc,
+			goList:      successRunnerFunc,
+			image:       spec.Image{Enabled: true, Push: true},
+			repo: &MockRepoService{
+				GetMocks: []GetMock{
+					{OutRepository: &repo, OutResponse: &github.Response{}},
+				},
+			},
+			pulls: &MockPullService{
+				GetMocks: []PullGetMock{
+					{OutPull: releasePull, OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				ListMocks: []ReleaseListMock{
+					{OutReleases: []github.Release{draftRelease}, OutResponse: &github.Response{}},
+				},
+				UploadAssetMocks: []ReleaseUploadAssetMock{
+					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
+				},
+				UpdateMocks: []ReleaseUpdateMock{
+					{OutRelease: &release, OutResponse: &github.Response{}},
+				},
+			},
+			build: &MockBuildCommand{
+				RunMocks: []BuildRunMock{
+					{OutCode: command.Success},
+				},
+				ManifestMocks: []ManifestMock{
+					{OutManifest: manifest},
+				},
+				PushImagesMocks: []PushImagesMock{
+					{OutError: errors.New("registry error")},
+				},
+			},
+			expectedExitCode: command.ImageError,
+		},
+		{
+			name:        "CommitSHA_DetachedHead_Success",
+			sha:         "e9e71af",
+			gitRevParse: gitRevParseFunc(releaseSHA, releaseSHA),
+			gitShow: func(context.Context, ...string) (int, string, error) {
+				return 0, "Merge pull request #1001 from octocat/release-0.1.0\n\nRELEASE 0.1.0\n", nil
+			},
+			gitTag:     successRunnerFunc,
+			gitPushTag: successRunnerFunc,
+			goList: func(context.Context, ...string) (int, string, error) {
+				return 1, "", errors.New("no go files")
+			},
+			repo: &MockRepoService{
+				GetMocks: []GetMock{
+					{OutRepository: &repo, OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				ListMocks: []ReleaseListMock{
+					{OutReleases: []github.Release{draftRelease}, OutResponse: &github.Response{}},
+				},
+				UpdateMocks: []ReleaseUpdateMock{
+					{OutRelease: &release, OutResponse: &github.Response{}},
+				},
+			},
+			notify: &MockNotifyService{
+				EndpointsMocks: []EndpointsMock{{}},
+			},
+			expectedExitCode: command.Success,
+			expectedVersion:  version,
+		},
+		{
+			name:        "MaintenanceRelease_Success",
+			number:      1001,
+			gitRevParse: gitRevParseFunc("a7f1b6c", releaseSHA),
+			gitTag:      successRunnerFunc,
+			gitPushTag:  successRunnerFunc,
+			goList: func(context.Context, ...string) (int, string, error) {
+				return 1, "", errors.New("no go files")
+			},
+			repo: &MockRepoService{
+				GetMocks: []GetMock{
+					{OutRepository: &repo, OutResponse: &github.Response{}},
+				},
+			},
+			pulls: &MockPullService{
+				GetMocks: []PullGetMock{
+					{OutPull: releasePull, OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				ListMocks: []ReleaseListMock{
+					{OutReleases: []github.Release{maintenanceDraftRelease}, OutResponse: &github.Response{}},
+				},
+				UpdateMocks: []ReleaseUpdateMock{
+					{OutRelease: &release, OutResponse: &github.Response{}},
+				},
+			},
+			notify: &MockNotifyService{
+				EndpointsMocks: []EndpointsMock{{}},
+			},
+			expectedExitCode:    command.Success,
+			expectedVersion:     version,
+			expectedMaintenance: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewFinish(ui.NewNop(), config.Config{}, spec.Spec{
+				Project: spec.Project{
+					Build: spec.Build{Image: tc.image},
+				},
+			})
+
+			c.args.number = tc.number
+			c.args.sha = tc.sha
+
+			c.data.owner = "octocat"
+			c.data.repo = "Hello-World"
+
+			c.funcs.gitRevParse = tc.gitRevParse
+			c.funcs.gitShow = tc.gitShow
+			c.funcs.gitTag = tc.gitTag
+			c.funcs.gitPushTag = tc.gitPushTag
+			c.funcs.goList = tc.goList
+			c.services.repo = tc.repo
+			c.services.pulls = tc.pulls
+			c.services.releases = tc.releases
+			c.services.notify = tc.notify
+			c.commands.build = tc.build
+
+			exitCode := c.exec()
+
+			assert.Equal(t, tc.expectedExitCode, exitCode)
+
+			// The images are pushed only once the release is published
+			if tc.build != nil && tc.build.PushImagesIndex > 0 {
+				assert.Equal(t, []string{"-skip-gates", "-push=false", "-module", "."}, tc.build.RunMocks[0].InArgs)
+				assert.Equal(t, 1, tc.releases.UpdateIndex)
+			}
+
+			if tc.expectedExitCode == command.Success {
+				assert.Equal(t, tc.expectedVersion, c.outputs.version)
+				assert.Equal(t, tc.expectedMaintenance, c.data.maintenance)
+			}
+		})
+	}
+}
+
+func TestParseReleaseName(t *testing.T) {
+	tests := []struct {
+		name            string
+		message         string
+		expectedOK      bool
+		expectedPrefix  string
+		expectedVersion semver.SemVer
+	}{
+		{
+			name:       "NoRelease",
+			message:    "Fix bugs",
+			expectedOK: false,
+		},
+		{
+			name:       "InvalidVersion",
+			message:    "Release notes for 0.1.0",
+			expectedOK: false,
+		},
+		{
+			name:            "PullRequestTitle",
+			message:         "RELEASE 0.1.0",
+			expectedOK:      true,
+			expectedVersion: version,
+		},
+		{
+			name:            "SquashCommit",
+			message:         "RELEASE 0.1.0 (#1001)\n\n* Release 0.1.0\n",
+			expectedOK:      true,
+			expectedVersion: version,
+		},
+		{
+			name:            "MergeCommit",
+			message:         "Merge pull request #1001 from octocat/release-0.1.0\n\nRELEASE 0.1.0\n",
+			expectedOK:      true,
+			expectedVersion: version,
+		},
+		{
+			name:            "PreRelease",
+			message:         "Release 1.3.0-rc.1\n",
+			expectedOK:      true,
+			expectedVersion: semver.SemVer{Major: 1, Minor: 3, Patch: 0, Prerelease: []string{"rc", "1"}},
+		},
+		{
+			name:            "Module",
+			message:         "RELEASE services/billing/0.1.0",
+			expectedOK:      true,
+			expectedPrefix:  "services/billing/",
+			expectedVersion: version,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			prefix, version, ok := parseReleaseName(tc.message)
+
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedPrefix, prefix)
+			assert.Equal(t, tc.expectedVersion, version)
+		})
+	}
+}
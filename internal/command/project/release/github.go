@@ -0,0 +1,117 @@
+package release
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gardenbed/go-github"
+)
+
+const (
+	makeLatestFalse = "false"
+)
+
+// githubReleaseService extends the GitHub release service for publishing releases that are not marked as the latest release.
+// By default, GitHub marks a published release as the latest release.
+type githubReleaseService struct {
+	*github.ReleaseService
+	client      *github.Client
+	owner, repo string
+	latest      func() bool
+}
+
+// Update updates a release.
+// If a published release should not be the latest release, it is explicitly marked as not the latest release.
+func (s *githubReleaseService) Update(ctx context.Context, id int, params github.ReleaseParams) (*github.Release, *github.Response, error) {
+	if params.Draft || s.latest() {
+		return s.ReleaseService.Update(ctx, id, params)
+	}
+
+	body := struct {
+		github.ReleaseParams
+		MakeLatest string `json:"make_latest"`
+	}{
+		ReleaseParams: params,
+		MakeLatest:    makeLatestFalse,
+	}
+
+	url := fmt.Sprintf("/repos/%s/%s/releases/%d", s.owner, s.repo, id)
+	req, err := s.client.NewRequest(ctx, "PATCH", url, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	release := new(github.Release)
+
+	resp, err := s.client.Do(req, release)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return release, resp, nil
+}
+
+// githubCheckService implements the checkService interface for GitHub.
+// The checks of a pull request are the check runs (e.g. GitHub Actions) and the commit statuses of its head commit.
+type githubCheckService struct {
+	client      *github.Client
+	owner, repo string
+}
+
+// Checks retrieves the checks of a pull request.
+func (s *githubCheckService) Checks(ctx context.Context, pull *github.Pull) ([]pullCheck, error) {
+	checks := []pullCheck{}
+
+	// See https://docs.github.com/rest/checks/runs#list-check-runs-for-a-git-reference
+	runs := struct {
+		CheckRuns []struct {
+			Name       string `json:"name"`
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+		} `json:"check_runs"`
+	}{}
+
+	url := fmt.Sprintf("/repos/%s/%s/commits/%s/check-runs", s.owner, s.repo, pull.Head.SHA)
+	if err := s.get(ctx, url, &runs); err != nil {
+		return nil, err
+	}
+
+	for _, run := range runs.CheckRuns {
+		state := checkPending
+		if run.Status == "completed" {
+			state = toCheckState(run.Conclusion)
+		}
+
+		checks = append(checks, pullCheck{Name: run.Name, State: state})
+	}
+
+	// See https://docs.github.com/rest/commits/statuses#get-the-combined-status-for-a-specific-reference
+	status := struct {
+		Statuses []struct {
+			Context string `json:"context"`
+			State   string `json:"state"`
+		} `json:"statuses"`
+	}{}
+
+	url = fmt.Sprintf("/repos/%s/%s/commits/%s/status", s.owner, s.repo, pull.Head.SHA)
+	if err := s.get(ctx, url, &status); err != nil {
+		return nil, err
+	}
+
+	for _, st := range status.Statuses {
+		checks = append(checks, pullCheck{Name: st.Context, State: toCheckState(st.State)})
+	}
+
+	return checks, nil
+}
+
+func (s *githubCheckService) get(ctx context.Context, url string, v interface{}) error {
+	req, err := s.client.NewPageRequest(ctx, "GET", url, 100, 1, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(req, v)
+
+	return err
+}
@@ -0,0 +1,176 @@
+package release
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gardenbed/go-github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitHubReleaseService_Update(t *testing.T) {
+	tests := []struct {
+		name              string
+		latest            bool
+		params            github.ReleaseParams
+		expectedNotLatest bool
+	}{
+		{
+			name:   "Draft",
+			latest: false,
+			params: github.ReleaseParams{
+				Name:    "1.4.3",
+				TagName: "v1.4.3",
+				Draft:   true,
+			},
+			expectedNotLatest: false,
+		},
+		{
+			name:   "Latest",
+			latest: true,
+			params: github.ReleaseParams{
+				Name:    "2.1.0",
+				TagName: "v2.1.0",
+			},
+			expectedNotLatest: false,
+		},
+		{
+			name:   "NotLatest",
+			latest: false,
+			params: github.ReleaseParams{
+				Name:    "1.4.3",
+				TagName: "v1.4.3",
+			},
+			expectedNotLatest: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var method, path, body string
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				method, path, body = r.Method, r.URL.Path, string(b)
+
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{ "id": 1, "name": "` + tc.params.Name + `", "tag_name": "` + tc.params.TagName + `" }`))
+			}))
+			defer ts.Close()
+
+			client, err := github.NewEnterpriseClient(ts.URL, ts.URL, ts.URL, "token")
+			assert.NoError(t, err)
+
+			s := &githubReleaseService{
+				ReleaseService: client.Repo("octocat", "Hello-World").Releases,
+				client:         client,
+				owner:          "octocat",
+				repo:           "Hello-World",
+				latest: func() bool {
+					return tc.latest
+				},
+			}
+
+			release, resp, err := s.Update(context.Background(), 1, tc.params)
+
+			assert.NoError(t, err)
+			assert.NotNil(t, resp)
+			assert.Equal(t, tc.params.TagName, release.TagName)
+			assert.Equal(t, "PATCH", method)
+			assert.Equal(t, "/repos/octocat/Hello-World/releases/1", path)
+
+			if tc.expectedNotLatest {
+				assert.Contains(t, body, `"make_latest":"false"`)
+			} else {
+				assert.NotContains(t, body, "make_latest")
+			}
+		})
+	}
+}
+
+func TestGitHubCheckService_Checks(t *testing.T) {
+	tests := []struct {
+		name           string
+		routes         map[string]string
+		expectedError  bool
+		expectedChecks []pullCheck
+	}{
+		{
+			name:          "CheckRunsFails",
+			routes:        map[string]string{},
+			expectedError: true,
+		},
+		{
+			name: "StatusFails",
+			routes: map[string]string{
+				"/repos/octocat/Hello-World/commits/25aa2bd/check-runs": `{ "total_count": 0, "check_runs": [] }`,
+			},
+			expectedError: true,
+		},
+		{
+			name: "Success",
+			routes: map[string]string{
+				"/repos/octocat/Hello-World/commits/25aa2bd/check-runs": `{
+					"total_count": 3,
+					"check_runs": [
+						{ "name": "build", "status": "completed", "conclusion": "success" },
+						{ "name": "test", "status": "completed", "conclusion": "failure" },
+						{ "name": "lint", "status": "in_progress", "conclusion": null }
+					]
+				}`,
+				"/repos/octocat/Hello-World/commits/25aa2bd/status": `{
+					"state": "pending",
+					"statuses": [
+						{ "context": "coverage", "state": "pending" }
+					]
+				}`,
+			},
+			expectedChecks: []pullCheck{
+				{Name: "build", State: checkSuccess},
+				{Name: "test", State: checkFailure},
+				{Name: "lint", State: checkPending},
+				{Name: "coverage", State: checkPending},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, ok := tc.routes[r.URL.Path]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{ "message": "Not Found" }`))
+					return
+				}
+
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(body))
+			}))
+			defer ts.Close()
+
+			client, err := github.NewEnterpriseClient(ts.URL, ts.URL, ts.URL, "token")
+			assert.NoError(t, err)
+
+			s := &githubCheckService{
+				client: client,
+				owner:  "octocat",
+				repo:   "Hello-World",
+			}
+
+			checks, err := s.Checks(context.Background(), &github.Pull{
+				Head: github.PullBranch{SHA: "25aa2bd"},
+			})
+
+			if tc.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedChecks, checks)
+			}
+		})
+	}
+}
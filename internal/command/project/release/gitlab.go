@@ -0,0 +1,815 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gardenbed/go-github"
+
+	"github.com/gardenbed/basil-cli/internal/gitlab"
+	"github.com/gardenbed/basil-cli/internal/semver"
+)
+
+// The GitLab services in this file adapt GitLab APIs to the same interfaces used for GitHub APIs.
+// This way, the release flow stays the same regardless of the platform hosting the repository.
+
+const gitlabPageSize = 100
+
+var (
+	keywordRE      = regexp.MustCompile(`"(?:[^"\\]|\\.)*"`)
+	releaseTitleRE = regexp.MustCompile(`^RELEASE (\S+)$`)
+)
+
+func toGitHubResponse(resp *gitlab.Response) *github.Response {
+	if resp == nil {
+		return nil
+	}
+
+	return &github.Response{
+		Response: resp.Response,
+		Pages: github.Pages{
+			Prev: resp.Pages.Prev,
+			Next: resp.Pages.Next,
+			Last: resp.Pages.Total,
+		},
+	}
+}
+
+func toGitHubPull(mr *gitlab.MergeRequest) *github.Pull {
+	pull := &github.Pull{
+		ID:        mr.ID,
+		Number:    mr.IID,
+		State:     mr.State,
+		Title:     mr.Title,
+		Body:      mr.Description,
+		Base:      github.PullBranch{Ref: mr.TargetBranch},
+		Head:      github.PullBranch{Ref: mr.SourceBranch, SHA: mr.SHA},
+		Merged:    mr.IsMerged(),
+		HTMLURL:   mr.WebURL,
+		CreatedAt: mr.CreatedAt,
+		UpdatedAt: mr.UpdatedAt,
+		MergedAt:  mr.MergedAt,
+	}
+
+	if mr.State == "opened" {
+		pull.State = "open"
+	}
+
+	if pull.Merged {
+		pull.MergeCommitSHA = mr.CommitSHA()
+	}
+
+	return pull
+}
+
+// gitlabRepoService implements the repoService interface for GitLab.
+type gitlabRepoService struct {
+	sync.Mutex
+	project *gitlab.ProjectService
+	users   *gitlab.UserService
+	// The protection of a branch is kept when disabled, so it can be restored later.
+	protections map[string]gitlabProtection
+}
+
+func newGitLabRepoService(project *gitlab.ProjectService, users *gitlab.UserService) *gitlabRepoService {
+	return &gitlabRepoService{
+		project:     project,
+		users:       users,
+		protections: map[string]gitlabProtection{},
+	}
+}
+
+// SaveState keeps the original protections of the disabled branches in the release journal.
+func (s *gitlabRepoService) SaveState(j *releaseJournal) {
+	s.Lock()
+	defer s.Unlock()
+
+	if j.GitLab == nil {
+		j.GitLab = new(gitlabState)
+	}
+
+	j.GitLab.Protections = nil
+	for branch, protection := range s.protections {
+		if j.GitLab.Protections == nil {
+			j.GitLab.Protections = map[string]*gitlabProtection{}
+		}
+		protection := protection
+		j.GitLab.Protections[branch] = &protection
+	}
+}
+
+// RestoreState restores the original protections of the disabled branches from the release journal.
+func (s *gitlabRepoService) RestoreState(j *releaseJournal) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if j.GitLab == nil {
+		return nil
+	}
+
+	for branch, protection := range j.GitLab.Protections {
+		if protection != nil {
+			s.protections[branch] = *protection
+		}
+	}
+
+	return nil
+}
+
+func (s *gitlabRepoService) Get(ctx context.Context) (*github.Repository, *github.Response, error) {
+	project, resp, err := s.project.Get(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &github.Repository{
+		ID:            project.ID,
+		Name:          project.Name,
+		FullName:      project.PathWithNamespace,
+		Description:   project.Description,
+		Private:       project.Visibility == "private",
+		Archived:      project.Archived,
+		DefaultBranch: project.DefaultBranch,
+		HTMLURL:       project.WebURL,
+	}, toGitHubResponse(resp), nil
+}
+
+func (s *gitlabRepoService) Permission(ctx context.Context, username string) (github.Permission, *github.Response, error) {
+	user, _, err := s.users.Get(ctx, username)
+	if err != nil {
+		return "", nil, err
+	}
+
+	member, resp, err := s.project.Member(ctx, user.ID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var perm github.Permission
+
+	switch {
+	// Maintainers can manage protected branches which is all direct mode needs.
+	case member.AccessLevel >= gitlab.MaintainerAccess:
+		perm = github.PermissionAdmin
+	case member.AccessLevel >= gitlab.DeveloperAccess:
+		perm = github.PermissionWrite
+	case member.AccessLevel >= gitlab.GuestAccess:
+		perm = github.PermissionRead
+	default:
+		perm = github.PermissionNone
+	}
+
+	return perm, toGitHubResponse(resp), nil
+}
+
+// BranchProtection removes the protection of a branch when disabled and restores it when enabled.
+// GitLab does not have an equivalent for exempting administrators from a branch protection.
+func (s *gitlabRepoService) BranchProtection(ctx context.Context, branch string, enabled bool) (*github.Response, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if !enabled {
+		protected, _, err := s.project.ProtectedBranch(ctx, branch)
+		if err != nil {
+			if gitlab.IsNotFound(err) {
+				s.protections[branch] = gitlabProtection{Protected: false}
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		resp, err := s.project.UnprotectBranch(ctx, branch)
+		if err != nil {
+			return nil, err
+		}
+
+		protection := gitlabProtection{
+			Protected:        true,
+			PushAccessLevel:  gitlab.MaintainerAccess,
+			MergeAccessLevel: gitlab.MaintainerAccess,
+			AllowForcePush:   protected.AllowForcePush,
+		}
+
+		if len(protected.PushAccessLevels) > 0 {
+			protection.PushAccessLevel = protected.PushAccessLevels[0].AccessLevel
+		}
+		if len(protected.MergeAccessLevels) > 0 {
+			protection.MergeAccessLevel = protected.MergeAccessLevels[0].AccessLevel
+		}
+
+		s.protections[branch] = protection
+
+		return toGitHubResponse(resp), nil
+	}
+
+	protection, ok := s.protections[branch]
+	if !ok {
+		return nil, fmt.Errorf("the original protection of %s branch is unknown", branch)
+	}
+
+	if !protection.Protected {
+		// The branch was not protected before disabling
+		delete(s.protections, branch)
+		return nil, nil
+	}
+
+	_, resp, err := s.project.ProtectBranch(ctx, gitlab.ProtectBranchParams{
+		Name:             branch,
+		PushAccessLevel:  protection.PushAccessLevel,
+		MergeAccessLevel: protection.MergeAccessLevel,
+		AllowForcePush:   protection.AllowForcePush,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	delete(s.protections, branch)
+
+	return toGitHubResponse(resp), nil
+}
+
+// gitlabRelease keeps track of a release that the release command has seen or created.
+type gitlabRelease struct {
+	params    github.ReleaseParams
+	published bool
+	htmlURL   string
+	links     []gitlab.ReleaseLinkParams
+}
+
+// gitlabReleaseService implements the releaseService interface for GitLab.
+//
+// GitLab releases are identified by tag names and they do not support drafts.
+// Creating a GitLab release also creates its tag if the tag does not exist yet.
+// So, a draft release is only kept locally and is created on GitLab once it is published.
+// The release command keeps the draft release notes in the release merge request too,
+// so draft releases are also recovered from release merge requests that are not released yet.
+// In direct mode, the draft release is kept in the release journal, so it can be resumed or aborted by another run.
+type gitlabReleaseService struct {
+	sync.Mutex
+	project  *gitlab.ProjectService
+	nextID   int
+	releases map[int]*gitlabRelease
+}
+
+func newGitLabReleaseService(project *gitlab.ProjectService) *gitlabReleaseService {
+	return &gitlabReleaseService{
+		project:  project,
+		nextID:   1,
+		releases: map[int]*gitlabRelease{},
+	}
+}
+
+// track adds or updates a release and returns its id.
+// It should be called while the lock is held.
+func (s *gitlabReleaseService) track(params github.ReleaseParams, published bool, htmlURL string) int {
+	for id, r := range s.releases {
+		if r.params.TagName == params.TagName {
+			// A locally created draft has the most recent notes.
+			if published || !r.published {
+				r.params = params
+				r.published = published
+				r.htmlURL = htmlURL
+			}
+			return id
+		}
+	}
+
+	id := s.nextID
+	s.nextID++
+	s.releases[id] = &gitlabRelease{
+		params:    params,
+		published: published,
+		htmlURL:   htmlURL,
+	}
+
+	return id
+}
+
+// SaveState keeps the draft release in progress in the release journal.
+func (s *gitlabReleaseService) SaveState(j *releaseJournal) {
+	s.Lock()
+	defer s.Unlock()
+
+	if j.GitLab == nil {
+		j.GitLab = new(gitlabState)
+	}
+
+	j.GitLab.Draft = nil
+	if r, ok := s.releases[j.ReleaseID]; ok && !r.published {
+		j.GitLab.Draft = &gitlabDraft{
+			ID:         j.ReleaseID,
+			Name:       r.params.Name,
+			TagName:    r.params.TagName,
+			Target:     r.params.Target,
+			Prerelease: r.params.Prerelease,
+			Body:       r.params.Body,
+			HTMLURL:    r.htmlURL,
+			Links:      r.links,
+		}
+	}
+}
+
+// RestoreState restores the draft release in progress from the release journal.
+func (s *gitlabReleaseService) RestoreState(j *releaseJournal) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if j.GitLab == nil || j.GitLab.Draft == nil {
+		if j.done(stepDraftCreated) && !j.done(stepReleasePublished) {
+			return fmt.Errorf("the draft release %s is not in the release journal and cannot be recovered on GitLab", j.Version)
+		}
+		return nil
+	}
+
+	d := j.GitLab.Draft
+	s.releases[d.ID] = &gitlabRelease{
+		params: github.ReleaseParams{
+			Name:       d.Name,
+			TagName:    d.TagName,
+			Target:     d.Target,
+			Draft:      true,
+			Prerelease: d.Prerelease,
+			Body:       d.Body,
+		},
+		htmlURL: d.HTMLURL,
+		links:   d.Links,
+	}
+
+	if s.nextID <= d.ID {
+		s.nextID = d.ID + 1
+	}
+
+	return nil
+}
+
+func (s *gitlabReleaseService) toGitHubRelease(id int) *github.Release {
+	r := s.releases[id]
+
+	release := &github.Release{
+		ID:         id,
+		Name:       r.params.Name,
+		TagName:    r.params.TagName,
+		Target:     r.params.Target,
+		Draft:      !r.published,
+		Prerelease: r.params.Prerelease,
+		Body:       r.params.Body,
+		HTMLURL:    r.htmlURL,
+	}
+
+	for _, link := range r.links {
+		release.Assets = append(release.Assets, github.ReleaseAsset{
+			Name:        link.Name,
+			DownloadURL: link.URL,
+		})
+	}
+
+	return release
+}
+
+// releaseDrafts recovers the draft releases from the release merge requests.
+func (s *gitlabReleaseService) releaseDrafts(ctx context.Context) ([]github.ReleaseParams, error) {
+	drafts := []github.ReleaseParams{}
+
+	for _, state := range []string{"opened", "merged"} {
+		mrs, _, err := listMergeRequests(ctx, s.project.MergeRequests, gitlab.MergeRequestsFilter{
+			State:  state,
+			Search: "RELEASE",
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, mr := range mrs {
+			m := releaseTitleRE.FindStringSubmatch(mr.Title)
+			if len(m) != 2 {
+				continue
+			}
+
+			// For a module in a sub-directory, the release name is prefixed with the module path
+			i := strings.LastIndex(m[1], "/")
+			prefix, name := m[1][:i+1], m[1][i+1:]
+
+			sv, ok := semver.Parse(name)
+			if !ok {
+				continue
+			}
+
+			drafts = append(drafts, github.ReleaseParams{
+				Name:    prefix + sv.String(),
+				TagName: prefix + sv.TagName(),
+				Target:  mr.TargetBranch,
+				Draft:   true,
+				Body:    mr.Description,
+			})
+		}
+	}
+
+	return drafts, nil
+}
+
+func (s *gitlabReleaseService) List(ctx context.Context, pageSize, pageNo int) ([]github.Release, *github.Response, error) {
+	glReleases, resp, err := s.project.Releases.List(ctx, pageSize, pageNo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var drafts []github.ReleaseParams
+	if pageNo == 1 {
+		if drafts, err = s.releaseDrafts(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	releases := []github.Release{}
+	published := map[string]bool{}
+
+	for _, r := range glReleases {
+		htmlURL := r.Links.Self
+		if htmlURL == "" {
+			htmlURL = s.project.WebURL("-/releases/" + r.TagName)
+		}
+
+		id := s.track(github.ReleaseParams{
+			Name:    r.Name,
+			TagName: r.TagName,
+			Body:    r.Description,
+		}, true, htmlURL)
+
+		published[r.TagName] = true
+		releases = append(releases, *s.toGitHubRelease(id))
+	}
+
+	for _, params := range drafts {
+		if published[params.TagName] {
+			continue
+		}
+
+		id := s.track(params, false, s.project.WebURL("-/releases"))
+		if r := s.releases[id]; !r.published {
+			releases = append(releases, *s.toGitHubRelease(id))
+		}
+	}
+
+	return releases, toGitHubResponse(resp), nil
+}
+
+func (s *gitlabReleaseService) Create(ctx context.Context, params github.ReleaseParams) (*github.Release, *github.Response, error) {
+	if params.Draft {
+		s.Lock()
+		defer s.Unlock()
+
+		id := s.track(params, false, s.project.WebURL("-/releases"))
+		return s.toGitHubRelease(id), nil, nil
+	}
+
+	glRelease, resp, err := s.project.Releases.Create(ctx, gitlab.CreateReleaseParams{
+		Name:        params.Name,
+		TagName:     params.TagName,
+		Ref:         params.Target,
+		Description: params.Body,
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	id := s.track(params, true, glRelease.Links.Self)
+	return s.toGitHubRelease(id), toGitHubResponse(resp), nil
+}
+
+func (s *gitlabReleaseService) Update(ctx context.Context, id int, params github.ReleaseParams) (*github.Release, *github.Response, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	r, ok := s.releases[id]
+	if !ok {
+		return nil, nil, fmt.Errorf("gitlab release not found: %d", id)
+	}
+
+	switch {
+	case r.published:
+		glRelease, resp, err := s.project.Releases.Update(ctx, r.params.TagName, gitlab.UpdateReleaseParams{
+			Name:        params.Name,
+			Description: params.Body,
+		})
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		params.TagName = r.params.TagName
+		r.params = params
+		r.htmlURL = glRelease.Links.Self
+
+		return s.toGitHubRelease(id), toGitHubResponse(resp), nil
+
+	case params.Draft:
+		r.params = params
+		return s.toGitHubRelease(id), nil, nil
+
+	default:
+		// Publishing a draft release
+		createParams := gitlab.CreateReleaseParams{
+			Name:        params.Name,
+			TagName:     params.TagName,
+			Ref:         params.Target,
+			Description: params.Body,
+		}
+
+		if len(r.links) > 0 {
+			createParams.Assets = &gitlab.ReleaseAssetsParams{
+				Links: r.links,
+			}
+		}
+
+		glRelease, resp, err := s.project.Releases.Create(ctx, createParams)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		r.params = params
+		r.published = true
+		r.htmlURL = glRelease.Links.Self
+
+		return s.toGitHubRelease(id), toGitHubResponse(resp), nil
+	}
+}
+
+// Delete deletes a release.
+// A draft release is only kept locally, so it is already gone if it was created by another run.
+func (s *gitlabReleaseService) Delete(ctx context.Context, id int) (*github.Response, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	r, ok := s.releases[id]
+	if !ok {
+		return nil, nil
+	}
+
+	var resp *gitlab.Response
+	if r.published {
+		var err error
+		if resp, err = s.project.Releases.Delete(ctx, r.params.TagName); err != nil {
+			return nil, err
+		}
+	}
+
+	delete(s.releases, id)
+
+	return toGitHubResponse(resp), nil
+}
+
+func (s *gitlabReleaseService) UploadAsset(ctx context.Context, id int, assetFile, assetLabel string) (*github.ReleaseAsset, *github.Response, error) {
+	s.Lock()
+	r, ok := s.releases[id]
+	s.Unlock()
+
+	if !ok {
+		return nil, nil, fmt.Errorf("gitlab release not found: %d", id)
+	}
+
+	upload, resp, err := s.project.UploadFile(ctx, assetFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	name := filepath.Base(assetFile)
+	link := gitlab.ReleaseLinkParams{
+		Name:     name,
+		URL:      s.project.UploadURL(*upload),
+		LinkType: "package",
+	}
+
+	if assetLabel != "" {
+		link.Name = assetLabel
+	}
+
+	s.Lock()
+	published, tag := r.published, r.params.TagName
+	if !published {
+		r.links = append(r.links, link)
+	}
+	s.Unlock()
+
+	if published {
+		if _, resp, err = s.project.Releases.CreateLink(ctx, tag, link); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return &github.ReleaseAsset{
+		Name:        name,
+		Label:       assetLabel,
+		DownloadURL: link.URL,
+	}, toGitHubResponse(resp), nil
+}
+
+// gitlabPullService implements the pullService interface for GitLab merge requests.
+type gitlabPullService struct {
+	mergeRequests *gitlab.MergeRequestService
+}
+
+func (s *gitlabPullService) Get(ctx context.Context, number int) (*github.Pull, *github.Response, error) {
+	mr, resp, err := s.mergeRequests.Get(ctx, number)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return toGitHubPull(mr), toGitHubResponse(resp), nil
+}
+
+func (s *gitlabPullService) Create(ctx context.Context, params github.CreatePullParams) (*github.Pull, *github.Response, error) {
+	mr, resp, err := s.mergeRequests.Create(ctx, gitlab.CreateMergeRequestParams{
+		Title:        params.Title,
+		Description:  params.Body,
+		SourceBranch: params.Head,
+		TargetBranch: params.Base,
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return toGitHubPull(mr), toGitHubResponse(resp), nil
+}
+
+func (s *gitlabPullService) Update(ctx context.Context, number int, params github.UpdatePullParams) (*github.Pull, *github.Response, error) {
+	updateParams := gitlab.UpdateMergeRequestParams{
+		Title:        params.Title,
+		Description:  params.Body,
+		TargetBranch: params.Base,
+	}
+
+	switch params.State {
+	case "open":
+		updateParams.StateEvent = "reopen"
+	case "closed":
+		updateParams.StateEvent = "close"
+	}
+
+	mr, resp, err := s.mergeRequests.Update(ctx, number, updateParams)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return toGitHubPull(mr), toGitHubResponse(resp), nil
+}
+
+// gitlabUserService implements the userService interface for GitLab.
+type gitlabUserService struct {
+	users *gitlab.UserService
+}
+
+func (s *gitlabUserService) User(ctx context.Context) (*github.User, *github.Response, error) {
+	user, resp, err := s.users.User(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &github.User{
+		ID:      user.ID,
+		Login:   user.Username,
+		Name:    user.Name,
+		Email:   user.Email,
+		HTMLURL: user.WebURL,
+	}, toGitHubResponse(resp), nil
+}
+
+// listMergeRequests retrieves the merge requests matching a filter from all pages.
+func listMergeRequests(ctx context.Context, mergeRequests *gitlab.MergeRequestService, filter gitlab.MergeRequestsFilter) ([]gitlab.MergeRequest, *gitlab.Response, error) {
+	all := []gitlab.MergeRequest{}
+
+	for pageNo := 1; ; {
+		mrs, resp, err := mergeRequests.List(ctx, gitlabPageSize, pageNo, filter)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		all = append(all, mrs...)
+
+		if resp == nil || resp.Pages.Next <= pageNo {
+			return all, resp, nil
+		}
+
+		pageNo = resp.Pages.Next
+	}
+}
+
+// gitlabSearchService implements the searchService interface for GitLab merge requests.
+// Only the keywords and the state qualifiers of a search query are supported.
+// Since GitLab matches the keywords as a substring of titles, all pages are retrieved and filtered before paginating the results.
+type gitlabSearchService struct {
+	mergeRequests *gitlab.MergeRequestService
+}
+
+func (s *gitlabSearchService) SearchIssues(ctx context.Context, pageSize, pageNo int, _ github.SearchResultSort, _ github.SearchResultOrder, query github.SearchQuery) (*github.SearchIssuesResult, *github.Response, error) {
+	q := query.String()
+	filter := gitlab.MergeRequestsFilter{}
+
+	var keywords []string
+	for _, m := range keywordRE.FindAllString(q, -1) {
+		if keyword, err := strconv.Unquote(m); err == nil {
+			keywords = append(keywords, keyword)
+		}
+	}
+	filter.Search = strings.Join(keywords, " ")
+
+	for _, term := range strings.Fields(keywordRE.ReplaceAllString(q, "")) {
+		switch github.Qualifier(term) {
+		case github.QualifierIsOpen, github.QualifierStateOpen:
+			filter.State = "opened"
+		case github.QualifierIsClosed, github.QualifierStateClosed:
+			filter.State = "closed"
+		case github.QualifierIsMerged:
+			filter.State = "merged"
+		}
+	}
+
+	mrs, resp, err := listMergeRequests(ctx, s.mergeRequests, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items := []github.Issue{}
+
+	for _, mr := range mrs {
+		// GitLab matches the search keywords as a substring of titles,
+		// so we only keep the merge requests with the exact same title.
+		if filter.Search != "" && !strings.EqualFold(mr.Title, filter.Search) {
+			continue
+		}
+
+		pull := toGitHubPull(&mr)
+		items = append(items, github.Issue{
+			ID:        pull.ID,
+			Number:    pull.Number,
+			State:     pull.State,
+			Title:     pull.Title,
+			Body:      pull.Body,
+			HTMLURL:   pull.HTMLURL,
+			PullURLs:  &github.PullURLs{HTMLURL: pull.HTMLURL},
+			CreatedAt: pull.CreatedAt,
+			UpdatedAt: pull.UpdatedAt,
+		})
+	}
+
+	// Paginate the matching merge requests
+	total, last := len(items), 1
+	if pageSize > 0 && len(items) > 0 {
+		last = (len(items) + pageSize - 1) / pageSize
+		start := min((pageNo-1)*pageSize, len(items))
+		end := min(start+pageSize, len(items))
+		items = items[start:end]
+	}
+
+	ghResp := toGitHubResponse(resp)
+	if ghResp != nil {
+		ghResp.Pages = github.Pages{Last: last}
+		if pageNo > 1 {
+			ghResp.Pages.Prev = pageNo - 1
+		}
+		if pageNo < last {
+			ghResp.Pages.Next = pageNo + 1
+		}
+	}
+
+	return &github.SearchIssuesResult{
+		TotalCount: total,
+		Items:      items,
+	}, ghResp, nil
+}
+
+// gitlabCheckService implements the checkService interface for GitLab merge requests.
+// The only check of a merge request is its head pipeline.
+type gitlabCheckService struct {
+	mergeRequests *gitlab.MergeRequestService
+}
+
+func (s *gitlabCheckService) Checks(ctx context.Context, pull *github.Pull) ([]pullCheck, error) {
+	mr, _, err := s.mergeRequests.Get(ctx, pull.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	checks := []pullCheck{}
+	if mr.HeadPipeline != nil {
+		checks = append(checks, pullCheck{
+			Name:  "pipeline",
+			State: toCheckState(mr.HeadPipeline.Status),
+		})
+	}
+
+	return checks, nil
+}
@@ -0,0 +1,691 @@
+package release
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/gardenbed/go-github"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/basil-cli/internal/gitlab"
+)
+
+type route struct {
+	statusCode int
+	body       string
+}
+
+// fakeGitLab is a fake GitLab server serving a fixed set of routes.
+// The routes are keyed by "METHOD path?query" and requests are recorded in the same format.
+// The pagination headers of a route are keyed in the same format too.
+type fakeGitLab struct {
+	sync.Mutex
+	*httptest.Server
+	routes   map[string]route
+	headers  map[string]http.Header
+	requests []string
+	bodies   map[string]string
+}
+
+func newFakeGitLab(t *testing.T, routes map[string]route) (*fakeGitLab, *gitlab.Client) {
+	f := &fakeGitLab{
+		routes:  routes,
+		headers: map[string]http.Header{},
+		bodies:  map[string]string{},
+	}
+
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Method + " " + r.URL.EscapedPath()
+		if r.URL.RawQuery != "" {
+			key += "?" + r.URL.RawQuery
+		}
+
+		body, _ := io.ReadAll(r.Body)
+
+		f.Lock()
+		f.requests = append(f.requests, key)
+		f.bodies[key] = string(body)
+		header := f.headers[key]
+		f.Unlock()
+
+		for name, values := range header {
+			w.Header()[name] = values
+		}
+
+		rt, ok := f.routes[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{ "message": "404 Not Found" }`))
+			return
+		}
+
+		w.WriteHeader(rt.statusCode)
+		_, _ = w.Write([]byte(rt.body))
+	}))
+
+	t.Cleanup(f.Close)
+
+	client, err := gitlab.NewClient(f.URL, "token")
+	assert.NoError(t, err)
+
+	return f, client
+}
+
+const (
+	projectPath       = "/api/v4/projects/octocat%2FHello-World"
+	openedMRsQuery    = "in=title&page=1&per_page=100&search=RELEASE&state=opened"
+	mergedMRsQuery    = "in=title&page=1&per_page=100&search=RELEASE&state=merged"
+	mockMergeRequests = `[
+		{
+			"id": 1001,
+			"iid": 2,
+			"state": "merged",
+			"title": "RELEASE 0.1.1",
+			"description": "release notes 0.1.1",
+			"source_branch": "release-0.1.1",
+			"target_branch": "main",
+			"sha": "25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378",
+			"merge_commit_sha": "c414d1004154c6c324bd78c69d10ee101e676059",
+			"web_url": "https://gitlab.com/octocat/Hello-World/-/merge_requests/2"
+		},
+		{
+			"id": 1000,
+			"iid": 1,
+			"state": "merged",
+			"title": "RELEASE 0.1.0",
+			"description": "release notes 0.1.0",
+			"source_branch": "release-0.1.0",
+			"target_branch": "main"
+		}
+	]`
+)
+
+func TestGitLabRepoService_Get(t *testing.T) {
+	_, client := newFakeGitLab(t, map[string]route{
+		"GET " + projectPath: {200, `{
+			"id": 1,
+			"name": "Hello-World",
+			"path_with_namespace": "octocat/Hello-World",
+			"description": "This is your first project!",
+			"default_branch": "main",
+			"visibility": "private",
+			"web_url": "https://gitlab.com/octocat/Hello-World"
+		}`},
+	})
+
+	s := newGitLabRepoService(client.Project("octocat/Hello-World"), client.Users)
+	repo, resp, err := s.Get(context.Background())
+
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	assert.Equal(t, &github.Repository{
+		ID:            1,
+		Name:          "Hello-World",
+		FullName:      "octocat/Hello-World",
+		Description:   "This is your first project!",
+		Private:       true,
+		DefaultBranch: "main",
+		HTMLURL:       "https://gitlab.com/octocat/Hello-World",
+	}, repo)
+}
+
+func TestGitLabRepoService_Permission(t *testing.T) {
+	tests := []struct {
+		name          string
+		routes        map[string]route
+		expectedPerm  github.Permission
+		expectedError string
+	}{
+		{
+			name:          "UserNotFound",
+			routes:        map[string]route{"GET /api/v4/users?username=octocat": {200, `[]`}},
+			expectedError: "gitlab user not found: octocat",
+		},
+		{
+			name: "NotMember",
+			routes: map[string]route{
+				"GET /api/v4/users?username=octocat": {200, `[{ "id": 7, "username": "octocat" }]`},
+			},
+			expectedError: "404 Not Found",
+		},
+		{
+			name: "Developer",
+			routes: map[string]route{
+				"GET /api/v4/users?username=octocat":    {200, `[{ "id": 7, "username": "octocat" }]`},
+				"GET " + projectPath + "/members/all/7": {200, `{ "id": 7, "access_level": 30 }`},
+			},
+			expectedPerm: github.PermissionWrite,
+		},
+		{
+			name: "Maintainer",
+			routes: map[string]route{
+				"GET /api/v4/users?username=octocat":    {200, `[{ "id": 7, "username": "octocat" }]`},
+				"GET " + projectPath + "/members/all/7": {200, `{ "id": 7, "access_level": 40 }`},
+			},
+			expectedPerm: github.PermissionAdmin,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, client := newFakeGitLab(t, tc.routes)
+			s := newGitLabRepoService(client.Project("octocat/Hello-World"), client.Users)
+
+			perm, _, err := s.Permission(context.Background(), "octocat")
+
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedPerm, perm)
+			}
+		})
+	}
+}
+
+func TestGitLabRepoService_BranchProtection(t *testing.T) {
+	tests := []struct {
+		name             string
+		routes           map[string]route
+		expectedRequests []string
+		expectedProtect  string
+	}{
+		{
+			name:   "NotProtected",
+			routes: map[string]route{},
+			expectedRequests: []string{
+				"GET " + projectPath + "/protected_branches/main",
+			},
+		},
+		{
+			name: "Protected",
+			routes: map[string]route{
+				"GET " + projectPath + "/protected_branches/main": {200, `{
+					"name": "main",
+					"push_access_levels": [{ "access_level": 0 }],
+					"merge_access_levels": [{ "access_level": 30 }]
+				}`},
+				"DELETE " + projectPath + "/protected_branches/main": {204, ``},
+				"POST " + projectPath + "/protected_branches":        {201, `{ "name": "main" }`},
+			},
+			expectedRequests: []string{
+				"GET " + projectPath + "/protected_branches/main",
+				"DELETE " + projectPath + "/protected_branches/main",
+				"POST " + projectPath + "/protected_branches",
+			},
+			expectedProtect: `{"name":"main","push_access_level":0,"merge_access_level":30,"allow_force_push":false}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f, client := newFakeGitLab(t, tc.routes)
+			s := newGitLabRepoService(client.Project("octocat/Hello-World"), client.Users)
+
+			_, err := s.BranchProtection(context.Background(), "main", false)
+			assert.NoError(t, err)
+
+			_, err = s.BranchProtection(context.Background(), "main", true)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedRequests, f.requests)
+			if tc.expectedProtect != "" {
+				assert.JSONEq(t, tc.expectedProtect, f.bodies["POST "+projectPath+"/protected_branches"])
+			}
+		})
+	}
+}
+
+func TestGitLabRepoService_State(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("UnknownProtection", func(t *testing.T) {
+		_, client := newFakeGitLab(t, map[string]route{})
+		s := newGitLabRepoService(client.Project("octocat/Hello-World"), client.Users)
+
+		_, err := s.BranchProtection(ctx, "main", true)
+		assert.EqualError(t, err, "the original protection of main branch is unknown")
+	})
+
+	t.Run("NotProtected", func(t *testing.T) {
+		f, client := newFakeGitLab(t, map[string]route{})
+		s := newGitLabRepoService(client.Project("octocat/Hello-World"), client.Users)
+
+		_, err := s.BranchProtection(ctx, "main", false)
+		assert.NoError(t, err)
+
+		journal := &releaseJournal{}
+		s.SaveState(journal)
+		assert.Equal(t, &gitlabProtection{Protected: false}, journal.GitLab.Protections["main"])
+
+		// Another run of the release command
+		s = newGitLabRepoService(client.Project("octocat/Hello-World"), client.Users)
+		assert.NoError(t, s.RestoreState(journal))
+
+		_, err = s.BranchProtection(ctx, "main", true)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"GET " + projectPath + "/protected_branches/main",
+		}, f.requests)
+	})
+
+	t.Run("Protected", func(t *testing.T) {
+		f, client := newFakeGitLab(t, map[string]route{
+			"GET " + projectPath + "/protected_branches/main": {200, `{
+				"name": "main",
+				"push_access_levels": [{ "access_level": 0 }],
+				"merge_access_levels": [{ "access_level": 30 }],
+				"allow_force_push": true
+			}`},
+			"DELETE " + projectPath + "/protected_branches/main": {204, ``},
+			"POST " + projectPath + "/protected_branches":        {201, `{ "name": "main" }`},
+		})
+
+		s := newGitLabRepoService(client.Project("octocat/Hello-World"), client.Users)
+
+		_, err := s.BranchProtection(ctx, "main", false)
+		assert.NoError(t, err)
+
+		// The journal is persisted and loaded by another run of the release command
+		journal := newFileJournal(t.TempDir())
+		j := &releaseJournal{Version: "0.1.1"}
+		s.SaveState(j)
+		assert.NoError(t, journal.Save(j))
+		j, err = journal.Load()
+		assert.NoError(t, err)
+
+		s = newGitLabRepoService(client.Project("octocat/Hello-World"), client.Users)
+		assert.NoError(t, s.RestoreState(j))
+
+		_, err = s.BranchProtection(ctx, "main", true)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"name":"main","push_access_level":0,"merge_access_level":30,"allow_force_push":true}`, f.bodies["POST "+projectPath+"/protected_branches"])
+	})
+}
+
+func TestGitLabReleaseService_List(t *testing.T) {
+	f, client := newFakeGitLab(t, map[string]route{
+		"GET " + projectPath + "/releases?page=1&per_page=10": {200, `[
+			{
+				"name": "0.1.0",
+				"tag_name": "v0.1.0",
+				"description": "release notes 0.1.0",
+				"_links": { "self": "https://gitlab.com/octocat/Hello-World/-/releases/v0.1.0" }
+			}
+		]`},
+		"GET " + projectPath + "/merge_requests?" + openedMRsQuery: {200, `[
+			{
+				"id": 1003,
+				"iid": 4,
+				"state": "opened",
+				"title": "RELEASE services/billing/0.2.0",
+				"description": "release notes services/billing/0.2.0",
+				"source_branch": "release-services/billing/0.2.0",
+				"target_branch": "main"
+			}
+		]`},
+		"GET " + projectPath + "/merge_requests?" + mergedMRsQuery: {200, mockMergeRequests},
+		"GET " + projectPath + "/merge_requests?in=title&page=2&per_page=100&search=RELEASE&state=merged": {200, `[
+			{
+				"id": 999,
+				"iid": 0,
+				"state": "merged",
+				"title": "RELEASE 0.0.1",
+				"description": "release notes 0.0.1",
+				"source_branch": "release-0.0.1",
+				"target_branch": "main"
+			}
+		]`},
+	})
+
+	f.headers["GET "+projectPath+"/merge_requests?"+mergedMRsQuery] = http.Header{
+		"X-Next-Page":   []string{"2"},
+		"X-Total-Pages": []string{"2"},
+	}
+
+	s := newGitLabReleaseService(client.Project("octocat/Hello-World"))
+	releases, _, err := s.List(context.Background(), 10, 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, releases, 4)
+
+	assert.Equal(t, "v0.1.0", releases[0].TagName)
+	assert.False(t, releases[0].Draft)
+	assert.Equal(t, "https://gitlab.com/octocat/Hello-World/-/releases/v0.1.0", releases[0].HTMLURL)
+
+	assert.Equal(t, "services/billing/v0.2.0", releases[1].TagName)
+	assert.Equal(t, "services/billing/0.2.0", releases[1].Name)
+	assert.True(t, releases[1].Draft)
+
+	assert.Equal(t, "v0.1.1", releases[2].TagName)
+	assert.Equal(t, "0.1.1", releases[2].Name)
+	assert.Equal(t, "main", releases[2].Target)
+	assert.Equal(t, "release notes 0.1.1", releases[2].Body)
+	assert.True(t, releases[2].Draft)
+
+	// The release merge requests are recovered from all pages
+	assert.Equal(t, "v0.0.1", releases[3].TagName)
+	assert.True(t, releases[3].Draft)
+}
+
+func TestGitLabReleaseService_DraftAndPublish(t *testing.T) {
+	assetFile := filepath.Join(t.TempDir(), "app-linux-amd64")
+	assert.NoError(t, os.WriteFile(assetFile, []byte("binary"), 0644))
+
+	f, client := newFakeGitLab(t, map[string]route{
+		"POST " + projectPath + "/uploads": {201, `{
+			"alt": "app-linux-amd64",
+			"url": "/uploads/66dbcd21ec5d24ed6ea225176098d52b/app-linux-amd64"
+		}`},
+		"POST " + projectPath + "/releases": {201, `{
+			"name": "0.1.1",
+			"tag_name": "v0.1.1",
+			"_links": { "self": "https://gitlab.com/octocat/Hello-World/-/releases/v0.1.1" }
+		}`},
+		"PUT " + projectPath + "/releases/v0.1.1": {200, `{
+			"name": "0.1.1",
+			"tag_name": "v0.1.1",
+			"_links": { "self": "https://gitlab.com/octocat/Hello-World/-/releases/v0.1.1" }
+		}`},
+	})
+
+	ctx := context.Background()
+	s := newGitLabReleaseService(client.Project("octocat/Hello-World"))
+
+	release, _, err := s.Create(ctx, github.ReleaseParams{
+		Name:    "0.1.1",
+		TagName: "v0.1.1",
+		Target:  "main",
+		Draft:   true,
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, release.Draft)
+	assert.Empty(t, f.requests)
+
+	asset, _, err := s.UploadAsset(ctx, release.ID, assetFile, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "app-linux-amd64", asset.Name)
+	assert.Equal(t, f.URL+"/octocat/Hello-World/uploads/66dbcd21ec5d24ed6ea225176098d52b/app-linux-amd64", asset.DownloadURL)
+
+	release, _, err = s.Update(ctx, release.ID, github.ReleaseParams{
+		Name:    "0.1.1",
+		TagName: "v0.1.1",
+		Target:  "main",
+		Draft:   false,
+		Body:    "release notes",
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, release.Draft)
+	assert.Equal(t, "https://gitlab.com/octocat/Hello-World/-/releases/v0.1.1", release.HTMLURL)
+	assert.JSONEq(t, `{
+		"name": "0.1.1",
+		"tag_name": "v0.1.1",
+		"ref": "main",
+		"description": "release notes",
+		"assets": {
+			"links": [
+				{
+					"name": "app-linux-amd64",
+					"url": "`+asset.DownloadURL+`",
+					"link_type": "package"
+				}
+			]
+		}
+	}`, f.bodies["POST "+projectPath+"/releases"])
+
+	_, _, err = s.Update(ctx, release.ID, github.ReleaseParams{
+		Name: "0.1.1",
+		Body: "updated release notes",
+	})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{ "name": "0.1.1", "description": "updated release notes" }`, f.bodies["PUT "+projectPath+"/releases/v0.1.1"])
+
+	_, _, err = s.Update(ctx, 99, github.ReleaseParams{})
+	assert.EqualError(t, err, "gitlab release not found: 99")
+}
+
+func TestGitLabReleaseService_State(t *testing.T) {
+	ctx := context.Background()
+	assetFile := filepath.Join(t.TempDir(), "app-linux-amd64")
+	assert.NoError(t, os.WriteFile(assetFile, []byte("binary"), 0644))
+
+	t.Run("NoDraft", func(t *testing.T) {
+		_, client := newFakeGitLab(t, map[string]route{})
+		s := newGitLabReleaseService(client.Project("octocat/Hello-World"))
+
+		err := s.RestoreState(&releaseJournal{Version: "0.1.1", ReleaseID: 1, Steps: []releaseStep{stepDraftCreated}})
+		assert.EqualError(t, err, "the draft release 0.1.1 is not in the release journal and cannot be recovered on GitLab")
+
+		err = s.RestoreState(&releaseJournal{Version: "0.1.1", ReleaseID: 1, Steps: []releaseStep{stepDraftCreated, stepReleasePublished}})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		f, client := newFakeGitLab(t, map[string]route{
+			"POST " + projectPath + "/uploads": {201, `{
+				"alt": "app-linux-amd64",
+				"url": "/uploads/66dbcd21ec5d24ed6ea225176098d52b/app-linux-amd64"
+			}`},
+			"POST " + projectPath + "/releases": {201, `{
+				"name": "0.1.1",
+				"tag_name": "v0.1.1",
+				"_links": { "self": "https://gitlab.com/octocat/Hello-World/-/releases/v0.1.1" }
+			}`},
+		})
+
+		s := newGitLabReleaseService(client.Project("octocat/Hello-World"))
+
+		release, _, err := s.Create(ctx, github.ReleaseParams{Name: "0.1.1", TagName: "v0.1.1", Target: "main", Draft: true})
+		assert.NoError(t, err)
+
+		asset, _, err := s.UploadAsset(ctx, release.ID, assetFile, "")
+		assert.NoError(t, err)
+
+		// The journal is persisted and loaded by another run of the release command
+		journal := newFileJournal(t.TempDir())
+		j := &releaseJournal{Version: "0.1.1", ReleaseID: release.ID, Steps: []releaseStep{stepDraftCreated}}
+		s.SaveState(j)
+		assert.NoError(t, journal.Save(j))
+		j, err = journal.Load()
+		assert.NoError(t, err)
+
+		s = newGitLabReleaseService(client.Project("octocat/Hello-World"))
+		assert.NoError(t, s.RestoreState(j))
+
+		// A new draft does not reuse the id of the restored draft
+		other, _, err := s.Create(ctx, github.ReleaseParams{Name: "0.2.0", TagName: "v0.2.0", Draft: true})
+		assert.NoError(t, err)
+		assert.NotEqual(t, release.ID, other.ID)
+
+		release, _, err = s.Update(ctx, release.ID, github.ReleaseParams{
+			Name:    "0.1.1",
+			TagName: "v0.1.1",
+			Target:  "main",
+			Body:    "release notes",
+		})
+
+		assert.NoError(t, err)
+		assert.False(t, release.Draft)
+		assert.JSONEq(t, `{
+			"name": "0.1.1",
+			"tag_name": "v0.1.1",
+			"ref": "main",
+			"description": "release notes",
+			"assets": {
+				"links": [
+					{
+						"name": "app-linux-amd64",
+						"url": "`+asset.DownloadURL+`",
+						"link_type": "package"
+					}
+				]
+			}
+		}`, f.bodies["POST "+projectPath+"/releases"])
+
+		// A published release is not kept in the journal
+		s.SaveState(j)
+		assert.Nil(t, j.GitLab.Draft)
+	})
+}
+
+func TestGitLabReleaseService_Delete(t *testing.T) {
+	f, client := newFakeGitLab(t, map[string]route{
+		"POST " + projectPath + "/releases":          {201, `{ "name": "0.1.0", "tag_name": "v0.1.0" }`},
+		"DELETE " + projectPath + "/releases/v0.1.0": {200, `{ "name": "0.1.0", "tag_name": "v0.1.0" }`},
+	})
+
+	ctx := context.Background()
+	s := newGitLabReleaseService(client.Project("octocat/Hello-World"))
+
+	// Unknown releases are already gone
+	_, err := s.Delete(ctx, 99)
+	assert.NoError(t, err)
+
+	// Draft releases are only kept locally
+	draft, _, err := s.Create(ctx, github.ReleaseParams{Name: "0.2.0", TagName: "v0.2.0", Draft: true})
+	assert.NoError(t, err)
+	_, err = s.Delete(ctx, draft.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, f.requests)
+
+	published, _, err := s.Create(ctx, github.ReleaseParams{Name: "0.1.0", TagName: "v0.1.0"})
+	assert.NoError(t, err)
+	_, err = s.Delete(ctx, published.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"POST " + projectPath + "/releases",
+		"DELETE " + projectPath + "/releases/v0.1.0",
+	}, f.requests)
+}
+
+func TestGitLabPullService(t *testing.T) {
+	f, client := newFakeGitLab(t, map[string]route{
+		"GET " + projectPath + "/merge_requests/2": {200, `{
+			"id": 1001,
+			"iid": 2,
+			"state": "merged",
+			"title": "RELEASE 0.1.1",
+			"source_branch": "release-0.1.1",
+			"target_branch": "main",
+			"sha": "25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378",
+			"squash_commit_sha": "c414d1004154c6c324bd78c69d10ee101e676059"
+		}`},
+		"POST " + projectPath + "/merge_requests":  {201, `{ "id": 1002, "iid": 3, "state": "opened", "title": "RELEASE 0.1.2" }`},
+		"PUT " + projectPath + "/merge_requests/3": {200, `{ "id": 1002, "iid": 3, "state": "closed", "title": "RELEASE 0.1.2" }`},
+	})
+
+	ctx := context.Background()
+	s := &gitlabPullService{mergeRequests: client.Project("octocat/Hello-World").MergeRequests}
+
+	pull, _, err := s.Get(ctx, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, pull.Number)
+	assert.True(t, pull.Merged)
+	assert.Equal(t, "c414d1004154c6c324bd78c69d10ee101e676059", pull.MergeCommitSHA)
+	assert.Equal(t, "release-0.1.1", pull.Head.Ref)
+	assert.Equal(t, "main", pull.Base.Ref)
+
+	pull, _, err = s.Create(ctx, github.CreatePullParams{
+		Title: "RELEASE 0.1.2",
+		Body:  "release notes",
+		Head:  "release-0.1.2",
+		Base:  "main",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, pull.Number)
+	assert.Equal(t, "open", pull.State)
+	assert.JSONEq(t, `{
+		"title": "RELEASE 0.1.2",
+		"description": "release notes",
+		"source_branch": "release-0.1.2",
+		"target_branch": "main"
+	}`, f.bodies["POST "+projectPath+"/merge_requests"])
+
+	pull, _, err = s.Update(ctx, 3, github.UpdatePullParams{State: "closed"})
+	assert.NoError(t, err)
+	assert.Equal(t, "closed", pull.State)
+	assert.JSONEq(t, `{ "state_event": "close" }`, f.bodies["PUT "+projectPath+"/merge_requests/3"])
+}
+
+func TestGitLabUserService_User(t *testing.T) {
+	_, client := newFakeGitLab(t, map[string]route{
+		"GET /api/v4/user": {200, `{ "id": 7, "username": "octocat", "name": "The Octocat", "email": "octocat@example.com" }`},
+	})
+
+	s := &gitlabUserService{users: client.Users}
+	user, _, err := s.User(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, &github.User{
+		ID:    7,
+		Login: "octocat",
+		Name:  "The Octocat",
+		Email: "octocat@example.com",
+	}, user)
+}
+
+func TestGitLabSearchService_SearchIssues(t *testing.T) {
+	f, client := newFakeGitLab(t, map[string]route{
+		// GitLab matches the keywords as a substring of titles, so the pre-releases come first
+		"GET " + projectPath + "/merge_requests?in=title&page=1&per_page=100&search=RELEASE+0.1.1&state=merged": {200, `[
+			{ "id": 1005, "iid": 6, "state": "merged", "title": "RELEASE 0.1.1-rc.2" },
+			{ "id": 1004, "iid": 5, "state": "merged", "title": "RELEASE 0.1.1-rc.1" }
+		]`},
+		"GET " + projectPath + "/merge_requests?in=title&page=2&per_page=100&search=RELEASE+0.1.1&state=merged": {200, mockMergeRequests},
+	})
+
+	f.headers["GET "+projectPath+"/merge_requests?in=title&page=1&per_page=100&search=RELEASE+0.1.1&state=merged"] = http.Header{
+		"X-Next-Page":   []string{"2"},
+		"X-Total-Pages": []string{"2"},
+	}
+
+	query := github.SearchQuery{}
+	query.IncludeKeywords("RELEASE 0.1.1")
+	query.IncludeQualifiers(
+		github.QualifierIsPR,
+		github.QualifierIsMerged,
+		github.QualifierInTitle,
+		github.QualifierRepo("octocat", "Hello-World"),
+	)
+
+	s := &gitlabSearchService{mergeRequests: client.Project("octocat/Hello-World").MergeRequests}
+	result, resp, err := s.SearchIssues(context.Background(), 1, 1, github.SortByDefault, github.DefaultOrder, query)
+
+	assert.NoError(t, err)
+	assert.Equal(t, github.Pages{Last: 1}, resp.Pages)
+	assert.Equal(t, 1, result.TotalCount)
+	assert.Len(t, result.Items, 1)
+	assert.Equal(t, 2, result.Items[0].Number)
+	assert.Equal(t, "RELEASE 0.1.1", result.Items[0].Title)
+	assert.Equal(t, "https://gitlab.com/octocat/Hello-World/-/merge_requests/2", result.Items[0].HTMLURL)
+}
+
+func TestGitLabCheckService_Checks(t *testing.T) {
+	_, client := newFakeGitLab(t, map[string]route{
+		"GET " + projectPath + "/merge_requests/2": {200, `{ "id": 1001, "iid": 2, "state": "opened", "head_pipeline": { "id": 100, "status": "failed" } }`},
+		"GET " + projectPath + "/merge_requests/3": {200, `{ "id": 1002, "iid": 3, "state": "opened" }`},
+	})
+
+	s := &gitlabCheckService{mergeRequests: client.Project("octocat/Hello-World").MergeRequests}
+
+	checks, err := s.Checks(context.Background(), &github.Pull{Number: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []pullCheck{{Name: "pipeline", State: checkFailure}}, checks)
+
+	checks, err = s.Checks(context.Background(), &github.Pull{Number: 3})
+	assert.NoError(t, err)
+	assert.Empty(t, checks)
+
+	_, err = s.Checks(context.Background(), &github.Pull{Number: 4})
+	assert.Error(t, err)
+}
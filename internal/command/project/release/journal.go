@@ -0,0 +1,164 @@
+package release
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/gardenbed/basil-cli/internal/gitlab"
+	"github.com/gardenbed/basil-cli/internal/spec"
+)
+
+const (
+	journalDir  = "basil"
+	journalFile = "release.json"
+)
+
+// releaseStep is a step of a release that is recorded in the release journal once completed.
+type releaseStep string
+
+const (
+	stepDraftCreated       releaseStep = "draft_created"
+	stepChangelogGenerated releaseStep = "changelog_generated"
+	stepCommitCreated      releaseStep = "commit_created"
+	stepTagCreated         releaseStep = "tag_created"
+	stepAssetsUploaded     releaseStep = "assets_uploaded"
+	stepProtectionDisabled releaseStep = "protection_disabled"
+	stepCommitPushed       releaseStep = "commit_pushed"
+	stepTagPushed          releaseStep = "tag_pushed"
+	stepReleasePublished   releaseStep = "release_published"
+	stepProtectionEnabled  releaseStep = "protection_enabled"
+	stepImagesPushed       releaseStep = "images_pushed"
+)
+
+// releaseJournal keeps track of the completed steps of a release in progress.
+// It has all the information required for resuming or aborting the release.
+type releaseJournal struct {
+	Mode       spec.ReleaseMode `json:"mode"`
+	Version    string           `json:"version"`
+	TagPrefix  string           `json:"tagPrefix,omitempty"`
+	Branch     string           `json:"branch"`
+	BaseCommit string           `json:"baseCommit"`
+	ReleaseID  int              `json:"releaseId"`
+	Changelog  string           `json:"changelog"`
+	Assets     []string         `json:"assets,omitempty"`
+	Steps      []releaseStep    `json:"steps"`
+	// GitLab is the state of the GitLab services for a release on GitLab.
+	GitLab *gitlabState `json:"gitlab,omitempty"`
+}
+
+// gitlabState is the state of a GitLab release in progress.
+// GitLab does not support draft releases and unprotecting a branch removes its settings,
+// so the draft release and the original branch protections are kept in the release journal.
+type gitlabState struct {
+	Draft       *gitlabDraft                 `json:"draft,omitempty"`
+	Protections map[string]*gitlabProtection `json:"protections,omitempty"`
+}
+
+// gitlabDraft is a draft release that is not created on GitLab yet.
+type gitlabDraft struct {
+	ID         int                        `json:"id"`
+	Name       string                     `json:"name"`
+	TagName    string                     `json:"tagName"`
+	Target     string                     `json:"target"`
+	Prerelease bool                       `json:"prerelease"`
+	Body       string                     `json:"body"`
+	HTMLURL    string                     `json:"htmlUrl"`
+	Links      []gitlab.ReleaseLinkParams `json:"links,omitempty"`
+}
+
+// gitlabProtection is the original protection of a branch.
+type gitlabProtection struct {
+	Protected        bool               `json:"protected"`
+	PushAccessLevel  gitlab.AccessLevel `json:"pushAccessLevel"`
+	MergeAccessLevel gitlab.AccessLevel `json:"mergeAccessLevel"`
+	AllowForcePush   bool               `json:"allowForcePush"`
+}
+
+func (j *releaseJournal) done(step releaseStep) bool {
+	for _, s := range j.Steps {
+		if s == step {
+			return true
+		}
+	}
+	return false
+}
+
+func (j *releaseJournal) complete(step releaseStep) {
+	if !j.done(step) {
+		j.Steps = append(j.Steps, step)
+	}
+}
+
+func (j *releaseJournal) undo(step releaseStep) {
+	for i, s := range j.Steps {
+		if s == step {
+			j.Steps = append(j.Steps[:i], j.Steps[i+1:]...)
+			return
+		}
+	}
+}
+
+func (j *releaseJournal) uploaded(asset string) bool {
+	for _, a := range j.Assets {
+		if a == asset {
+			return true
+		}
+	}
+	return false
+}
+
+// fileJournal persists the release journal as a JSON file.
+type fileJournal struct {
+	path string
+}
+
+// newFileJournal creates a release journal under the given git directory.
+func newFileJournal(gitDir string) *fileJournal {
+	return &fileJournal{
+		path: filepath.Join(gitDir, journalDir, journalFile),
+	}
+}
+
+// Load reads the release journal.
+// If there is no release in progress, it returns nil.
+func (f *fileJournal) Load() (*releaseJournal, error) {
+	b, err := os.ReadFile(f.path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	j := new(releaseJournal)
+	if err := json.Unmarshal(b, j); err != nil {
+		return nil, err
+	}
+
+	return j, nil
+}
+
+// Save writes the release journal.
+func (f *fileJournal) Save(j *releaseJournal) error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(f.path, b, 0644)
+}
+
+// Delete removes the release journal.
+func (f *fileJournal) Delete() error {
+	if err := os.Remove(f.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
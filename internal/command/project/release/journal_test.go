@@ -0,0 +1,85 @@
+package release
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/basil-cli/internal/spec"
+)
+
+func TestReleaseJournal(t *testing.T) {
+	j := new(releaseJournal)
+
+	assert.False(t, j.done(stepDraftCreated))
+
+	j.complete(stepDraftCreated)
+	j.complete(stepChangelogGenerated)
+	j.complete(stepDraftCreated)
+	assert.True(t, j.done(stepDraftCreated))
+	assert.Equal(t, []releaseStep{stepDraftCreated, stepChangelogGenerated}, j.Steps)
+
+	j.undo(stepDraftCreated)
+	assert.False(t, j.done(stepDraftCreated))
+	assert.Equal(t, []releaseStep{stepChangelogGenerated}, j.Steps)
+
+	j.Assets = []string{"bin/app"}
+	assert.True(t, j.uploaded("bin/app"))
+	assert.False(t, j.uploaded("bin/other"))
+}
+
+func TestFileJournal(t *testing.T) {
+	gitDir := t.TempDir()
+	f := newFileJournal(gitDir)
+
+	assert.Equal(t, filepath.Join(gitDir, "basil", "release.json"), f.path)
+
+	t.Run("NoJournal", func(t *testing.T) {
+		j, err := f.Load()
+		assert.NoError(t, err)
+		assert.Nil(t, j)
+	})
+
+	t.Run("SaveAndLoad", func(t *testing.T) {
+		expected := &releaseJournal{
+			Mode:       spec.ReleaseModeDirect,
+			Version:    "0.1.0",
+			Branch:     "main",
+			BaseCommit: "25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378",
+			ReleaseID:  1,
+			Changelog:  "changelog content",
+			Assets:     []string{"bin/app"},
+			Steps:      []releaseStep{stepDraftCreated, stepChangelogGenerated},
+		}
+
+		assert.NoError(t, f.Save(expected))
+
+		j, err := f.Load()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, j)
+	})
+
+	t.Run("InvalidJournal", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(f.path, []byte("{"), 0644))
+
+		j, err := f.Load()
+		assert.Error(t, err)
+		assert.Nil(t, j)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		assert.NoError(t, f.Delete())
+		assert.NoError(t, f.Delete())
+
+		j, err := f.Load()
+		assert.NoError(t, err)
+		assert.Nil(t, j)
+	})
+
+	t.Run("SaveFails", func(t *testing.T) {
+		err := newFileJournal("/dev/null").Save(new(releaseJournal))
+		assert.Error(t, err)
+	})
+}
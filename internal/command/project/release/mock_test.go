@@ -7,6 +7,8 @@ import (
 	"github.com/gardenbed/go-github"
 
 	buildcmd "github.com/gardenbed/basil-cli/internal/command/project/build"
+	"github.com/gardenbed/basil-cli/internal/git"
+	"github.com/gardenbed/basil-cli/internal/notify"
 	"github.com/gardenbed/basil-cli/internal/semver"
 )
 
@@ -18,9 +20,17 @@ type (
 		OutError  error
 	}
 
+	GitTagsMock struct {
+		OutTags  git.Tags
+		OutError error
+	}
+
 	MockGitService struct {
 		RemoteIndex int
 		RemoteMocks []RemoteMock
+
+		TagsIndex int
+		TagsMocks []GitTagsMock
 	}
 )
 
@@ -31,6 +41,12 @@ func (m *MockGitService) Remote(name string) (string, string, error) {
 	return m.RemoteMocks[i].OutDomain, m.RemoteMocks[i].OutPath, m.RemoteMocks[i].OutError
 }
 
+func (m *MockGitService) Tags() (git.Tags, error) {
+	i := m.TagsIndex
+	m.TagsIndex++
+	return m.TagsMocks[i].OutTags, m.TagsMocks[i].OutError
+}
+
 type (
 	GetMock struct {
 		InContext     context.Context
@@ -128,6 +144,13 @@ type (
 		OutError        error
 	}
 
+	ReleaseDeleteMock struct {
+		InContext   context.Context
+		InReleaseID int
+		OutResponse *github.Response
+		OutError    error
+	}
+
 	MockReleaseService struct {
 		ListIndex int
 		ListMocks []ReleaseListMock
@@ -140,6 +163,9 @@ type (
 
 		UploadAssetIndex int
 		UploadAssetMocks []ReleaseUploadAssetMock
+
+		DeleteIndex int
+		DeleteMocks []ReleaseDeleteMock
 	}
 )
 
@@ -179,6 +205,14 @@ func (m *MockReleaseService) UploadAsset(ctx context.Context, releaseID int, ass
 	return m.UploadAssetMocks[i].OutReleaseAsset, m.UploadAssetMocks[i].OutResponse, m.UploadAssetMocks[i].OutError
 }
 
+func (m *MockReleaseService) Delete(ctx context.Context, releaseID int) (*github.Response, error) {
+	i := m.DeleteIndex
+	m.DeleteIndex++
+	m.DeleteMocks[i].InContext = ctx
+	m.DeleteMocks[i].InReleaseID = releaseID
+	return m.DeleteMocks[i].OutResponse, m.DeleteMocks[i].OutError
+}
+
 type (
 	PullGetMock struct {
 		InContext   context.Context
@@ -294,6 +328,28 @@ func (m *MockSearchService) SearchIssues(ctx context.Context, pageSize, pageNo i
 	return m.SearchIssuesMocks[i].OutResult, m.SearchIssuesMocks[i].OutResponse, m.SearchIssuesMocks[i].OutError
 }
 
+type (
+	ChecksMock struct {
+		InContext context.Context
+		InPull    *github.Pull
+		OutChecks []pullCheck
+		OutError  error
+	}
+
+	MockCheckService struct {
+		ChecksIndex int
+		ChecksMocks []ChecksMock
+	}
+)
+
+func (m *MockCheckService) Checks(ctx context.Context, pull *github.Pull) ([]pullCheck, error) {
+	i := m.ChecksIndex
+	m.ChecksIndex++
+	m.ChecksMocks[i].InContext = ctx
+	m.ChecksMocks[i].InPull = pull
+	return m.ChecksMocks[i].OutChecks, m.ChecksMocks[i].OutError
+}
+
 type (
 	GenerateMock struct {
 		InContext  context.Context
@@ -316,6 +372,86 @@ func (m *MockChangelogService) Generate(ctx context.Context, spec changelogspec.
 	return m.GenerateMocks[i].OutContent, m.GenerateMocks[i].OutError
 }
 
+type (
+	EndpointsMock struct {
+		OutEndpoints []string
+	}
+
+	NotifyMock struct {
+		InContext context.Context
+		InRelease notify.Release
+		OutError  error
+	}
+
+	MockNotifyService struct {
+		EndpointsIndex int
+		EndpointsMocks []EndpointsMock
+
+		NotifyIndex int
+		NotifyMocks []NotifyMock
+	}
+)
+
+func (m *MockNotifyService) Endpoints() []string {
+	i := m.EndpointsIndex
+	m.EndpointsIndex++
+	return m.EndpointsMocks[i].OutEndpoints
+}
+
+func (m *MockNotifyService) Notify(ctx context.Context, release notify.Release) error {
+	i := m.NotifyIndex
+	m.NotifyIndex++
+	m.NotifyMocks[i].InContext = ctx
+	m.NotifyMocks[i].InRelease = release
+	return m.NotifyMocks[i].OutError
+}
+
+type (
+	JournalLoadMock struct {
+		OutJournal *releaseJournal
+		OutError   error
+	}
+
+	JournalSaveMock struct {
+		InJournal releaseJournal
+		OutError  error
+	}
+
+	JournalDeleteMock struct {
+		OutError error
+	}
+
+	MockJournalService struct {
+		LoadIndex int
+		LoadMocks []JournalLoadMock
+
+		SaveIndex int
+		SaveMocks []JournalSaveMock
+
+		DeleteIndex int
+		DeleteMocks []JournalDeleteMock
+	}
+)
+
+func (m *MockJournalService) Load() (*releaseJournal, error) {
+	i := m.LoadIndex
+	m.LoadIndex++
+	return m.LoadMocks[i].OutJournal, m.LoadMocks[i].OutError
+}
+
+func (m *MockJournalService) Save(journal *releaseJournal) error {
+	i := m.SaveIndex
+	m.SaveIndex++
+	m.SaveMocks[i].InJournal = *journal
+	return m.SaveMocks[i].OutError
+}
+
+func (m *MockJournalService) Delete() error {
+	i := m.DeleteIndex
+	m.DeleteIndex++
+	return m.DeleteMocks[i].OutError
+}
+
 type (
 	SemverRunMock struct {
 		InArgs  []string
@@ -326,12 +462,27 @@ type (
 		OutSemVer semver.SemVer
 	}
 
+	BumpMock struct {
+		OutBump    semver.Bump
+		OutCommits git.Commits
+	}
+
+	TagPrefixMock struct {
+		OutTagPrefix string
+	}
+
 	MockSemverCommand struct {
 		RunIndex int
 		RunMocks []SemverRunMock
 
 		SemVerIndex int
 		SemVerMocks []SemVerMock
+
+		BumpIndex int
+		BumpMocks []BumpMock
+
+		TagPrefixIndex int
+		TagPrefixMocks []TagPrefixMock
 	}
 )
 
@@ -348,22 +499,43 @@ func (m *MockSemverCommand) SemVer() semver.SemVer {
 	return m.SemVerMocks[i].OutSemVer
 }
 
+func (m *MockSemverCommand) Bump() (semver.Bump, git.Commits) {
+	i := m.BumpIndex
+	m.BumpIndex++
+	return m.BumpMocks[i].OutBump, m.BumpMocks[i].OutCommits
+}
+
+func (m *MockSemverCommand) TagPrefix() string {
+	i := m.TagPrefixIndex
+	m.TagPrefixIndex++
+	return m.TagPrefixMocks[i].OutTagPrefix
+}
+
 type (
 	BuildRunMock struct {
 		InArgs  []string
 		OutCode int
 	}
 
-	ArtifactsMock struct {
-		OutArtifacts []buildcmd.Artifact
+	ManifestMock struct {
+		OutManifest buildcmd.Manifest
+		OutError    error
+	}
+
+	PushImagesMock struct {
+		InContext context.Context
+		OutError  error
 	}
 
 	MockBuildCommand struct {
 		RunIndex int
 		RunMocks []BuildRunMock
 
-		ArtifactsIndex int
-		ArtifactsMocks []ArtifactsMock
+		ManifestIndex int
+		ManifestMocks []ManifestMock
+
+		PushImagesIndex int
+		PushImagesMocks []PushImagesMock
 	}
 )
 
@@ -374,8 +546,34 @@ func (m *MockBuildCommand) Run(args []string) int {
 	return m.RunMocks[i].OutCode
 }
 
-func (m *MockBuildCommand) Artifacts() []buildcmd.Artifact {
-	i := m.ArtifactsIndex
-	m.ArtifactsIndex++
-	return m.ArtifactsMocks[i].OutArtifacts
+func (m *MockBuildCommand) Manifest() (buildcmd.Manifest, error) {
+	i := m.ManifestIndex
+	m.ManifestIndex++
+	return m.ManifestMocks[i].OutManifest, m.ManifestMocks[i].OutError
+}
+
+func (m *MockBuildCommand) PushImages(ctx context.Context) error {
+	i := m.PushImagesIndex
+	m.PushImagesIndex++
+	m.PushImagesMocks[i].InContext = ctx
+	return m.PushImagesMocks[i].OutError
+}
+
+type (
+	GateRunMock struct {
+		InArgs  []string
+		OutCode int
+	}
+
+	MockGateCommand struct {
+		RunIndex int
+		RunMocks []GateRunMock
+	}
+)
+
+func (m *MockGateCommand) Run(args []string) int {
+	i := m.RunIndex
+	m.RunIndex++
+	m.RunMocks[i].InArgs = args
+	return m.RunMocks[i].OutCode
 }
@@ -0,0 +1,189 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gardenbed/go-github"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	defaultPageSize    = 100
+	defaultConcurrency = 4
+
+	// GitHub search API only provides up to 1000 results for each search.
+	searchMaxPages = 1000 / defaultPageSize
+)
+
+// listFunc retrieves a page of items from a GitHub list API.
+type listFunc[T any] func(ctx context.Context, pageSize, pageNo int) ([]T, *github.Response, error)
+
+// paginator traverses the pages of a GitHub list API.
+// The first page is retrieved for finding the number of pages and the rest of pages are retrieved concurrently
+// with a bounded number of requests in flight.
+// When there is no request remaining in the current rate limit window, requests are paused until the rate limit resets.
+type paginator[T any] struct {
+	list        listFunc[T]
+	pageSize    int
+	concurrency int
+	maxPages    int
+	now         func() time.Time
+	sleep       func(context.Context, time.Duration) error
+
+	sync.Mutex
+	rate github.Rate
+}
+
+func newPaginator[T any](list listFunc[T]) *paginator[T] {
+	return &paginator[T]{
+		list:        list,
+		pageSize:    defaultPageSize,
+		concurrency: defaultConcurrency,
+		now:         time.Now,
+		sleep:       sleep,
+	}
+}
+
+// sleep pauses for a duration or until the context is cancelled.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// waitForRateLimit pauses until the rate limit resets if there is no request remaining.
+func (p *paginator[T]) waitForRateLimit(ctx context.Context) error {
+	p.Lock()
+	rate := p.rate
+	p.Unlock()
+
+	// The rate limit is unknown (e.g. GitLab) or there are requests remaining
+	if rate.Limit == 0 || rate.Remaining > 0 {
+		return nil
+	}
+
+	reset := rate.Reset.Time()
+	d := reset.Sub(p.now())
+	if d <= 0 {
+		return nil
+	}
+
+	// There is no point in waiting if the rate limit resets after the deadline
+	if deadline, ok := ctx.Deadline(); ok && reset.After(deadline) {
+		return fmt.Errorf("github rate limit exceeded until %s", reset.Format(time.RFC3339))
+	}
+
+	return p.sleep(ctx, d)
+}
+
+func (p *paginator[T]) fetch(ctx context.Context, pageNo int) ([]T, *github.Response, error) {
+	if err := p.waitForRateLimit(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	items, resp, err := p.list(ctx, p.pageSize, pageNo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp != nil && resp.Rate.Limit > 0 {
+		p.Lock()
+		p.rate = resp.Rate
+		p.Unlock()
+	}
+
+	return items, resp, nil
+}
+
+// find returns the first item matching a predicate in the order of pages.
+// Once a matching item is found, the requests for the subsequent pages are cancelled.
+// If no item matches the predicate, it returns nil.
+func (p *paginator[T]) find(ctx context.Context, match func(T) bool) (*T, error) {
+	items, resp, err := p.fetch(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range items {
+		if match(items[i]) {
+			return &items[i], nil
+		}
+	}
+
+	var last int
+	if resp != nil {
+		last = resp.Pages.Last
+	}
+
+	if p.maxPages > 0 && last > p.maxPages {
+		last = p.maxPages
+	}
+
+	var mu sync.Mutex
+	var found *T
+	foundPage := last + 1
+	cancels := map[int]context.CancelFunc{}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(p.concurrency)
+
+	for page := 2; page <= last; page++ {
+		mu.Lock()
+		if page > foundPage {
+			mu.Unlock()
+			break
+		}
+		pageCtx, cancel := context.WithCancel(groupCtx)
+		cancels[page] = cancel
+		mu.Unlock()
+
+		group.Go(func() error {
+			defer cancel()
+
+			items, _, err := p.fetch(pageCtx, page)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			delete(cancels, page)
+
+			// A matching item is already found in a previous page
+			if page > foundPage {
+				return nil
+			}
+
+			if err != nil {
+				return err
+			}
+
+			for i := range items {
+				if match(items[i]) {
+					found, foundPage = &items[i], page
+					for pg, cancel := range cancels {
+						if pg > page {
+							cancel()
+						}
+					}
+					break
+				}
+			}
+
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	return found, nil
+}
@@ -0,0 +1,297 @@
+package release
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gardenbed/go-github"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeList is a fake GitHub list API serving a fixed number of pages.
+type fakeList struct {
+	sync.Mutex
+	pages       [][]int
+	errPage     int
+	rate        github.Rate
+	delay       time.Duration
+	inFlight    int
+	maxInFlight int
+	requests    []int
+}
+
+func (f *fakeList) list(ctx context.Context, pageSize, pageNo int) ([]int, *github.Response, error) {
+	f.Lock()
+	f.requests = append(f.requests, pageNo)
+	f.inFlight++
+	if f.inFlight > f.maxInFlight {
+		f.maxInFlight = f.inFlight
+	}
+	f.Unlock()
+
+	defer func() {
+		f.Lock()
+		f.inFlight--
+		f.Unlock()
+	}()
+
+	if f.delay > 0 {
+		if err := sleep(ctx, f.delay); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if pageNo == f.errPage {
+		return nil, nil, errors.New("github error")
+	}
+
+	resp := &github.Response{
+		Pages: github.Pages{Last: len(f.pages)},
+		Rate:  f.rate,
+	}
+
+	return f.pages[pageNo-1], resp, nil
+}
+
+func (f *fakeList) requestedPages() []int {
+	f.Lock()
+	defer f.Unlock()
+
+	pages := append([]int{}, f.requests...)
+	sort.Ints(pages)
+	return pages
+}
+
+func TestPaginator_find(t *testing.T) {
+	tests := []struct {
+		name             string
+		list             *fakeList
+		maxPages         int
+		value            int
+		expectedError    string
+		expectedItem     *int
+		expectedRequests []int
+	}{
+		{
+			name: "FirstPageFails",
+			list: &fakeList{
+				pages:   [][]int{{1, 2}},
+				errPage: 1,
+			},
+			value:            1,
+			expectedError:    "github error",
+			expectedRequests: []int{1},
+		},
+		{
+			name: "FoundInFirstPage",
+			list: &fakeList{
+				pages: [][]int{{1, 2}, {3, 4}, {5, 6}},
+			},
+			value:            2,
+			expectedItem:     intPtr(2),
+			expectedRequests: []int{1},
+		},
+		{
+			name: "PageFails",
+			list: &fakeList{
+				pages:   [][]int{{1, 2}, {3, 4}, {5, 6}},
+				errPage: 2,
+			},
+			value:            6,
+			expectedError:    "github error",
+			expectedRequests: []int{1, 2, 3},
+		},
+		{
+			name: "NotFound",
+			list: &fakeList{
+				pages: [][]int{{1, 2}, {3, 4}, {5, 6}, {7, 8}, {9, 10}, {11, 12}},
+			},
+			value:            13,
+			expectedRequests: []int{1, 2, 3, 4, 5, 6},
+		},
+		{
+			name: "FoundInLastPage",
+			list: &fakeList{
+				pages: [][]int{{1, 2}, {3, 4}, {5, 6}, {7, 8}, {9, 10}, {11, 12}},
+			},
+			value:            12,
+			expectedItem:     intPtr(12),
+			expectedRequests: []int{1, 2, 3, 4, 5, 6},
+		},
+		{
+			name: "MaxPages",
+			list: &fakeList{
+				pages: [][]int{{1, 2}, {3, 4}, {5, 6}, {7, 8}, {9, 10}, {11, 12}},
+			},
+			maxPages:         3,
+			value:            12,
+			expectedRequests: []int{1, 2, 3},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := newPaginator(tc.list.list)
+			p.maxPages = tc.maxPages
+
+			item, err := p.find(context.Background(), func(i int) bool {
+				return i == tc.value
+			})
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedItem, item)
+			}
+
+			assert.Equal(t, tc.expectedRequests, tc.list.requestedPages())
+		})
+	}
+}
+
+func TestPaginator_find_FirstMatchInPageOrder(t *testing.T) {
+	list := &fakeList{
+		pages: [][]int{{1, 2}, {3, 4}, {5, 6}, {7, 8}, {9, 10}, {11, 12}},
+	}
+
+	p := newPaginator(list.list)
+
+	// All items greater than 4 match, but the first one in the order of pages is expected
+	item, err := p.find(context.Background(), func(i int) bool {
+		return i > 4
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, intPtr(5), item)
+}
+
+func TestPaginator_find_BoundedConcurrency(t *testing.T) {
+	pages := make([][]int, 20)
+	for i := range pages {
+		pages[i] = []int{i}
+	}
+
+	list := &fakeList{
+		pages: pages,
+		delay: 10 * time.Millisecond,
+	}
+
+	p := newPaginator(list.list)
+	p.concurrency = 3
+
+	item, err := p.find(context.Background(), func(i int) bool {
+		return i < 0
+	})
+
+	assert.NoError(t, err)
+	assert.Nil(t, item)
+	assert.Len(t, list.requestedPages(), 20)
+	assert.LessOrEqual(t, list.maxInFlight, 3)
+}
+
+func TestPaginator_find_Cancellation(t *testing.T) {
+	list := &fakeList{
+		pages: [][]int{{1, 2}, {3, 4}, {5, 6}},
+		delay: time.Minute,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	p := newPaginator(list.list)
+	item, err := p.find(ctx, func(i int) bool {
+		return i == 6
+	})
+
+	assert.Nil(t, item)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestPaginator_find_RateLimit(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	t.Run("WaitsForReset", func(t *testing.T) {
+		list := &fakeList{
+			pages: [][]int{{1, 2}, {3, 4}},
+			rate: github.Rate{
+				Limit:     5000,
+				Remaining: 0,
+				Reset:     github.Epoch(now.Add(time.Minute).Unix()),
+			},
+		}
+
+		var slept []time.Duration
+
+		p := newPaginator(list.list)
+		p.now = func() time.Time { return now }
+		p.sleep = func(_ context.Context, d time.Duration) error {
+			slept = append(slept, d)
+			return nil
+		}
+
+		item, err := p.find(context.Background(), func(i int) bool {
+			return i == 4
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, intPtr(4), item)
+		assert.Equal(t, []time.Duration{time.Minute}, slept)
+	})
+
+	t.Run("ResetsAfterDeadline", func(t *testing.T) {
+		list := &fakeList{
+			pages: [][]int{{1, 2}, {3, 4}},
+			rate: github.Rate{
+				Limit:     5000,
+				Remaining: 0,
+				Reset:     github.Epoch(time.Now().Add(time.Hour).Unix()),
+			},
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		p := newPaginator(list.list)
+		item, err := p.find(ctx, func(i int) bool {
+			return i == 4
+		})
+
+		assert.Nil(t, item)
+		assert.ErrorContains(t, err, "github rate limit exceeded until")
+		assert.Equal(t, []int{1}, list.requestedPages())
+	})
+
+	t.Run("RequestsRemaining", func(t *testing.T) {
+		list := &fakeList{
+			pages: [][]int{{1, 2}, {3, 4}},
+			rate: github.Rate{
+				Limit:     5000,
+				Remaining: 4999,
+				Reset:     github.Epoch(now.Add(time.Minute).Unix()),
+			},
+		}
+
+		p := newPaginator(list.list)
+		p.now = func() time.Time { return now }
+		p.sleep = func(context.Context, time.Duration) error {
+			t.Fatal("unexpected wait for the rate limit")
+			return nil
+		}
+
+		item, err := p.find(context.Background(), func(i int) bool {
+			return i == 4
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, intPtr(4), item)
+	})
+}
+
+func intPtr(i int) *int {
+	return &i
+}
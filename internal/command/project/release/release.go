@@ -6,8 +6,11 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"path"
+	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"text/template"
 	"time"
 
@@ -21,9 +24,12 @@ import (
 
 	"github.com/gardenbed/basil-cli/internal/command"
 	buildcmd "github.com/gardenbed/basil-cli/internal/command/project/build"
+	gatecmd "github.com/gardenbed/basil-cli/internal/command/project/gate"
 	semvercmd "github.com/gardenbed/basil-cli/internal/command/project/semver"
 	"github.com/gardenbed/basil-cli/internal/config"
 	"github.com/gardenbed/basil-cli/internal/git"
+	"github.com/gardenbed/basil-cli/internal/gitlab"
+	"github.com/gardenbed/basil-cli/internal/notify"
 	"github.com/gardenbed/basil-cli/internal/semver"
 	"github.com/gardenbed/basil-cli/internal/spec"
 	"github.com/gardenbed/basil-cli/internal/ui"
@@ -34,29 +40,98 @@ const (
 	synopsis = `Create a release`
 	help     = `
   Use this command for creating a new release.
-  Currently, only GitHub repositories are supported.
+  Currently, GitHub and GitLab repositories are supported.
+  For self-hosted GitLab instances, the GitLab domain should be configured.
 
   It assumes the remote repository name is origin.
   The initial semantic version is always 0.1.0.
+  The built artifacts and their checksums (checksums.txt) are uploaded to the release.
+  If archiving is enabled for builds, the archives are uploaded instead of the binaries.
+  If signing is enabled, detached ASCII-armored signatures (.asc) are created for them using gpg and uploaded too.
+  If pushing images is enabled for builds, the images are pushed to the registry only once the release is published.
+
+  For a module in a sub-directory of a repository (monorepo), run this command from the module directory.
+  The release tag is prefixed with the module path (e.g. services/billing/v1.2.3).
+  The version is resolved from the module tags and the commits changing the module directory.
+  The changelog in the module directory only includes the module tags and the artifacts are built from the module directory.
 
   DIRECT Release:
   A new release commit will be created, tagged, and directly pushed to the default branch.
-  A new GitHub release will also be created and published.
+  A new GitHub/GitLab release will also be created and published.
 
   INDIRECT Release:
   A new release commit will be created and a pull request will be opened for it to be reviewed and merged.
   A new draft GitHub release will also be created.
+  For GitLab, the release is created once the merge request is merged since GitLab does not support draft releases.
   After the pull request is merged, you need to tag the release commit and publish the draft release.
-  The last step can be done manually or through a GitGub action.
+  The last step can be done by re-running this command or through a CI pipeline using basil project release finish.
 
   Usage:  basil project release [flags]
 
   Flags:
-    -patch      create a patch release (default: true)
-    -minor      create a minor release (default: false)
-    -major      create a major release (default: false)
-    -comment    add a description for the release
-    -mode       the release mode, either direct or indirect (default: {{.Project.Release.Mode}})
+    -patch               create a patch release (default: true)
+    -minor               create a minor release (default: false)
+    -major               create a major release (default: false)
+    -auto                determine the release from the Conventional Commits since the last release (default: false)
+    -pre                 create a pre-release on the given channel (e.g. alpha, beta, rc)
+    -comment             add a description for the release
+    -mode                the release mode, either direct or indirect (default: {{.Project.Release.Mode}})
+    -sign                sign the artifacts and checksums with gpg (default: {{.Project.Release.Sign}})
+    -signing-key         the gpg key for signing the artifacts (default: the gpg default key)
+    -maintenance-branch  a glob pattern for maintenance branches (e.g. release/*) {{if .Project.Release.MaintenanceBranch}}(default: {{.Project.Release.MaintenanceBranch}}){{end}}
+    -dry-run             print the release plan without pushing or changing anything remotely
+    -resume              continue a failed direct release from the last completed step
+    -abort               undo a failed direct release (local commit and tag, draft release, and branch protection)
+    -skip-gates          do not run the quality gates (for emergencies only)
+
+  Quality Gates:
+  If any quality gate is enabled in the spec file (project.gates), the quality gates run before releasing.
+  A failing quality gate stops the release and -skip-gates can be used for skipping them in emergencies.
+  See "basil project gate -help" for the list of quality gates.
+
+  Resume/Abort:
+  Every completed step of a direct release is recorded in .git/basil/release.json.
+  If a direct release fails, it can be resumed with -resume or undone with -abort.
+  For GitLab, the draft release and the original branch protection are also recorded, since GitLab does not keep them.
+  Aborting resets the default branch to the commit before the release (uncommitted changes are discarded).
+  The release commit and tag cannot be undone once they are pushed.
+  Indirect releases can be safely re-run.
+
+  Automatic Release:
+  With -auto, the release is determined from the Conventional Commits since the most recent tag.
+  A breaking change (type! or BREAKING CHANGE:) results in a major release.
+  By default, feat results in a minor release and fix and perf result in a patch release.
+  This mapping can be configured under project.release.bumps in the spec file.
+
+  Pre-Release:
+  A pre-release on a channel is versioned as <version>-<channel>.<number> (e.g. v1.3.0-rc.1).
+  Releasing again on the same channel increments the number (e.g. v1.3.0-rc.2).
+  Releasing without a channel promotes the latest pre-release to a final release (e.g. v1.3.0).
+  The GitHub release is marked as a pre-release.
+
+  Maintenance Release:
+  Besides the default branch, patch releases can be created from maintenance branches matching a glob pattern (e.g. release/*).
+  Only patch releases are allowed and the version should belong to the release line of the branch (e.g. 1.4.x for release/1.4).
+  The pull request and the release target the maintenance branch and the GitHub release is not marked as the latest release.
+
+  Hooks:
+  Shell commands can run at different phases of a release, configured under project.release.hooks in the spec file.
+  The phases are before_changelog, before_commit, after_tag, and after_publish.
+  The after_tag hooks run once the release tag is pushed in both direct and indirect modes.
+  The release version and tag are available to the commands as BASIL_VERSION and BASIL_TAG environment variables.
+  A failing hook aborts the release and the files changed by the hooks are included in the release commit.
+
+  Notifications:
+  Published releases can be announced to the endpoints configured under project.release.notifications in the spec file.
+  Generic webhooks receive the release (version, changelog, URL, and artifacts) as JSON or a custom payload from a Go template.
+  Slack and Mattermost incoming webhooks receive a formatted message.
+  Environment variables in the webhook URLs and headers are expanded (e.g. ${WEBHOOK_TOKEN}).
+  Failed deliveries are retried with backoff and a failed notification is reported without failing the release.
+
+  Dry-Run:
+  All read-only steps are executed for real and artifacts are built locally.
+  All git commands, hooks, GitHub/GitLab changes that mutate the repository, image pushes, and notifications are recorded in a plan and printed.
+  The changelog is generated and printed, and the changelog file is restored.
 
   Examples:
     basil project release
@@ -66,20 +141,37 @@ const (
     basil project release -comment="Fixing Bugs!"
     basil project release -minor -comment "New Features!"
     basil project release -major -comment "Breaking Changes!"
+    basil project release -auto
+    basil project release -minor -pre rc
+    basil project release -pre rc
+    basil project release -minor -dry-run
+    basil project release -maintenance-branch "release/*"
+    basil project release -resume
+    basil project release -abort
   `
 )
 
 const (
-	remoteName = "origin"
+	remoteName   = "origin"
+	signatureExt = ".asc"
+
+	hookBeforeChangelog = "before_changelog"
+	hookBeforeCommit    = "before_commit"
+	hookAfterTag        = "after_tag"
+	hookAfterPublish    = "after_publish"
+	hookVersionEnv      = "BASIL_VERSION"
+	hookTagEnv          = "BASIL_TAG"
 )
 
 var (
-	h2Regex = regexp.MustCompile(`##[^\n]*\n`)
+	h2Regex          = regexp.MustCompile(`##[^\n]*\n`)
+	releaseLineRegex = regexp.MustCompile(`(?:^|[/-])v?([0-9]+)\.([0-9]+)(?:\.x)?$`)
 )
 
 type (
 	gitService interface {
 		Remote(string) (string, string, error)
+		Tags() (git.Tags, error)
 	}
 
 	repoService interface {
@@ -93,6 +185,7 @@ type (
 		Create(context.Context, github.ReleaseParams) (*github.Release, *github.Response, error)
 		Update(context.Context, int, github.ReleaseParams) (*github.Release, *github.Response, error)
 		UploadAsset(context.Context, int, string, string) (*github.ReleaseAsset, *github.Response, error)
+		Delete(context.Context, int) (*github.Response, error)
 	}
 
 	pullService interface {
@@ -109,18 +202,47 @@ type (
 		SearchIssues(context.Context, int, int, github.SearchResultSort, github.SearchResultOrder, github.SearchQuery) (*github.SearchIssuesResult, *github.Response, error)
 	}
 
+	checkService interface {
+		Checks(context.Context, *github.Pull) ([]pullCheck, error)
+	}
+
 	changelogService interface {
 		Generate(context.Context, changelogspec.Spec) (string, error)
 	}
 
+	notifyService interface {
+		Endpoints() []string
+		Notify(context.Context, notify.Release) error
+	}
+
+	journalService interface {
+		Load() (*releaseJournal, error)
+		Save(*releaseJournal) error
+		Delete() error
+	}
+
+	// stateService is implemented by the services that keep the state of a release in progress locally (e.g. GitLab draft releases).
+	// The state is kept in the release journal, so the release can be resumed or aborted by another run.
+	stateService interface {
+		SaveState(*releaseJournal)
+		RestoreState(*releaseJournal) error
+	}
+
 	semverCommand interface {
 		Run([]string) int
 		SemVer() semver.SemVer
+		Bump() (semver.Bump, git.Commits)
+		TagPrefix() string
 	}
 
 	buildCommand interface {
 		Run([]string) int
-		Artifacts() []buildcmd.Artifact
+		Manifest() (buildcmd.Manifest, error)
+		PushImages(context.Context) error
+	}
+
+	gateCommand interface {
+		Run([]string) int
 	}
 )
 
@@ -131,25 +253,38 @@ type Command struct {
 	spec   spec.Spec
 	flags  struct {
 		patch, minor, major bool
+		auto                bool
+		pre                 string
 		comment             string
+		dryRun              bool
+		resume, abort       bool
+		skipGates           bool
 	}
 	data struct {
 		owner, repo   string
+		tagPrefix     string
+		maintenance   bool
 		changelogSpec changelogspec.Spec
 	}
 	funcs struct {
 		goList        shell.RunnerFunc
 		gitStatus     shell.RunnerFunc
 		gitRevBranch  shell.RunnerFunc
+		gitRevParse   shell.RunnerFunc
+		gitShow       shell.RunnerFunc
 		gitBranch     shell.RunnerFunc
 		gitCheckout   shell.RunnerFunc
 		gitAdd        shell.RunnerFunc
+		gitAddAll     shell.RunnerFunc
 		gitCommit     shell.RunnerFunc
 		gitTag        shell.RunnerFunc
+		gitReset      shell.RunnerFunc
 		gitPull       shell.RunnerFunc
 		gitPush       shell.RunnerFunc
 		gitPushTag    shell.RunnerFunc
 		gitPushBranch shell.RunnerFunc
+		gpgSign       shell.RunnerFunc
+		hook          shell.RunnerWithFunc
 	}
 	services struct {
 		git       gitService
@@ -158,14 +293,19 @@ type Command struct {
 		pulls     pullService
 		users     userService
 		search    searchService
+		checks    checkService
 		changelog changelogService
+		journal   journalService
+		notify    notifyService
 	}
 	commands struct {
 		semver semverCommand
 		build  buildCommand
+		gate   gateCommand
 	}
 	outputs struct {
 		version semver.SemVer
+		plan    *releasePlan
 	}
 }
 
@@ -205,6 +345,15 @@ func (c *Command) Run(args []string) int {
 		return code
 	}
 
+	if code := c.setup(); code != command.Success {
+		return code
+	}
+
+	return c.exec()
+}
+
+// setup creates the dependencies of the command.
+func (c *Command) setup() int {
 	git, err := git.Open(".")
 	if err != nil {
 		c.ui.Errorf(ui.Red, "%s", err)
@@ -217,67 +366,153 @@ func (c *Command) Run(args []string) int {
 		return command.GitError
 	}
 
-	if domain != "github.com" {
-		c.ui.Errorf(ui.Red, "unsupported git platform: %s", domain)
-		return command.GitHubError
+	gitPath, err := git.Path()
+	if err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.GitError
 	}
 
-	parts := strings.Split(path, "/")
-	if len(parts) != 2 {
-		c.ui.Errorf(ui.Red, "Unexpected GitHub repository: cannot parse owner and repo.")
-		return command.GitHubError
-	}
-	ownerName, repoName := parts[0], parts[1]
+	var platform changelogspec.Platform
+	var accessToken string
 
-	if c.config.GitHub.AccessToken == "" {
-		c.ui.Errorf(ui.Red, "A GitHub access token is required.")
+	switch {
+	case domain == string(changelogspec.PlatformGitHub):
+		if code := c.useGitHub(path); code != command.Success {
+			return code
+		}
+		platform, accessToken = changelogspec.PlatformGitHub, c.config.GitHub.AccessToken
+
+	case domain == string(changelogspec.PlatformGitLab) || domain == c.config.GitLab.Domain:
+		if code := c.useGitLab(domain, path); code != command.Success {
+			return code
+		}
+		platform, accessToken = changelogspec.PlatformGitLab, c.config.GitLab.AccessToken
+
+	default:
+		c.ui.Errorf(ui.Red, "unsupported git platform: %s", domain)
 		return command.GitHubError
 	}
 
-	client := github.NewClient(c.config.GitHub.AccessToken)
-	repo := client.Repo(ownerName, repoName)
-
 	changelogSpec, err := changelogspec.Default().FromFile()
 	if err != nil {
 		c.ui.Errorf(ui.Red, "%s", err)
 		return command.ChangelogError
 	}
 
-	changelogSpec = changelogSpec.WithRepo(domain, path)
-	changelogSpec.Repo.AccessToken = c.config.GitHub.AccessToken
+	// Self-hosted GitLab instances are also generated as GitLab repositories
+	changelogSpec = changelogSpec.WithRepo(string(platform), path)
+	changelogSpec.Repo.AccessToken = accessToken
 	changelog, err := changelog.New(changelogSpec, &changelogUI{UI: c.ui})
 	if err != nil {
 		c.ui.Errorf(ui.Red, "%s", err)
 		return command.ChangelogError
 	}
 
-	c.data.owner = ownerName
-	c.data.repo = repoName
 	c.data.changelogSpec = changelogSpec
 
 	c.funcs.goList = shell.Runner("go", "list", "./...")
 	c.funcs.gitStatus = shell.Runner("git", "status", "--porcelain")
 	c.funcs.gitRevBranch = shell.Runner("git", "rev-parse", "--abbrev-ref", "HEAD")
+	c.funcs.gitRevParse = shell.Runner("git", "rev-parse")
+	c.funcs.gitShow = shell.Runner("git", "show", "--no-patch", "--format=%B")
 	c.funcs.gitBranch = shell.Runner("git", "branch")
 	c.funcs.gitCheckout = shell.Runner("git", "checkout")
 	c.funcs.gitAdd = shell.Runner("git", "add", c.data.changelogSpec.General.File)
+	c.funcs.gitAddAll = shell.Runner("git", "add", "--all")
 	c.funcs.gitCommit = shell.Runner("git", "commit", "-m")
 	c.funcs.gitTag = shell.Runner("git", "tag")
+	c.funcs.gitReset = shell.Runner("git", "reset", "--hard")
 	c.funcs.gitPull = shell.Runner("git", "pull")
 	c.funcs.gitPush = shell.Runner("git", "push")
 	c.funcs.gitPushTag = shell.Runner("git", "push", remoteName)
 	c.funcs.gitPushBranch = shell.Runner("git", "push", "-u", remoteName)
+	c.funcs.gpgSign = shell.Runner("gpg", "--yes", "--armor", "--detach-sign")
+	c.funcs.hook = shell.RunnerWith("sh", "-c")
 	c.services.git = git
+	c.services.changelog = changelog
+	c.services.journal = newFileJournal(filepath.Join(gitPath, ".git"))
+	c.services.notify = notify.New(c.spec.Project.Release.Notifications)
+	c.commands.semver = semvercmd.New(ui.NewNop(), c.spec)
+	c.commands.build = buildcmd.New(c.ui, c.spec)
+	c.commands.gate = gatecmd.New(c.ui, c.spec)
+
+	return command.Success
+}
+
+// useGitHub sets up the services for a GitHub repository.
+func (c *Command) useGitHub(path string) int {
+	parts := strings.Split(path, "/")
+	if len(parts) != 2 {
+		c.ui.Errorf(ui.Red, "Unexpected GitHub repository: cannot parse owner and repo.")
+		return command.GitHubError
+	}
+	ownerName, repoName := parts[0], parts[1]
+
+	if c.config.GitHub.AccessToken == "" {
+		c.ui.Errorf(ui.Red, "A GitHub access token is required.")
+		return command.GitHubError
+	}
+
+	client := github.NewClient(c.config.GitHub.AccessToken)
+	repo := client.Repo(ownerName, repoName)
+
+	c.data.owner = ownerName
+	c.data.repo = repoName
 	c.services.repo = repo
-	c.services.releases = repo.Releases
+	c.services.releases = &githubReleaseService{
+		ReleaseService: repo.Releases,
+		client:         client,
+		owner:          ownerName,
+		repo:           repoName,
+		latest: func() bool {
+			// Maintenance releases should not be marked as the latest release
+			return !c.data.maintenance
+		},
+	}
 	c.services.pulls = repo.Pulls
 	c.services.users = client.Users
 	c.services.search = client.Search
-	c.services.changelog = changelog
-	c.commands.semver = semvercmd.New(ui.NewNop())
-	c.commands.build = buildcmd.New(c.ui, c.spec)
+	c.services.checks = &githubCheckService{
+		client: client,
+		owner:  ownerName,
+		repo:   repoName,
+	}
 
-	return c.exec()
+	return command.Success
+}
+
+// useGitLab sets up the services for a GitLab project either on gitlab.com or a self-hosted instance.
+func (c *Command) useGitLab(domain, path string) int {
+	i := strings.LastIndex(path, "/")
+	if i <= 0 {
+		c.ui.Errorf(ui.Red, "Unexpected GitLab project: cannot parse namespace and project.")
+		return command.GitLabError
+	}
+	namespace, projectName := path[:i], path[i+1:]
+
+	if c.config.GitLab.AccessToken == "" {
+		c.ui.Errorf(ui.Red, "A GitLab access token is required.")
+		return command.GitLabError
+	}
+
+	client, err := gitlab.NewClient("https://"+domain, c.config.GitLab.AccessToken)
+	if err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.GitLabError
+	}
+
+	project := client.Project(path)
+
+	c.data.owner = namespace
+	c.data.repo = projectName
+	c.services.repo = newGitLabRepoService(project, client.Users)
+	c.services.releases = newGitLabReleaseService(project)
+	c.services.pulls = &gitlabPullService{mergeRequests: project.MergeRequests}
+	c.services.users = &gitlabUserService{users: client.Users}
+	c.services.search = &gitlabSearchService{mergeRequests: project.MergeRequests}
+	c.services.checks = &gitlabCheckService{mergeRequests: project.MergeRequests}
+
+	return command.Success
 }
 
 func (c *Command) parseFlags(args []string) int {
@@ -285,7 +520,13 @@ func (c *Command) parseFlags(args []string) int {
 	fs.BoolVar(&c.flags.patch, "patch", true, "")
 	fs.BoolVar(&c.flags.minor, "minor", false, "")
 	fs.BoolVar(&c.flags.major, "major", false, "")
+	fs.BoolVar(&c.flags.auto, "auto", false, "")
+	fs.StringVar(&c.flags.pre, "pre", "", "")
 	fs.StringVar(&c.flags.comment, "comment", "", "")
+	fs.BoolVar(&c.flags.dryRun, "dry-run", false, "")
+	fs.BoolVar(&c.flags.resume, "resume", false, "")
+	fs.BoolVar(&c.flags.abort, "abort", false, "")
+	fs.BoolVar(&c.flags.skipGates, "skip-gates", false, "")
 
 	fs.Usage = func() {
 		c.ui.Printf(c.Help())
@@ -300,11 +541,39 @@ func (c *Command) parseFlags(args []string) int {
 		return command.FlagError
 	}
 
+	if c.flags.pre != "" && !semver.IsChannel(c.flags.pre) {
+		c.ui.Errorf(ui.Red, "Invalid pre-release channel: %s", c.flags.pre)
+		return command.FlagError
+	}
+
+	if c.flags.resume && c.flags.abort {
+		c.ui.Errorf(ui.Red, "The -resume and -abort flags cannot be used together.")
+		return command.FlagError
+	}
+
 	return command.Success
 }
 
 // exec in an auxiliary method, so we can test the business logic with mock dependencies.
 func (c *Command) exec() int {
+	if c.flags.dryRun {
+		c.ui.Warnf(ui.Yellow, "Running in dry-run mode: mutating steps are recorded and not executed.")
+		c.enableDryRun()
+		defer func() {
+			c.outputs.plan.print(c.ui, c.outputs.version)
+		}()
+	}
+
+	// ==============================> RUN QUALITY GATES <==============================
+
+	// The quality gates have their own timeout, so they run before the release timeout starts.
+	// A release in progress has already passed the quality gates.
+	if c.spec.Project.Gates.Enabled() && !c.flags.skipGates && !c.flags.resume && !c.flags.abort {
+		if code := c.commands.gate.Run(nil); code != command.Success {
+			return code
+		}
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
@@ -313,6 +582,7 @@ func (c *Command) exec() int {
 	c.ui.Printf("Running preflight checks ...")
 
 	checklist := command.PreflightChecklist{
+		GPG: c.spec.Project.Release.Sign,
 		Git: true,
 		Go:  true,
 	}
@@ -322,6 +592,38 @@ func (c *Command) exec() int {
 		return command.PreflightError
 	}
 
+	// ==============================> LOAD RELEASE JOURNAL <==============================
+
+	journal, err := c.services.journal.Load()
+	if err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.OSError
+	}
+
+	// Restore the state of services kept in the release journal
+	if journal != nil && (c.flags.resume || c.flags.abort) {
+		for _, s := range c.stateServices() {
+			if err := s.RestoreState(journal); err != nil {
+				c.ui.Errorf(ui.Red, "%s", err)
+				return command.GenericError
+			}
+		}
+	}
+
+	if c.flags.abort {
+		return c.abortRelease(ctx, journal)
+	}
+
+	if c.flags.resume && journal == nil {
+		c.ui.Errorf(ui.Red, "There is no release in progress to resume.")
+		return command.GenericError
+	}
+
+	if !c.flags.resume && journal != nil {
+		c.ui.Errorf(ui.Red, "The release %s is in progress. Use -resume to continue it or -abort to undo it.", journal.Version)
+		return command.GenericError
+	}
+
 	// ==============================> VALIDATE REPO STATE <==============================
 
 	repo, _, err := c.services.repo.Get(ctx)
@@ -337,8 +639,39 @@ func (c *Command) exec() int {
 	}
 
 	if gitBranch != repo.DefaultBranch {
-		c.ui.Errorf(ui.Red, "The repository can only be released from the default branch.")
-		return command.GitError
+		if !c.isMaintenanceBranch(gitBranch) {
+			c.ui.Errorf(ui.Red, "The repository can only be released from the default branch or a maintenance branch.")
+			return command.GitError
+		}
+
+		c.data.maintenance = true
+		c.ui.Warnf(ui.Yellow, "Releasing from the %s maintenance branch ...", gitBranch)
+	}
+
+	// ==============================> RESUME THE RELEASE IN PROGRESS <==============================
+
+	if c.flags.resume {
+		// Resuming on another branch would commit and tag the wrong branch
+		if gitBranch != journal.Branch {
+			c.ui.Errorf(ui.Red, "The release can only be resumed on the %s branch.", journal.Branch)
+			return command.GitError
+		}
+
+		version, ok := semver.Parse(journal.Version)
+		if !ok {
+			c.ui.Errorf(ui.Red, "Invalid version in release journal: %s", journal.Version)
+			return command.GenericError
+		}
+
+		c.outputs.version = version
+		c.data.tagPrefix = journal.TagPrefix
+		c.ui.Printf("Resuming the release %s of %s/%s ...", c.releaseName(), c.data.owner, c.data.repo)
+
+		if code := c.scopeChangelog(); code != command.Success {
+			return code
+		}
+
+		return c.directReleaseWithJournal(ctx, gitBranch, journal)
 	}
 
 	_, gitStatus, err := c.funcs.gitStatus(ctx)
@@ -368,22 +701,53 @@ func (c *Command) exec() int {
 		return code
 	}
 
+	var bump semver.Bump
 	switch {
+	case c.flags.auto:
+		var commits git.Commits
+		bump, commits = c.commands.semver.Bump()
+
+		if len(commits) == 0 {
+			c.ui.Warnf(ui.Yellow, "No commit since the last release requires a release, defaulting to a patch release.")
+		} else {
+			c.ui.Printf("A %s release is determined by the following commits:", bump)
+			for _, commit := range commits {
+				c.ui.Printf("  %s", commit)
+			}
+		}
 	case c.flags.major:
-		c.outputs.version = c.commands.semver.SemVer().ReleaseMajor()
+		bump = semver.Major
 	case c.flags.minor:
-		c.outputs.version = c.commands.semver.SemVer().ReleaseMinor()
+		bump = semver.Minor
 	case c.flags.patch:
 		fallthrough
 	default:
-		c.outputs.version = c.commands.semver.SemVer().ReleasePatch()
+		bump = semver.Patch
+	}
+
+	c.outputs.version = c.commands.semver.SemVer().Release(bump, c.flags.pre)
+	c.data.tagPrefix = c.commands.semver.TagPrefix()
+
+	if c.data.maintenance {
+		if code := c.validateMaintenanceRelease(gitBranch, bump); code != command.Success {
+			return code
+		}
+	}
+
+	if code := c.scopeChangelog(); code != command.Success {
+		return code
 	}
 
 	// ==============================> BRANCH BASED ON MODE <==============================
 
 	switch c.spec.Project.Release.Mode {
 	case spec.ReleaseModeDirect:
-		return c.directRelease(ctx, gitBranch)
+		return c.directReleaseWithJournal(ctx, gitBranch, &releaseJournal{
+			Mode:      spec.ReleaseModeDirect,
+			Version:   c.outputs.version.String(),
+			TagPrefix: c.data.tagPrefix,
+			Branch:    gitBranch,
+		})
 	case spec.ReleaseModeIndirect:
 		return c.indirectRelease(ctx, gitBranch)
 	default:
@@ -392,11 +756,59 @@ func (c *Command) exec() int {
 	}
 }
 
+// directReleaseWithJournal runs a direct release and reports how to continue or undo it in case of a failure.
+func (c *Command) directReleaseWithJournal(ctx context.Context, defaultBranch string, journal *releaseJournal) int {
+	code := c.directRelease(ctx, defaultBranch, journal)
+
+	if code != command.Success && len(journal.Steps) > 0 {
+		if journal.done(stepProtectionDisabled) && !journal.done(stepProtectionEnabled) {
+			c.ui.Warnf(ui.Yellow, "The %s branch is still unprotected.", defaultBranch)
+		}
+		c.ui.Warnf(ui.Yellow, "💁 Re-run this command with -resume to continue the release or with -abort to undo it.")
+	}
+
+	return code
+}
+
+// saveStep marks a release step as completed and persists the release journal.
+func (c *Command) saveStep(journal *releaseJournal, step releaseStep) int {
+	journal.complete(step)
+
+	if err := c.saveJournal(journal); err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.OSError
+	}
+
+	return command.Success
+}
+
+// stateServices returns the services that keep the state of a release in progress locally.
+func (c *Command) stateServices() []stateService {
+	services := []stateService{}
+	for _, s := range []any{c.services.repo, c.services.releases} {
+		if ss, ok := s.(stateService); ok {
+			services = append(services, ss)
+		}
+	}
+
+	return services
+}
+
+// saveJournal persists the release journal together with the state of services.
+func (c *Command) saveJournal(journal *releaseJournal) error {
+	for _, s := range c.stateServices() {
+		s.SaveState(journal)
+	}
+
+	return c.services.journal.Save(journal)
+}
+
 // For direct mode
-func (c *Command) directRelease(ctx context.Context, defaultBranch string) int {
+// Each completed step is recorded in the release journal, so a failed release can be resumed or aborted.
+func (c *Command) directRelease(ctx context.Context, defaultBranch string, journal *releaseJournal) int {
 	// ==============================> CHECK GITHUB PERMISSION <==============================
 
-	c.ui.Printf("Checking GitHub permission for direct mode ...")
+	c.ui.Printf("Checking permission for direct mode ...")
 
 	user, _, err := c.services.users.User(ctx)
 	if err != nil {
@@ -411,184 +823,459 @@ func (c *Command) directRelease(ctx context.Context, defaultBranch string) int {
 	}
 
 	if perm != github.PermissionAdmin {
-		c.ui.Errorf(ui.Red, "The provided GitHub access token does not have admin permission for direct mode.")
+		c.ui.Errorf(ui.Red, "The provided access token does not have admin permission for direct mode.")
 		return command.GitHubError
 	}
 
-	// ==============================> CREATE A DRAFT RELEASE <==============================
+	// ==============================> RECORD THE BASE COMMIT <==============================
 
-	c.ui.Infof(ui.Green, "Creating the draft release %s ...", c.outputs.version)
+	if journal.BaseCommit == "" {
+		_, head, err := c.funcs.gitRevParse(ctx, "HEAD")
+		if err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GitError
+		}
 
-	release, _, err := c.services.releases.Create(ctx, github.ReleaseParams{
-		Name:       c.outputs.version.String(),
-		TagName:    c.outputs.version.TagName(),
-		Target:     defaultBranch,
-		Draft:      true,
-		Prerelease: false,
-	})
+		journal.BaseCommit = head
+	}
 
-	if err != nil {
-		c.ui.Errorf(ui.Red, "%s", err)
-		return command.GitHubError
+	release := &github.Release{
+		ID:      journal.ReleaseID,
+		Name:    c.releaseName(),
+		TagName: c.tagName(),
+		Target:  defaultBranch,
+		Draft:   true,
 	}
 
-	// ==============================> GENERATE CHANGELOG <==============================
+	// ==============================> CREATE A DRAFT RELEASE <==============================
 
-	c.ui.Infof(ui.Green, "Creating/Updating the changelog ...")
+	if !journal.done(stepDraftCreated) {
+		c.ui.Infof(ui.Green, "Creating the draft release %s ...", c.outputs.version)
 
-	c.data.changelogSpec.Tags.Future = c.outputs.version.TagName()
+		release, _, err = c.services.releases.Create(ctx, github.ReleaseParams{
+			Name:       c.releaseName(),
+			TagName:    c.tagName(),
+			Target:     defaultBranch,
+			Draft:      true,
+			Prerelease: c.outputs.version.IsPrerelease(),
+		})
 
-	changelog, err := c.services.changelog.Generate(ctx, c.data.changelogSpec)
-	if err != nil {
-		c.ui.Errorf(ui.Red, "%s", err)
-		return command.ChangelogError
+		if err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GitHubError
+		}
+
+		journal.ReleaseID = release.ID
+		if code := c.saveStep(journal, stepDraftCreated); code != command.Success {
+			return code
+		}
 	}
 
-	// Remove the H2 title
-	changelog = h2Regex.ReplaceAllString(changelog, "")
-	changelog = strings.TrimLeft(changelog, "\n")
+	// ==============================> GENERATE CHANGELOG <==============================
 
-	// ==============================> CREATE RELEASE COMMIT & TAG <==============================
+	if !journal.done(stepChangelogGenerated) {
+		if code := c.runHooks(ctx, hookBeforeChangelog, c.spec.Project.Release.Hooks.BeforeChangelog); code != command.Success {
+			return code
+		}
 
-	c.ui.Infof(ui.Green, "Creating the release commit %s ...", c.outputs.version)
+		c.ui.Infof(ui.Green, "Creating/Updating the changelog ...")
 
-	if _, _, err := c.funcs.gitAdd(ctx); err != nil {
-		c.ui.Errorf(ui.Red, "%s", err)
-		return command.GitError
-	}
+		c.data.changelogSpec.Tags.Future = c.tagName()
 
-	// We need to create the commit using the git command.
-	// So, all user configurations (author, committer, signing key, etc.) will be picked up correctly and automatically.
-	message := fmt.Sprintf("Release %s", c.outputs.version)
-	if _, _, err := c.funcs.gitCommit(ctx, message); err != nil {
-		c.ui.Errorf(ui.Red, "%s", err)
-		return command.GitError
-	}
+		changelog, err := c.services.changelog.Generate(ctx, c.data.changelogSpec)
+		if err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.ChangelogError
+		}
 
-	c.ui.Infof(ui.Green, "Creating the release tag %s ...", c.outputs.version)
+		// Remove the H2 title
+		changelog = h2Regex.ReplaceAllString(changelog, "")
+		changelog = strings.TrimLeft(changelog, "\n")
 
-	// We need to create the tag using the git command.
-	// So, all user configurations (author, committer, signing key, etc.) will be picked up correctly and automatically.
-	if _, _, err := c.funcs.gitTag(ctx, "-a", c.outputs.version.TagName(), "-m", message); err != nil {
-		c.ui.Errorf(ui.Red, "%s", err)
-		return command.GitError
+		journal.Changelog = changelog
+		if code := c.saveStep(journal, stepChangelogGenerated); code != command.Success {
+			return code
+		}
 	}
 
-	// ==============================> BUILD AND UPLOAD ARTIFACTS <==============================
+	// ==============================> CREATE RELEASE COMMIT & TAG <==============================
 
-	// Check if we can build any artifacts
-	if _, _, err := c.funcs.goList(ctx); err == nil {
-		c.ui.Printf("Building artifacts ...")
+	// We need to create the commit using the git command.
+	// So, all user configurations (author, committer, signing key, etc.) will be picked up correctly and automatically.
+	message := fmt.Sprintf("Release %s", c.releaseName())
 
-		// Run build command
-		if code := c.commands.build.Run(nil); code != command.Success {
+	if !journal.done(stepCommitCreated) {
+		if code := c.runHooks(ctx, hookBeforeCommit, c.spec.Project.Release.Hooks.BeforeCommit); code != command.Success {
 			return code
 		}
 
-		if artifacts := c.commands.build.Artifacts(); len(artifacts) > 0 {
-			c.ui.Infof(ui.Green, "Uploading artifacts to release %s ...", release.Name)
+		c.ui.Infof(ui.Green, "Creating the release commit %s ...", c.outputs.version)
 
-			group, groupCtx := errgroup.WithContext(ctx)
+		if err := c.gitAddRelease(ctx); err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GitError
+		}
 
-			for _, artifact := range artifacts {
-				artifact := artifact // https://golang.org/doc/faq#closures_and_goroutines
-				group.Go(func() error {
-					_, _, err := c.services.releases.UploadAsset(groupCtx, release.ID, artifact.Path, artifact.Label)
-					return err
-				})
-			}
+		if _, _, err := c.funcs.gitCommit(ctx, message); err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GitError
+		}
 
-			if err := group.Wait(); err != nil {
-				c.ui.Errorf(ui.Red, "%s", err)
-				return command.GitHubError
-			}
+		if code := c.saveStep(journal, stepCommitCreated); code != command.Success {
+			return code
 		}
 	}
 
-	// ==============================> TEMPORARILY DISABLE DEFAULT BRANCH PROTECTION <==============================
+	if !journal.done(stepTagCreated) {
+		c.ui.Infof(ui.Green, "Creating the release tag %s ...", c.outputs.version)
 
-	c.ui.Warnf(ui.Yellow, "Temporarily enabling push to %s branch ...", defaultBranch)
+		// We need to create the tag using the git command.
+		// So, all user configurations (author, committer, signing key, etc.) will be picked up correctly and automatically.
+		if _, _, err := c.funcs.gitTag(ctx, "-a", c.tagName(), "-m", message); err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GitError
+		}
 
-	if _, err := c.services.repo.BranchProtection(ctx, defaultBranch, false); err != nil {
-		c.ui.Errorf(ui.Red, "%s", err)
-		return command.GitHubError
+		if code := c.saveStep(journal, stepTagCreated); code != command.Success {
+			return code
+		}
 	}
 
-	// ==============================> PUSH RELEASE COMMIT & TAG <==============================
+	// ==============================> BUILD AND UPLOAD ARTIFACTS <==============================
 
-	c.ui.Infof(ui.Green, "Pushing the release commit %s ...", c.outputs.version)
+	built := false
 
-	if _, _, err := c.funcs.gitPush(ctx); err != nil {
-		c.ui.Errorf(ui.Red, "%s", err)
-		return command.GitError
-	}
+	if !journal.done(stepAssetsUploaded) {
+		// Check if we can build any artifacts
+		if _, _, err := c.funcs.goList(ctx); err == nil {
+			c.ui.Printf("Building artifacts ...")
 
-	c.ui.Infof(ui.Green, "Pushing the release tag %s ...", c.outputs.version.TagName())
+			// Run build command
+			if code := c.commands.build.Run(c.buildArgs()); code != command.Success {
+				return code
+			}
+			built = true
 
-	if _, _, err := c.funcs.gitPushTag(ctx, c.outputs.version.TagName()); err != nil {
-		c.ui.Errorf(ui.Red, "%s", err)
-		return command.GitError
-	}
+			artifacts, code := c.releaseArtifacts(ctx)
+			if code != command.Success {
+				return code
+			}
 
-	// ==============================> PUBLISH THE RELEASE <==============================
+			if len(artifacts) > 0 {
+				c.ui.Infof(ui.Green, "Uploading artifacts to release %s ...", release.Name)
 
-	c.ui.Infof(ui.Green, "Publishing the release %s ...", release.Name)
+				var mu sync.Mutex
+				group, groupCtx := errgroup.WithContext(ctx)
 
-	description := changelog
-	if c.flags.comment != "" {
-		description = fmt.Sprintf("%s\n\n%s", c.flags.comment, description)
+				for _, artifact := range artifacts {
+					// Skip the artifacts uploaded before resuming the release
+					if journal.uploaded(artifact.Path) {
+						continue
+					}
+
+					artifact := artifact // https://golang.org/doc/faq#closures_and_goroutines
+					group.Go(func() error {
+						if _, _, err := c.services.releases.UploadAsset(groupCtx, release.ID, artifact.Path, artifact.Label); err != nil {
+							return err
+						}
+
+						mu.Lock()
+						journal.Assets = append(journal.Assets, artifact.Path)
+						mu.Unlock()
+
+						return nil
+					})
+				}
+
+				if err := group.Wait(); err != nil {
+					c.ui.Errorf(ui.Red, "%s", err)
+					// Keep track of the uploaded artifacts
+					_ = c.saveJournal(journal)
+					return command.GitHubError
+				}
+			}
+		}
+
+		if code := c.saveStep(journal, stepAssetsUploaded); code != command.Success {
+			return code
+		}
 	}
 
-	release, _, err = c.services.releases.Update(ctx, release.ID, github.ReleaseParams{
-		Name:       release.Name,
-		TagName:    release.TagName,
-		Target:     release.Target,
-		Draft:      false,
-		Prerelease: false,
-		Body:       description,
-	})
+	// ==============================> TEMPORARILY DISABLE DEFAULT BRANCH PROTECTION <==============================
 
-	if err != nil {
-		c.ui.Errorf(ui.Red, "%s", err)
-		return command.GitHubError
+	if !journal.done(stepProtectionDisabled) {
+		c.ui.Warnf(ui.Yellow, "Temporarily enabling push to %s branch ...", defaultBranch)
+
+		if _, err := c.services.repo.BranchProtection(ctx, defaultBranch, false); err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GitHubError
+		}
+
+		if code := c.saveStep(journal, stepProtectionDisabled); code != command.Success {
+			return code
+		}
+	}
+
+	// ==============================> PUSH RELEASE COMMIT & TAG <==============================
+
+	if !journal.done(stepCommitPushed) {
+		c.ui.Infof(ui.Green, "Pushing the release commit %s ...", c.outputs.version)
+
+		if _, _, err := c.funcs.gitPush(ctx); err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GitError
+		}
+
+		if code := c.saveStep(journal, stepCommitPushed); code != command.Success {
+			return code
+		}
+	}
+
+	if !journal.done(stepTagPushed) {
+		c.ui.Infof(ui.Green, "Pushing the release tag %s ...", c.tagName())
+
+		if _, _, err := c.funcs.gitPushTag(ctx, c.tagName()); err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GitError
+		}
+
+		// The after_tag hooks run once the tag is pushed, the same as in indirect mode
+		if code := c.runHooks(ctx, hookAfterTag, c.spec.Project.Release.Hooks.AfterTag); code != command.Success {
+			return code
+		}
+
+		if code := c.saveStep(journal, stepTagPushed); code != command.Success {
+			return code
+		}
+	}
+
+	// ==============================> PUBLISH THE RELEASE <==============================
+
+	// The journal does not keep the published release (e.g. its URL and assets)
+	publishedBefore := journal.done(stepReleasePublished)
+
+	if !journal.done(stepReleasePublished) {
+		c.ui.Infof(ui.Green, "Publishing the release %s ...", release.Name)
+
+		description := journal.Changelog
+		if c.flags.comment != "" {
+			description = fmt.Sprintf("%s\n\n%s", c.flags.comment, description)
+		}
+
+		release, _, err = c.services.releases.Update(ctx, release.ID, github.ReleaseParams{
+			Name:       release.Name,
+			TagName:    release.TagName,
+			Target:     release.Target,
+			Draft:      false,
+			Prerelease: c.outputs.version.IsPrerelease(),
+			Body:       description,
+		})
+
+		if err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GitHubError
+		}
+
+		if code := c.saveStep(journal, stepReleasePublished); code != command.Success {
+			return code
+		}
 	}
 
 	// ==============================> RE-ENABLE DEFAULT BRANCH PROTECTION <==============================
 
-	c.ui.Warnf(ui.Yellow, "🔒 Re-disabling push to %s branch ...", defaultBranch)
+	if !journal.done(stepProtectionEnabled) {
+		c.ui.Warnf(ui.Yellow, "🔒 Re-disabling push to %s branch ...", defaultBranch)
+
+		if _, err := c.services.repo.BranchProtection(ctx, defaultBranch, true); err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GitHubError
+		}
+
+		if code := c.saveStep(journal, stepProtectionEnabled); code != command.Success {
+			return code
+		}
+	}
+
+	// ==============================> PUSH IMAGES <==============================
+
+	if !journal.done(stepImagesPushed) && c.pushesImages() {
+		// The images are built again if the artifacts were uploaded before resuming the release
+		if !built {
+			if _, _, err := c.funcs.goList(ctx); err == nil {
+				c.ui.Printf("Building images ...")
+
+				if code := c.commands.build.Run(c.buildArgs()); code != command.Success {
+					return code
+				}
+			}
+		}
+
+		if code := c.pushImages(ctx); code != command.Success {
+			return code
+		}
+
+		if code := c.saveStep(journal, stepImagesPushed); code != command.Success {
+			return code
+		}
+	}
+
+	// ==============================> RUN AFTER PUBLISH HOOKS <==============================
 
-	if _, err := c.services.repo.BranchProtection(ctx, defaultBranch, true); err != nil {
+	if code := c.runHooks(ctx, hookAfterPublish, c.spec.Project.Release.Hooks.AfterPublish); code != command.Success {
+		return code
+	}
+
+	// ==============================> SEND NOTIFICATIONS <==============================
+
+	// If the release was published before resuming, it is retrieved again for the notifications
+	if publishedBefore && len(c.services.notify.Endpoints()) > 0 {
+		if published, err := c.lookupPublishedRelease(ctx, c.tagName()); err != nil {
+			c.ui.Warnf(ui.Yellow, "Cannot retrieve the release %s: %s", release.Name, err)
+		} else if published != nil {
+			release = published
+		}
+	}
+
+	c.notifyRelease(ctx, release)
+
+	// ==============================> DONE <==============================
+
+	if err := c.services.journal.Delete(); err != nil {
 		c.ui.Errorf(ui.Red, "%s", err)
-		return command.GitHubError
+		return command.OSError
+	}
+
+	if release.HTMLURL != "" {
+		c.ui.Infof(ui.Magenta, "🔗 %s", release.HTMLURL)
+	}
+
+	return command.Success
+}
+
+// abortRelease undoes the completed steps of a release in progress as much as possible.
+// The local release commit and tag are removed, the draft release is deleted, and the branch protection is restored.
+// The release commit and tag cannot be undone once they are pushed.
+func (c *Command) abortRelease(ctx context.Context, journal *releaseJournal) int {
+	if journal == nil {
+		c.ui.Warnf(ui.Yellow, "There is no release in progress to abort.")
+		return command.Success
+	}
+
+	version, ok := semver.Parse(journal.Version)
+	if !ok {
+		c.ui.Errorf(ui.Red, "Invalid version in release journal: %s", journal.Version)
+		return command.GenericError
+	}
+
+	tag := journal.TagPrefix + version.TagName()
+
+	c.ui.Warnf(ui.Yellow, "Aborting the release %s ...", version)
+
+	// ==============================> RESTORE DEFAULT BRANCH PROTECTION <==============================
+
+	if journal.done(stepProtectionDisabled) && !journal.done(stepProtectionEnabled) {
+		c.ui.Warnf(ui.Yellow, "🔒 Re-disabling push to %s branch ...", journal.Branch)
+
+		if _, err := c.services.repo.BranchProtection(ctx, journal.Branch, true); err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GitHubError
+		}
+
+		if code := c.saveStep(journal, stepProtectionEnabled); code != command.Success {
+			return code
+		}
+	}
+
+	// ==============================> DELETE THE DRAFT RELEASE <==============================
+
+	if journal.done(stepDraftCreated) && !journal.done(stepReleasePublished) {
+		c.ui.Infof(ui.Green, "Deleting the draft release %s ...", version)
+
+		if _, err := c.services.releases.Delete(ctx, journal.ReleaseID); err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GitHubError
+		}
+
+		journal.undo(stepDraftCreated)
+		if err := c.saveJournal(journal); err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.OSError
+		}
+	}
+
+	// ==============================> DELETE THE LOCAL TAG <==============================
+
+	if journal.done(stepTagCreated) && !journal.done(stepTagPushed) {
+		c.ui.Infof(ui.Green, "Deleting the release tag %s ...", tag)
+
+		if _, _, err := c.funcs.gitTag(ctx, "-d", tag); err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GitError
+		}
+
+		journal.undo(stepTagCreated)
+		if err := c.saveJournal(journal); err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.OSError
+		}
+	}
+
+	// ==============================> RESET THE LOCAL COMMIT <==============================
+
+	if journal.done(stepChangelogGenerated) && !journal.done(stepCommitPushed) {
+		_, gitBranch, err := c.funcs.gitRevBranch(ctx)
+		if err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GitError
+		}
+
+		if gitBranch != journal.Branch {
+			c.ui.Errorf(ui.Red, "The release can only be aborted on the %s branch.", journal.Branch)
+			return command.GitError
+		}
+
+		c.ui.Infof(ui.Green, "Resetting the %s branch to %s ...", journal.Branch, journal.BaseCommit)
+
+		if _, _, err := c.funcs.gitReset(ctx, journal.BaseCommit); err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GitError
+		}
+
+		journal.undo(stepCommitCreated)
+		journal.undo(stepChangelogGenerated)
+		if err := c.saveJournal(journal); err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.OSError
+		}
 	}
 
 	// ==============================> DONE <==============================
 
-	c.ui.Infof(ui.Magenta, "🔗 %s", release.HTMLURL)
+	if journal.done(stepCommitPushed) || journal.done(stepTagPushed) {
+		c.ui.Warnf(ui.Yellow, "The release commit or tag %s has already been pushed and cannot be undone.", tag)
+	}
+
+	if err := c.services.journal.Delete(); err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.OSError
+	}
+
+	c.ui.Infof(ui.Green, "The release %s is aborted.", version)
 
 	return command.Success
 }
 
 // For indirect mode
 func (c *Command) indirectRelease(ctx context.Context, defaultBranch string) int {
-	title := fmt.Sprintf("RELEASE %s", c.outputs.version)
-	releaseBranch := fmt.Sprintf("release-%s", c.outputs.version)
+	title := fmt.Sprintf("RELEASE %s", c.releaseName())
+	releaseBranch := fmt.Sprintf("release-%s", c.releaseName())
 
 	// ==============================> CHECK FOR A MERGED PULL REQUEST <==============================
 
 	c.ui.Printf("Checking for a merged pull request for release %s ...", c.outputs.version)
 
 	// Search for a merged pull request matching the release version
-	mergedQuery := github.SearchQuery{}
-	mergedQuery.IncludeKeywords(title)
-	mergedQuery.IncludeQualifiers(
-		github.QualifierIsPR,
-		github.QualifierIsMerged,
-		github.QualifierInTitle,
-		github.QualifierRepo(c.data.owner, c.data.repo),
-	)
-
-	mergedResult, _, err := c.services.search.SearchIssues(ctx, 1, 1, github.SortByDefault, github.DefaultOrder, mergedQuery)
+	mergedPull, err := c.searchPull(ctx, github.QualifierIsMerged, title)
 	if err != nil {
 		c.ui.Errorf(ui.Red, "%s", err)
 		return command.GitHubError
@@ -596,8 +1283,8 @@ func (c *Command) indirectRelease(ctx context.Context, defaultBranch string) int
 
 	// ==============================> FINISH THE RELEASE <==============================
 
-	if len(mergedResult.Items) > 0 {
-		return c.tagAndPublishRelease(ctx, mergedResult.Items[0].Number, defaultBranch)
+	if mergedPull != nil {
+		return c.tagAndPublishRelease(ctx, mergedPull.Number, defaultBranch)
 	}
 
 	// ==============================> CHECK FOR AN OPEN PULL REQUEST <==============================
@@ -605,16 +1292,7 @@ func (c *Command) indirectRelease(ctx context.Context, defaultBranch string) int
 	c.ui.Printf("Checking for an open pull request for release %s ...", c.outputs.version)
 
 	// Search for an open pull request matching the release version
-	openQuery := github.SearchQuery{}
-	openQuery.IncludeKeywords(title)
-	openQuery.IncludeQualifiers(
-		github.QualifierIsPR,
-		github.QualifierIsOpen,
-		github.QualifierInTitle,
-		github.QualifierRepo(c.data.owner, c.data.repo),
-	)
-
-	openResult, _, err := c.services.search.SearchIssues(ctx, 1, 1, github.SortByDefault, github.DefaultOrder, openQuery)
+	openPull, err := c.searchPull(ctx, github.QualifierIsOpen, title)
 	if err != nil {
 		c.ui.Errorf(ui.Red, "%s", err)
 		return command.GitHubError
@@ -632,13 +1310,13 @@ func (c *Command) indirectRelease(ctx context.Context, defaultBranch string) int
 		description = fmt.Sprintf("%s\n\n%s", c.flags.comment, description)
 	}
 
-	if len(openResult.Items) == 0 {
+	if openPull == nil {
 		code := c.createPullAndRelease(ctx, defaultBranch, releaseBranch, title, description)
 		if code != command.Success {
 			return code
 		}
 	} else {
-		code := c.updatePullAndRelease(ctx, openResult.Items[0].Number, defaultBranch, title, description)
+		code := c.updatePullAndRelease(ctx, openPull.Number, defaultBranch, title, description)
 		if code != command.Success {
 			return code
 		}
@@ -649,6 +1327,36 @@ func (c *Command) indirectRelease(ctx context.Context, defaultBranch string) int
 	return command.Success
 }
 
+// searchPull finds the pull request with a given title in a given state (merged or open).
+// The search matches the title keywords loosely (e.g. RELEASE 0.2.0 also matches RELEASE 0.2.0-rc.1),
+// so the result pages are traversed until a pull request with the exact title is found.
+// If no pull request is found, it returns nil.
+func (c *Command) searchPull(ctx context.Context, state github.Qualifier, title string) (*github.Issue, error) {
+	query := github.SearchQuery{}
+	query.IncludeKeywords(title)
+	query.IncludeQualifiers(
+		github.QualifierIsPR,
+		state,
+		github.QualifierInTitle,
+		github.QualifierRepo(c.data.owner, c.data.repo),
+	)
+
+	search := func(ctx context.Context, pageSize, pageNo int) ([]github.Issue, *github.Response, error) {
+		result, resp, err := c.services.search.SearchIssues(ctx, pageSize, pageNo, github.SortByDefault, github.DefaultOrder, query)
+		if err != nil {
+			return nil, nil, err
+		}
+		return result.Items, resp, nil
+	}
+
+	p := newPaginator(search)
+	p.maxPages = searchMaxPages
+
+	return p.find(ctx, func(item github.Issue) bool {
+		return strings.EqualFold(item.Title, title)
+	})
+}
+
 func (c *Command) tagAndPublishRelease(ctx context.Context, number int, defaultBranch string) int {
 	// ==============================> GET MERGED PULL REQUEST & DRAFT RELEASE <==============================
 
@@ -658,12 +1366,12 @@ func (c *Command) tagAndPublishRelease(ctx context.Context, number int, defaultB
 		return command.GitHubError
 	}
 
-	release, code := c.findDraftRelease(ctx, c.outputs.version.TagName())
+	release, code := c.findDraftRelease(ctx, c.tagName())
 	if code != command.Success {
 		return code
 	}
 
-	// ==============================> CREATE & PUSH TAG <==============================
+	// ==============================> PULL THE LATEST CHANGES <==============================
 
 	c.ui.Infof(ui.Green, "Pulling the latest changes on the %s branch ...", defaultBranch)
 
@@ -672,23 +1380,34 @@ func (c *Command) tagAndPublishRelease(ctx context.Context, number int, defaultB
 		return command.GitError
 	}
 
+	return c.publishDraftRelease(ctx, release, pull.MergeCommitSHA)
+}
+
+// publishDraftRelease tags a release commit, builds and uploads the artifacts to a draft release, and publishes the release.
+func (c *Command) publishDraftRelease(ctx context.Context, release *github.Release, commit string) int {
+	// ==============================> CREATE & PUSH TAG <==============================
+
 	c.ui.Infof(ui.Green, "Creating the release tag %s ...", c.outputs.version)
 
 	// We need to create the tag using the git command.
 	// So, all user configurations (author, committer, signing key, etc.) will be picked up correctly and automatically.
-	message := fmt.Sprintf("Release %s", c.outputs.version)
-	if _, _, err := c.funcs.gitTag(ctx, "-a", c.outputs.version.TagName(), pull.MergeCommitSHA, "-m", message); err != nil {
+	message := fmt.Sprintf("Release %s", c.releaseName())
+	if _, _, err := c.funcs.gitTag(ctx, "-a", c.tagName(), commit, "-m", message); err != nil {
 		c.ui.Errorf(ui.Red, "%s", err)
 		return command.GitError
 	}
 
-	c.ui.Infof(ui.Green, "Pushing the release tag %s ...", c.outputs.version.TagName())
+	c.ui.Infof(ui.Green, "Pushing the release tag %s ...", c.tagName())
 
-	if _, _, err := c.funcs.gitPushTag(ctx, c.outputs.version.TagName()); err != nil {
+	if _, _, err := c.funcs.gitPushTag(ctx, c.tagName()); err != nil {
 		c.ui.Errorf(ui.Red, "%s", err)
 		return command.GitError
 	}
 
+	if code := c.runHooks(ctx, hookAfterTag, c.spec.Project.Release.Hooks.AfterTag); code != command.Success {
+		return code
+	}
+
 	// ==============================> BUILD AND UPLOAD ARTIFACTS <==============================
 
 	// Check if we can build any artifacts
@@ -696,11 +1415,16 @@ func (c *Command) tagAndPublishRelease(ctx context.Context, number int, defaultB
 		c.ui.Printf("Building artifacts ...")
 
 		// Run build command
-		if code := c.commands.build.Run(nil); code != command.Success {
+		if code := c.commands.build.Run(c.buildArgs()); code != command.Success {
+			return code
+		}
+
+		artifacts, code := c.releaseArtifacts(ctx)
+		if code != command.Success {
 			return code
 		}
 
-		if artifacts := c.commands.build.Artifacts(); len(artifacts) > 0 {
+		if len(artifacts) > 0 {
 			c.ui.Infof(ui.Green, "Uploading artifacts to release %s ...", c.outputs.version)
 
 			group, groupCtx := errgroup.WithContext(ctx)
@@ -724,12 +1448,12 @@ func (c *Command) tagAndPublishRelease(ctx context.Context, number int, defaultB
 
 	c.ui.Infof(ui.Green, "Publishing the release %s ...", release.Name)
 
-	release, _, err = c.services.releases.Update(ctx, release.ID, github.ReleaseParams{
+	release, _, err := c.services.releases.Update(ctx, release.ID, github.ReleaseParams{
 		Name:       release.Name,
 		TagName:    release.TagName,
 		Target:     release.Target,
 		Draft:      false,
-		Prerelease: false,
+		Prerelease: c.outputs.version.IsPrerelease(),
 		Body:       release.Body,
 	})
 
@@ -738,6 +1462,18 @@ func (c *Command) tagAndPublishRelease(ctx context.Context, number int, defaultB
 		return command.GitHubError
 	}
 
+	if code := c.pushImages(ctx); code != command.Success {
+		return code
+	}
+
+	if code := c.runHooks(ctx, hookAfterPublish, c.spec.Project.Release.Hooks.AfterPublish); code != command.Success {
+		return code
+	}
+
+	// ==============================> SEND NOTIFICATIONS <==============================
+
+	c.notifyRelease(ctx, release)
+
 	// ==============================> DONE <==============================
 
 	c.ui.Infof(ui.Magenta, "🔗 %s", release.HTMLURL)
@@ -748,9 +1484,13 @@ func (c *Command) tagAndPublishRelease(ctx context.Context, number int, defaultB
 func (c *Command) pushReleaseBranch(ctx context.Context, defaultBranch, releaseBranch string) (string, int) {
 	// ==============================> GENERATE CHANGELOG <==============================
 
+	if code := c.runHooks(ctx, hookBeforeChangelog, c.spec.Project.Release.Hooks.BeforeChangelog); code != command.Success {
+		return "", code
+	}
+
 	c.ui.Infof(ui.Green, "Creating/Updating the changelog ...")
 
-	c.data.changelogSpec.Tags.Future = c.outputs.version.TagName()
+	c.data.changelogSpec.Tags.Future = c.tagName()
 
 	changelog, err := c.services.changelog.Generate(ctx, c.data.changelogSpec)
 	if err != nil {
@@ -772,16 +1512,20 @@ func (c *Command) pushReleaseBranch(ctx context.Context, defaultBranch, releaseB
 		return changelog, command.GitError
 	}
 
+	if code := c.runHooks(ctx, hookBeforeCommit, c.spec.Project.Release.Hooks.BeforeCommit); code != command.Success {
+		return changelog, code
+	}
+
 	c.ui.Infof(ui.Green, "Creating the release commit %s ...", c.outputs.version)
 
-	if _, _, err := c.funcs.gitAdd(ctx); err != nil {
+	if err := c.gitAddRelease(ctx); err != nil {
 		c.ui.Errorf(ui.Red, "%s", err)
 		return changelog, command.GitError
 	}
 
 	// We need to create the commit using the git command.
 	// So, all user configurations (author, committer, signing key, etc.) will be picked up correctly and automatically.
-	message := fmt.Sprintf("Release %s", c.outputs.version)
+	message := fmt.Sprintf("Release %s", c.releaseName())
 	if _, _, err := c.funcs.gitCommit(ctx, message); err != nil {
 		c.ui.Errorf(ui.Red, "%s", err)
 		return changelog, command.GitError
@@ -836,11 +1580,11 @@ func (c *Command) createPullAndRelease(ctx context.Context, defaultBranch, relea
 	c.ui.Infof(ui.Green, "Creating the draft release %s ...", c.outputs.version)
 
 	release, _, err := c.services.releases.Create(ctx, github.ReleaseParams{
-		Name:       c.outputs.version.String(),
-		TagName:    c.outputs.version.TagName(),
+		Name:       c.releaseName(),
+		TagName:    c.tagName(),
 		Target:     defaultBranch,
 		Draft:      true,
-		Prerelease: false,
+		Prerelease: c.outputs.version.IsPrerelease(),
 		Body:       description,
 	})
 
@@ -879,17 +1623,17 @@ func (c *Command) updatePullAndRelease(ctx context.Context, number int, defaultB
 
 	c.ui.Infof(ui.Green, "Updating the draft release %s ...", c.outputs.version)
 
-	release, code := c.findDraftRelease(ctx, c.outputs.version.TagName())
+	release, code := c.findDraftRelease(ctx, c.tagName())
 	if code != command.Success {
 		return code
 	}
 
 	release, _, err = c.services.releases.Update(ctx, release.ID, github.ReleaseParams{
-		Name:       c.outputs.version.String(),
-		TagName:    c.outputs.version.TagName(),
+		Name:       c.releaseName(),
+		TagName:    c.tagName(),
 		Target:     defaultBranch,
 		Draft:      true,
-		Prerelease: false,
+		Prerelease: c.outputs.version.IsPrerelease(),
 		Body:       description,
 	})
 
@@ -908,45 +1652,238 @@ func (c *Command) updatePullAndRelease(ctx context.Context, number int, defaultB
 	return command.Success
 }
 
-func (c *Command) findDraftRelease(ctx context.Context, tag string) (*github.Release, int) {
-	releases, resp, err := c.services.releases.List(ctx, 100, 1)
+// runHooks runs the shell commands of a release hook phase one by one.
+// The release version and tag are exposed to the commands as environment variables.
+func (c *Command) runHooks(ctx context.Context, phase string, commands []string) int {
+	if len(commands) == 0 {
+		return command.Success
+	}
+
+	c.ui.Infof(ui.Green, "Running %s hooks ...", phase)
+
+	opts := shell.RunOptions{
+		Environment: map[string]string{
+			hookVersionEnv: c.outputs.version.String(),
+			hookTagEnv:     c.tagName(),
+		},
+	}
+
+	for _, cmd := range commands {
+		c.ui.Printf("  %s", cmd)
+
+		_, out, err := c.funcs.hook(ctx, opts, cmd)
+		if err != nil {
+			c.ui.Errorf(ui.Red, "The %s hook failed: %s", phase, err)
+			return command.HookError
+		}
+
+		if out != "" {
+			c.ui.Printf("%s", out)
+		}
+	}
+
+	return command.Success
+}
+
+// notifyRelease announces a published release to the notification endpoints.
+// The release is already published, so a failed notification is only reported.
+func (c *Command) notifyRelease(ctx context.Context, release *github.Release) {
+	endpoints := c.services.notify.Endpoints()
+	if len(endpoints) == 0 {
+		return
+	}
+
+	c.ui.Infof(ui.Green, "Sending release notifications to %s ...", strings.Join(endpoints, ", "))
+
+	artifacts := []notify.Artifact{}
+	for _, asset := range release.Assets {
+		artifacts = append(artifacts, notify.Artifact{
+			Name: asset.Name,
+			URL:  asset.DownloadURL,
+		})
+	}
+
+	err := c.services.notify.Notify(ctx, notify.Release{
+		Repo:       c.data.owner + "/" + c.data.repo,
+		Name:       release.Name,
+		Version:    c.outputs.version.String(),
+		Tag:        c.tagName(),
+		URL:        release.HTMLURL,
+		Changelog:  release.Body,
+		Prerelease: release.Prerelease,
+		Artifacts:  artifacts,
+	})
+
+	if err != nil {
+		for _, line := range strings.Split(err.Error(), "\n") {
+			c.ui.Warnf(ui.Yellow, "%s", line)
+		}
+	}
+}
+
+// gitAddRelease stages the changelog for the release commit.
+// If there are any hooks before the release commit, all files changed by them are staged too.
+func (c *Command) gitAddRelease(ctx context.Context) error {
+	if _, _, err := c.funcs.gitAdd(ctx); err != nil {
+		return err
+	}
+
+	hooks := c.spec.Project.Release.Hooks
+	if len(hooks.BeforeChangelog) > 0 || len(hooks.BeforeCommit) > 0 {
+		if _, _, err := c.funcs.gitAddAll(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isMaintenanceBranch determines whether or not a branch is a maintenance branch.
+func (c *Command) isMaintenanceBranch(branch string) bool {
+	pattern := c.spec.Project.Release.MaintenanceBranch
+	if pattern == "" {
+		return false
+	}
+
+	matched, err := path.Match(pattern, branch)
+	return err == nil && matched
+}
+
+// validateMaintenanceRelease ensures a release from a maintenance branch is constrained to the release line of the branch.
+// Only patch releases are allowed and the release tag should not already exist.
+func (c *Command) validateMaintenanceRelease(branch string, bump semver.Bump) int {
+	if bump != semver.Patch {
+		c.ui.Errorf(ui.Red, "Only patch releases can be created from the %s maintenance branch.", branch)
+		return command.FlagError
+	}
+
+	// If the branch name has a release line (e.g. release/1.4), the version should belong to it
+	if m := releaseLineRegex.FindStringSubmatch(branch); len(m) == 3 {
+		if line := fmt.Sprintf("%d.%d", c.outputs.version.Major, c.outputs.version.Minor); line != m[1]+"."+m[2] {
+			c.ui.Errorf(ui.Red, "The version %s does not belong to the %s.x release line of the %s maintenance branch.", c.outputs.version, m[1]+"."+m[2], branch)
+			return command.GenericError
+		}
+	}
+
+	tags, err := c.services.git.Tags()
 	if err != nil {
 		c.ui.Errorf(ui.Red, "%s", err)
-		return nil, command.GitHubError
+		return command.GitError
 	}
 
-	for _, release := range releases {
-		if release.Draft && release.TagName == tag {
-			return &release, command.Success
+	if _, ok := tags.First(func(t git.Tag) bool { return t.Name == c.tagName() }); ok {
+		c.ui.Errorf(ui.Red, "The release tag %s already exists.", c.tagName())
+		return command.GitError
+	}
+
+	return command.Success
+}
+
+// tagName returns the release tag name.
+// For a module in a sub-directory, the tag name is prefixed with the module path.
+func (c *Command) tagName() string {
+	return c.data.tagPrefix + c.outputs.version.TagName()
+}
+
+// releaseName returns the release name.
+// For a module in a sub-directory, the release name is prefixed with the module path.
+func (c *Command) releaseName() string {
+	return c.data.tagPrefix + c.outputs.version.String()
+}
+
+// scopeChangelog excludes the tags of other modules from the changelog of a module in a sub-directory.
+func (c *Command) scopeChangelog() int {
+	if c.data.tagPrefix == "" {
+		return command.Success
+	}
+
+	tags, err := c.services.git.Tags()
+	if err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.GitError
+	}
+
+	for _, tag := range tags {
+		if name, ok := strings.CutPrefix(tag.Name, c.data.tagPrefix); !ok || strings.Contains(name, "/") {
+			c.data.changelogSpec.Tags.Exclude = append(c.data.changelogSpec.Tags.Exclude, tag.Name)
 		}
 	}
 
-	var release *github.Release
+	return command.Success
+}
+
+// buildArgs returns the arguments for the build command.
+// The quality gates are run by the release command itself and skipped for building the release artifacts.
+// The images are not pushed by the build command, since they are pushed once the release is published.
+// Only the released module is built, since the other modules in the directory (e.g. nested modules) have their own releases.
+func (c *Command) buildArgs() []string {
+	return []string{"-skip-gates", "-push=false", "-module", "."}
+}
+
+// pushesImages determines whether or not the images built for a release are pushed to the registry.
+func (c *Command) pushesImages() bool {
+	image := c.spec.Project.Build.Image
+	return image.Enabled && image.Push
+}
+
+// pushImages pushes the images built for the release to the registry.
+// The images are pushed after the release is published, so a failed release does not leave pushed image tags behind.
+func (c *Command) pushImages(ctx context.Context) int {
+	if !c.pushesImages() {
+		return command.Success
+	}
+
+	c.ui.Infof(ui.Green, "Pushing the images of release %s ...", c.outputs.version)
 
-	group, ctx := errgroup.WithContext(ctx)
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
+	if err := c.commands.build.PushImages(ctx); err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.ImageError
+	}
 
-	for page := 2; page <= resp.Pages.Last; page++ {
-		group.Go(func() error {
-			releases, _, err := c.services.releases.List(ctx, 100, 1)
-			if err != nil {
-				return err
-			}
+	return command.Success
+}
 
-			for _, r := range releases {
-				if r.Draft && r.TagName == tag {
-					release = &r
-					cancel()
-					break
-				}
-			}
+// releaseArtifacts returns the build artifacts listed in the build manifest to be uploaded to a release.
+// If signing is enabled, it also signs the artifacts and returns their signatures as artifacts.
+func (c *Command) releaseArtifacts(ctx context.Context) ([]buildcmd.Artifact, int) {
+	manifest, err := c.commands.build.Manifest()
+	if err != nil {
+		c.ui.Errorf(ui.Red, "Cannot read the build manifest: %s", err)
+		return nil, command.OSError
+	}
 
-			return nil
-		})
+	artifacts := manifest.ToArtifacts()
+	if !c.spec.Project.Release.Sign || len(artifacts) == 0 {
+		return artifacts, command.Success
 	}
 
-	if err := group.Wait(); err != nil {
+	c.ui.Infof(ui.Green, "Signing artifacts ...")
+
+	signatures := make([]buildcmd.Artifact, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		signature := artifact.Path + signatureExt
+
+		args := []string{}
+		if key := c.spec.Project.Release.SigningKey; key != "" {
+			args = append(args, "--local-user", key)
+		}
+		args = append(args, "--output", signature, artifact.Path)
+
+		if _, _, err := c.funcs.gpgSign(ctx, args...); err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return nil, command.GPGError
+		}
+
+		signatures = append(signatures, buildcmd.Artifact{Path: signature})
+	}
+
+	return append(artifacts, signatures...), command.Success
+}
+
+// findDraftRelease finds the draft release for a tag and fails if there is no draft release for the tag.
+func (c *Command) findDraftRelease(ctx context.Context, tag string) (*github.Release, int) {
+	release, err := c.lookupDraftRelease(ctx, tag)
+	if err != nil {
 		c.ui.Errorf(ui.Red, "%s", err)
 		return nil, command.GitHubError
 	}
@@ -958,3 +1895,19 @@ func (c *Command) findDraftRelease(ctx context.Context, tag string) (*github.Rel
 
 	return release, command.Success
 }
+
+// lookupPublishedRelease finds the published release for a tag.
+// If there is no published release for the tag, it returns nil.
+func (c *Command) lookupPublishedRelease(ctx context.Context, tag string) (*github.Release, error) {
+	return newPaginator(c.services.releases.List).find(ctx, func(release github.Release) bool {
+		return !release.Draft && release.TagName == tag
+	})
+}
+
+// lookupDraftRelease finds the draft release for a tag.
+// If there is no draft release for the tag, it returns nil.
+func (c *Command) lookupDraftRelease(ctx context.Context, tag string) (*github.Release, error) {
+	return newPaginator(c.services.releases.List).find(ctx, func(release github.Release) bool {
+		return release.Draft && release.TagName == tag
+	})
+}
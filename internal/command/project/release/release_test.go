@@ -13,6 +13,8 @@ import (
 	"github.com/gardenbed/basil-cli/internal/command"
 	buildcmd "github.com/gardenbed/basil-cli/internal/command/project/build"
 	"github.com/gardenbed/basil-cli/internal/config"
+	"github.com/gardenbed/basil-cli/internal/git"
+	"github.com/gardenbed/basil-cli/internal/notify"
 	"github.com/gardenbed/basil-cli/internal/semver"
 	"github.com/gardenbed/basil-cli/internal/spec"
 	"github.com/gardenbed/basil-cli/internal/ui"
@@ -40,6 +42,17 @@ var (
 		},
 	}
 
+	manifest = buildcmd.Manifest{
+		Version: "0.1.0",
+		Artifacts: []buildcmd.ManifestEntry{
+			{
+				Path:    "bin/app",
+				Label:   "linux",
+				Version: "0.1.0",
+			},
+		},
+	}
+
 	draftRelease = github.Release{
 		Name:       "0.1.0",
 		TagName:    "v0.1.0",
@@ -172,24 +185,34 @@ func TestCommand_Run(t *testing.T) {
 		assert.NotNil(t, c.funcs.goList)
 		assert.NotNil(t, c.funcs.gitStatus)
 		assert.NotNil(t, c.funcs.gitRevBranch)
+		assert.NotNil(t, c.funcs.gitRevParse)
+		assert.NotNil(t, c.funcs.gitShow)
 		assert.NotNil(t, c.funcs.gitBranch)
 		assert.NotNil(t, c.funcs.gitCheckout)
 		assert.NotNil(t, c.funcs.gitAdd)
+		assert.NotNil(t, c.funcs.gitAddAll)
 		assert.NotNil(t, c.funcs.gitCommit)
 		assert.NotNil(t, c.funcs.gitTag)
+		assert.NotNil(t, c.funcs.gitReset)
 		assert.NotNil(t, c.funcs.gitPull)
 		assert.NotNil(t, c.funcs.gitPush)
 		assert.NotNil(t, c.funcs.gitPushTag)
 		assert.NotNil(t, c.funcs.gitPushBranch)
+		assert.NotNil(t, c.funcs.gpgSign)
+		assert.NotNil(t, c.funcs.hook)
 		assert.NotNil(t, c.services.git)
 		assert.NotNil(t, c.services.repo)
 		assert.NotNil(t, c.services.releases)
 		assert.NotNil(t, c.services.pulls)
 		assert.NotNil(t, c.services.users)
 		assert.NotNil(t, c.services.search)
+		assert.NotNil(t, c.services.checks)
 		assert.NotNil(t, c.services.changelog)
+		assert.NotNil(t, c.services.journal)
+		assert.NotNil(t, c.services.notify)
 		assert.NotNil(t, c.commands.semver)
 		assert.NotNil(t, c.commands.build)
+		assert.NotNil(t, c.commands.gate)
 	})
 }
 
@@ -215,11 +238,25 @@ func TestCommand_parseFlags(t *testing.T) {
 				"-patch",
 				"-minor",
 				"-major",
+				"-auto",
+				"-pre", "rc",
 				"-comment", "description",
 				"-mode", "direct",
+				"-dry-run",
+				"-skip-gates",
 			},
 			expectedExitCode: command.Success,
 		},
+		{
+			name:             "InvalidPreReleaseChannel",
+			args:             []string{"-pre", "rc.1"},
+			expectedExitCode: command.FlagError,
+		},
+		{
+			name:             "ResumeAndAbort",
+			args:             []string{"-resume", "-abort"},
+			expectedExitCode: command.FlagError,
+		},
 	}
 
 	for _, tc := range tests {
@@ -239,15 +276,172 @@ func TestCommand_exec(t *testing.T) {
 		patchFlag        bool
 		minorFlag        bool
 		majorFlag        bool
+		autoFlag         bool
+		preFlag          string
+		resumeFlag       bool
+		abortFlag        bool
+		skipGatesFlag    bool
 		gitRevBranch     shell.RunnerFunc
 		gitStatus        shell.RunnerFunc
 		gitPull          shell.RunnerFunc
 		repo             *MockRepoService
 		users            *MockUserService
 		search           *MockSearchService
+		git              *MockGitService
 		semver           *MockSemverCommand
+		gate             *MockGateCommand
+		journal          *MockJournalService
 		expectedExitCode int
+		expectedVersion  string
 	}{
+		{
+			name: "GatesFail",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Gates: spec.Gates{Test: true},
+				},
+			},
+			gate: &MockGateCommand{
+				RunMocks: []GateRunMock{
+					{OutCode: command.GateError},
+				},
+			},
+			expectedExitCode: command.GateError,
+		},
+		{
+			name: "SkipGates",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Gates: spec.Gates{Test: true},
+				},
+			},
+			skipGatesFlag: true,
+			journal: &MockJournalService{
+				LoadMocks: []JournalLoadMock{
+					{OutError: errors.New("io error")},
+				},
+			},
+			expectedExitCode: command.OSError,
+		},
+		{
+			name: "NoGatesOnAbort",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Gates: spec.Gates{Test: true},
+				},
+			},
+			abortFlag: true,
+			journal: &MockJournalService{
+				LoadMocks: []JournalLoadMock{
+					{OutJournal: nil},
+				},
+			},
+			expectedExitCode: command.Success,
+		},
+		{
+			name: "JournalLoadFails",
+			journal: &MockJournalService{
+				LoadMocks: []JournalLoadMock{
+					{OutError: errors.New("io error")},
+				},
+			},
+			expectedExitCode: command.OSError,
+		},
+		{
+			name: "ReleaseInProgress",
+			journal: &MockJournalService{
+				LoadMocks: []JournalLoadMock{
+					{OutJournal: &releaseJournal{Version: "0.1.0"}},
+				},
+			},
+			expectedExitCode: command.GenericError,
+		},
+		{
+			name:       "NoReleaseToResume",
+			resumeFlag: true,
+			journal: &MockJournalService{
+				LoadMocks: []JournalLoadMock{
+					{OutJournal: nil},
+				},
+			},
+			expectedExitCode: command.GenericError,
+		},
+		{
+			name:      "NoReleaseToAbort",
+			abortFlag: true,
+			journal: &MockJournalService{
+				LoadMocks: []JournalLoadMock{
+					{OutJournal: nil},
+				},
+			},
+			expectedExitCode: command.Success,
+		},
+		{
+			name:       "ResumeInvalidVersion",
+			resumeFlag: true,
+			gitRevBranch: func(context.Context, ...string) (int, string, error) {
+				return 0, "main", nil
+			},
+			repo: &MockRepoService{
+				GetMocks: []GetMock{
+					{OutRepository: &repo, OutResponse: &github.Response{}},
+				},
+			},
+			journal: &MockJournalService{
+				LoadMocks: []JournalLoadMock{
+					{OutJournal: &releaseJournal{Version: "invalid", Branch: "main"}},
+				},
+			},
+			expectedExitCode: command.GenericError,
+		},
+		{
+			name:       "ResumeOnAnotherBranch",
+			resumeFlag: true,
+			gitRevBranch: func(context.Context, ...string) (int, string, error) {
+				return 0, "release/0.1", nil
+			},
+			spec: spec.Spec{
+				Project: spec.Project{
+					Release: spec.Release{
+						MaintenanceBranch: "release/*",
+					},
+				},
+			},
+			repo: &MockRepoService{
+				GetMocks: []GetMock{
+					{OutRepository: &repo, OutResponse: &github.Response{}},
+				},
+			},
+			journal: &MockJournalService{
+				LoadMocks: []JournalLoadMock{
+					{OutJournal: &releaseJournal{Mode: spec.ReleaseModeDirect, Version: "0.1.0", Branch: "main"}},
+				},
+			},
+			expectedExitCode: command.GitError,
+		},
+		{
+			name:       "ResumeRelease",
+			resumeFlag: true,
+			gitRevBranch: func(context.Context, ...string) (int, string, error) {
+				return 0, "main", nil
+			},
+			repo: &MockRepoService{
+				GetMocks: []GetMock{
+					{OutRepository: &repo, OutResponse: &github.Response{}},
+				},
+			},
+			users: &MockUserService{
+				UserMocks: []UserMock{
+					{OutError: errors.New("github error")},
+				},
+			},
+			journal: &MockJournalService{
+				LoadMocks: []JournalLoadMock{
+					{OutJournal: &releaseJournal{Mode: spec.ReleaseModeDirect, Version: "0.1.0", Branch: "main"}},
+				},
+			},
+			expectedExitCode: command.GitHubError,
+		},
 		{
 			name: "RepoGetFails",
 			repo: &MockRepoService{
@@ -378,6 +572,9 @@ func TestCommand_exec(t *testing.T) {
 				SemVerMocks: []SemVerMock{
 					{OutSemVer: version},
 				},
+				TagPrefixMocks: []TagPrefixMock{
+					{OutTagPrefix: ""},
+				},
 			},
 			expectedExitCode: command.GitHubError,
 		},
@@ -413,6 +610,9 @@ func TestCommand_exec(t *testing.T) {
 				SemVerMocks: []SemVerMock{
 					{OutSemVer: version},
 				},
+				TagPrefixMocks: []TagPrefixMock{
+					{OutTagPrefix: ""},
+				},
 			},
 			expectedExitCode: command.GitHubError,
 		},
@@ -443,364 +643,404 @@ func TestCommand_exec(t *testing.T) {
 				SemVerMocks: []SemVerMock{
 					{OutSemVer: version},
 				},
+				TagPrefixMocks: []TagPrefixMock{
+					{OutTagPrefix: ""},
+				},
 			},
 			expectedExitCode: command.SpecError,
+			expectedVersion:  "1.0.0",
 		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			c := &Command{
-				ui:   ui.NewNop(),
-				spec: tc.spec,
-			}
-
-			c.flags.patch = tc.patchFlag
-			c.flags.minor = tc.minorFlag
-			c.flags.major = tc.majorFlag
-
-			c.data.owner = "octocat"
-			c.data.repo = "Hello-World"
-
-			c.funcs.gitRevBranch = tc.gitRevBranch
-			c.funcs.gitStatus = tc.gitStatus
-			c.funcs.gitPull = tc.gitPull
-			c.services.repo = tc.repo
-			c.services.users = tc.users
-			c.services.search = tc.search
-			c.commands.semver = tc.semver
-
-			exitCode := c.exec()
-
-			assert.Equal(t, tc.expectedExitCode, exitCode)
-		})
-	}
-}
-
-func TestCommand_directRelease(t *testing.T) {
-	tests := []struct {
-		name             string
-		commentFlag      string
-		gitAdd           shell.RunnerFunc
-		gitCommit        shell.RunnerFunc
-		gitTag           shell.RunnerFunc
-		goList           shell.RunnerFunc
-		gitPush          shell.RunnerFunc
-		gitPushTag       shell.RunnerFunc
-		users            *MockUserService
-		repo             *MockRepoService
-		releases         *MockReleaseService
-		changelog        *MockChangelogService
-		build            *MockBuildCommand
-		version          semver.SemVer
-		ctx              context.Context
-		defaultBranch    string
-		expectedExitCode int
-	}{
 		{
-			name: "UsersUserFails",
-			users: &MockUserService{
-				UserMocks: []UserMock{
-					{OutError: errors.New("github error")},
+			name: "PreRelease",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Release: spec.Release{
+						Mode: spec.ReleaseMode(""),
+					},
 				},
 			},
-			version:          version,
-			ctx:              context.Background(),
-			defaultBranch:    "main",
-			expectedExitCode: command.GitHubError,
-		},
-		{
-			name: "RepoPermissionFails",
-			users: &MockUserService{
-				UserMocks: []UserMock{
-					{OutUser: &user, OutResponse: &github.Response{}},
-				},
+			minorFlag: true,
+			preFlag:   "rc",
+			gitRevBranch: func(context.Context, ...string) (int, string, error) {
+				return 0, "main", nil
 			},
+			gitStatus: successRunnerFunc,
+			gitPull:   successRunnerFunc,
 			repo: &MockRepoService{
-				PermissionMocks: []PermissionMock{
-					{OutError: errors.New("github error")},
+				GetMocks: []GetMock{
+					{OutRepository: &repo, OutResponse: &github.Response{}},
 				},
 			},
-			version:          version,
-			ctx:              context.Background(),
-			defaultBranch:    "main",
-			expectedExitCode: command.GitHubError,
-		},
-		{
-			name: "InvalidUserPermission",
-			users: &MockUserService{
-				UserMocks: []UserMock{
-					{OutUser: &user, OutResponse: &github.Response{}},
+			semver: &MockSemverCommand{
+				RunMocks: []SemverRunMock{
+					{OutCode: command.Success},
 				},
-			},
-			repo: &MockRepoService{
-				PermissionMocks: []PermissionMock{
-					{OutPermission: github.PermissionWrite, OutResponse: &github.Response{}},
+				SemVerMocks: []SemVerMock{
+					{OutSemVer: version},
+				},
+				TagPrefixMocks: []TagPrefixMock{
+					{OutTagPrefix: ""},
 				},
 			},
-			version:          version,
-			ctx:              context.Background(),
-			defaultBranch:    "main",
-			expectedExitCode: command.GitHubError,
+			expectedExitCode: command.SpecError,
+			expectedVersion:  "0.2.0-rc.1",
 		},
 		{
-			name: "CreateReleaseFails",
-			users: &MockUserService{
-				UserMocks: []UserMock{
-					{OutUser: &user, OutResponse: &github.Response{}},
+			name: "AutoRelease",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Release: spec.Release{
+						Mode: spec.ReleaseMode(""),
+					},
 				},
 			},
+			autoFlag: true,
+			gitRevBranch: func(context.Context, ...string) (int, string, error) {
+				return 0, "main", nil
+			},
+			gitStatus: successRunnerFunc,
+			gitPull:   successRunnerFunc,
 			repo: &MockRepoService{
-				PermissionMocks: []PermissionMock{
-					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				GetMocks: []GetMock{
+					{OutRepository: &repo, OutResponse: &github.Response{}},
 				},
 			},
-			releases: &MockReleaseService{
-				CreateMocks: []ReleaseCreateMock{
-					{OutError: errors.New("github error")},
+			semver: &MockSemverCommand{
+				RunMocks: []SemverRunMock{
+					{OutCode: command.Success},
+				},
+				SemVerMocks: []SemVerMock{
+					{OutSemVer: version},
+				},
+				TagPrefixMocks: []TagPrefixMock{
+					{OutTagPrefix: ""},
+				},
+				BumpMocks: []BumpMock{
+					{
+						OutBump: semver.Minor,
+						OutCommits: git.Commits{
+							{Hash: "25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378", Message: "feat: add a feature"},
+						},
+					},
 				},
 			},
-			version:          version,
-			ctx:              context.Background(),
-			defaultBranch:    "main",
-			expectedExitCode: command.GitHubError,
+			expectedExitCode: command.SpecError,
+			expectedVersion:  "0.2.0",
 		},
 		{
-			name: "ChangelogGenerateFails",
-			users: &MockUserService{
-				UserMocks: []UserMock{
-					{OutUser: &user, OutResponse: &github.Response{}},
+			name: "AutoRelease_NoReleaseCommit",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Release: spec.Release{
+						Mode: spec.ReleaseMode(""),
+					},
 				},
 			},
-			repo: &MockRepoService{
-				PermissionMocks: []PermissionMock{
-					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
-				},
-			},
-			releases: &MockReleaseService{
-				CreateMocks: []ReleaseCreateMock{
-					{OutRelease: &draftRelease, OutResponse: &github.Response{}},
-				},
+			autoFlag: true,
+			gitRevBranch: func(context.Context, ...string) (int, string, error) {
+				return 0, "main", nil
 			},
-			changelog: &MockChangelogService{
-				GenerateMocks: []GenerateMock{
-					{OutError: errors.New("changelog error")},
+			gitStatus: successRunnerFunc,
+			gitPull:   successRunnerFunc,
+			repo: &MockRepoService{
+				GetMocks: []GetMock{
+					{OutRepository: &repo, OutResponse: &github.Response{}},
 				},
 			},
-			version:          version,
-			ctx:              context.Background(),
-			defaultBranch:    "main",
-			expectedExitCode: command.ChangelogError,
-		},
-		{
-			name: "GitAddFails",
-			gitAdd: func(context.Context, ...string) (int, string, error) {
-				return 1, "", errors.New("git error")
-			},
-			users: &MockUserService{
-				UserMocks: []UserMock{
-					{OutUser: &user, OutResponse: &github.Response{}},
+			semver: &MockSemverCommand{
+				RunMocks: []SemverRunMock{
+					{OutCode: command.Success},
 				},
-			},
-			repo: &MockRepoService{
-				PermissionMocks: []PermissionMock{
-					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				SemVerMocks: []SemVerMock{
+					{OutSemVer: version},
 				},
-			},
-			releases: &MockReleaseService{
-				CreateMocks: []ReleaseCreateMock{
-					{OutRelease: &draftRelease, OutResponse: &github.Response{}},
+				TagPrefixMocks: []TagPrefixMock{
+					{OutTagPrefix: ""},
 				},
-			},
-			changelog: &MockChangelogService{
-				GenerateMocks: []GenerateMock{
-					{OutContent: "changelog content"},
+				BumpMocks: []BumpMock{
+					{OutBump: semver.Patch},
 				},
 			},
-			version:          version,
-			ctx:              context.Background(),
-			defaultBranch:    "main",
-			expectedExitCode: command.GitError,
+			expectedExitCode: command.SpecError,
+			expectedVersion:  "0.1.0",
 		},
 		{
-			name:   "GitCommitFails",
-			gitAdd: successRunnerFunc,
-			gitCommit: func(context.Context, ...string) (int, string, error) {
-				return 1, "", errors.New("git error")
-			},
-			users: &MockUserService{
-				UserMocks: []UserMock{
-					{OutUser: &user, OutResponse: &github.Response{}},
+			name: "MaintenanceBranch_MinorRelease",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Release: spec.Release{
+						MaintenanceBranch: "release/*",
+					},
 				},
 			},
+			minorFlag: true,
+			gitRevBranch: func(context.Context, ...string) (int, string, error) {
+				return 0, "release/0.1", nil
+			},
+			gitStatus: successRunnerFunc,
+			gitPull:   successRunnerFunc,
 			repo: &MockRepoService{
-				PermissionMocks: []PermissionMock{
-					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				GetMocks: []GetMock{
+					{OutRepository: &repo, OutResponse: &github.Response{}},
 				},
 			},
-			releases: &MockReleaseService{
-				CreateMocks: []ReleaseCreateMock{
-					{OutRelease: &draftRelease, OutResponse: &github.Response{}},
+			semver: &MockSemverCommand{
+				RunMocks: []SemverRunMock{
+					{OutCode: command.Success},
 				},
-			},
-			changelog: &MockChangelogService{
-				GenerateMocks: []GenerateMock{
-					{OutContent: "changelog content"},
+				SemVerMocks: []SemVerMock{
+					{OutSemVer: version},
+				},
+				TagPrefixMocks: []TagPrefixMock{
+					{OutTagPrefix: ""},
 				},
 			},
-			version:          version,
-			ctx:              context.Background(),
-			defaultBranch:    "main",
-			expectedExitCode: command.GitError,
+			expectedExitCode: command.FlagError,
 		},
 		{
-			name:      "GitTagFails",
-			gitAdd:    successRunnerFunc,
-			gitCommit: successRunnerFunc,
-			gitTag: func(context.Context, ...string) (int, string, error) {
-				return 1, "", errors.New("git error")
-			},
-			users: &MockUserService{
-				UserMocks: []UserMock{
-					{OutUser: &user, OutResponse: &github.Response{}},
+			name: "MaintenanceBranch_InvalidReleaseLine",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Release: spec.Release{
+						MaintenanceBranch: "release/*",
+					},
 				},
 			},
+			patchFlag: true,
+			gitRevBranch: func(context.Context, ...string) (int, string, error) {
+				return 0, "release/1.4", nil
+			},
+			gitStatus: successRunnerFunc,
+			gitPull:   successRunnerFunc,
 			repo: &MockRepoService{
-				PermissionMocks: []PermissionMock{
-					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				GetMocks: []GetMock{
+					{OutRepository: &repo, OutResponse: &github.Response{}},
 				},
 			},
-			releases: &MockReleaseService{
-				CreateMocks: []ReleaseCreateMock{
-					{OutRelease: &draftRelease, OutResponse: &github.Response{}},
+			semver: &MockSemverCommand{
+				RunMocks: []SemverRunMock{
+					{OutCode: command.Success},
 				},
-			},
-			changelog: &MockChangelogService{
-				GenerateMocks: []GenerateMock{
-					{OutContent: "changelog content"},
+				SemVerMocks: []SemVerMock{
+					{OutSemVer: version},
+				},
+				TagPrefixMocks: []TagPrefixMock{
+					{OutTagPrefix: ""},
 				},
 			},
-			version:          version,
-			ctx:              context.Background(),
-			defaultBranch:    "main",
-			expectedExitCode: command.GitError,
+			expectedExitCode: command.GenericError,
 		},
 		{
-			name:      "BuildRunFails",
-			gitAdd:    successRunnerFunc,
-			gitCommit: successRunnerFunc,
-			gitTag:    successRunnerFunc,
-			goList:    successRunnerFunc,
-			users: &MockUserService{
-				UserMocks: []UserMock{
-					{OutUser: &user, OutResponse: &github.Response{}},
+			name: "MaintenanceBranch_TagsFails",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Release: spec.Release{
+						MaintenanceBranch: "release/*",
+					},
 				},
 			},
+			patchFlag: true,
+			gitRevBranch: func(context.Context, ...string) (int, string, error) {
+				return 0, "release/0.1", nil
+			},
+			gitStatus: successRunnerFunc,
+			gitPull:   successRunnerFunc,
 			repo: &MockRepoService{
-				PermissionMocks: []PermissionMock{
-					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				GetMocks: []GetMock{
+					{OutRepository: &repo, OutResponse: &github.Response{}},
 				},
 			},
-			releases: &MockReleaseService{
-				CreateMocks: []ReleaseCreateMock{
-					{OutRelease: &draftRelease, OutResponse: &github.Response{}},
+			git: &MockGitService{
+				TagsMocks: []GitTagsMock{
+					{OutError: errors.New("git error")},
 				},
 			},
-			changelog: &MockChangelogService{
-				GenerateMocks: []GenerateMock{
-					{OutContent: "changelog content"},
+			semver: &MockSemverCommand{
+				RunMocks: []SemverRunMock{
+					{OutCode: command.Success},
 				},
-			},
-			build: &MockBuildCommand{
-				RunMocks: []BuildRunMock{
-					{OutCode: command.GoError},
+				SemVerMocks: []SemVerMock{
+					{OutSemVer: version},
+				},
+				TagPrefixMocks: []TagPrefixMock{
+					{OutTagPrefix: ""},
 				},
 			},
-			version:          version,
-			ctx:              context.Background(),
-			defaultBranch:    "main",
-			expectedExitCode: command.GoError,
+			expectedExitCode: command.GitError,
 		},
 		{
-			name:      "UploadReleaseAssetFails",
-			gitAdd:    successRunnerFunc,
-			gitCommit: successRunnerFunc,
-			gitTag:    successRunnerFunc,
-			goList:    successRunnerFunc,
-			users: &MockUserService{
-				UserMocks: []UserMock{
-					{OutUser: &user, OutResponse: &github.Response{}},
+			name: "MaintenanceBranch_TagExists",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Release: spec.Release{
+						MaintenanceBranch: "release/*",
+					},
 				},
 			},
-			repo: &MockRepoService{
-				PermissionMocks: []PermissionMock{
-					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
-				},
+			patchFlag: true,
+			gitRevBranch: func(context.Context, ...string) (int, string, error) {
+				return 0, "release/0.1", nil
 			},
-			releases: &MockReleaseService{
-				CreateMocks: []ReleaseCreateMock{
-					{OutRelease: &draftRelease, OutResponse: &github.Response{}},
-				},
-				UploadAssetMocks: []ReleaseUploadAssetMock{
-					{OutError: errors.New("github error")},
+			gitStatus: successRunnerFunc,
+			gitPull:   successRunnerFunc,
+			repo: &MockRepoService{
+				GetMocks: []GetMock{
+					{OutRepository: &repo, OutResponse: &github.Response{}},
 				},
 			},
-			changelog: &MockChangelogService{
-				GenerateMocks: []GenerateMock{
-					{OutContent: "changelog content"},
+			git: &MockGitService{
+				TagsMocks: []GitTagsMock{
+					{OutTags: git.Tags{{Name: "v0.1.0"}}},
 				},
 			},
-			build: &MockBuildCommand{
-				RunMocks: []BuildRunMock{
+			semver: &MockSemverCommand{
+				RunMocks: []SemverRunMock{
 					{OutCode: command.Success},
 				},
-				ArtifactsMocks: []ArtifactsMock{
-					{OutArtifacts: artifacts},
+				SemVerMocks: []SemVerMock{
+					{OutSemVer: version},
+				},
+				TagPrefixMocks: []TagPrefixMock{
+					{OutTagPrefix: ""},
 				},
 			},
-			version:          version,
-			ctx:              context.Background(),
-			defaultBranch:    "main",
-			expectedExitCode: command.GitHubError,
+			expectedExitCode: command.GitError,
 		},
 		{
-			name:      "DisableBranchProtectionFails",
-			gitAdd:    successRunnerFunc,
-			gitCommit: successRunnerFunc,
-			gitTag:    successRunnerFunc,
-			goList:    successRunnerFunc,
-			users: &MockUserService{
-				UserMocks: []UserMock{
-					{OutUser: &user, OutResponse: &github.Response{}},
+			name: "MaintenanceBranch_Success",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Release: spec.Release{
+						MaintenanceBranch: "release/*",
+					},
 				},
 			},
+			patchFlag: true,
+			gitRevBranch: func(context.Context, ...string) (int, string, error) {
+				return 0, "release/0.1", nil
+			},
+			gitStatus: successRunnerFunc,
+			gitPull:   successRunnerFunc,
 			repo: &MockRepoService{
-				PermissionMocks: []PermissionMock{
-					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				GetMocks: []GetMock{
+					{OutRepository: &repo, OutResponse: &github.Response{}},
 				},
-				BranchProtectionMocks: []BranchProtectionMock{
-					{OutError: errors.New("github error")},
+			},
+			git: &MockGitService{
+				TagsMocks: []GitTagsMock{
+					{OutTags: git.Tags{{Name: "v0.0.9"}}},
 				},
 			},
-			releases: &MockReleaseService{
-				CreateMocks: []ReleaseCreateMock{
-					{OutRelease: &draftRelease, OutResponse: &github.Response{}},
+			semver: &MockSemverCommand{
+				RunMocks: []SemverRunMock{
+					{OutCode: command.Success},
 				},
-				UploadAssetMocks: []ReleaseUploadAssetMock{
-					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
+				SemVerMocks: []SemVerMock{
+					{OutSemVer: version},
 				},
-			},
-			changelog: &MockChangelogService{
-				GenerateMocks: []GenerateMock{
-					{OutContent: "changelog content"},
+				TagPrefixMocks: []TagPrefixMock{
+					{OutTagPrefix: ""},
 				},
 			},
-			build: &MockBuildCommand{
-				RunMocks: []BuildRunMock{
-					{OutCode: command.Success},
+			expectedExitCode: command.SpecError,
+			expectedVersion:  "0.1.0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Command{
+				ui:   ui.NewNop(),
+				spec: tc.spec,
+			}
+
+			c.flags.patch = tc.patchFlag
+			c.flags.minor = tc.minorFlag
+			c.flags.major = tc.majorFlag
+			c.flags.auto = tc.autoFlag
+			c.flags.pre = tc.preFlag
+			c.flags.resume = tc.resumeFlag
+			c.flags.abort = tc.abortFlag
+			c.flags.skipGates = tc.skipGatesFlag
+
+			c.data.owner = "octocat"
+			c.data.repo = "Hello-World"
+
+			c.funcs.gitRevBranch = tc.gitRevBranch
+			c.funcs.gitStatus = tc.gitStatus
+			c.funcs.gitPull = tc.gitPull
+			c.services.repo = tc.repo
+			c.services.users = tc.users
+			c.services.search = tc.search
+			c.services.git = tc.git
+			c.commands.semver = tc.semver
+			c.commands.gate = tc.gate
+
+			c.services.journal = tc.journal
+			if tc.journal == nil {
+				c.services.journal = &MockJournalService{
+					LoadMocks: []JournalLoadMock{{}},
+				}
+			}
+
+			exitCode := c.exec()
+
+			assert.Equal(t, tc.expectedExitCode, exitCode)
+			if tc.expectedVersion != "" {
+				assert.Equal(t, tc.expectedVersion, c.outputs.version.String())
+			}
+		})
+	}
+}
+
+func TestCommand_directRelease(t *testing.T) {
+	tests := []struct {
+		name               string
+		spec               spec.Spec
+		commentFlag        string
+		gitAdd             shell.RunnerFunc
+		gitCommit          shell.RunnerFunc
+		gitTag             shell.RunnerFunc
+		goList             shell.RunnerFunc
+		gitPush            shell.RunnerFunc
+		gitPushTag         shell.RunnerFunc
+		hook               shell.RunnerWithFunc
+		users              *MockUserService
+		repo               *MockRepoService
+		releases           *MockReleaseService
+		changelog          *MockChangelogService
+		build              *MockBuildCommand
+		notify             *MockNotifyService
+		gitDir             string
+		journal            *releaseJournal
+		version            semver.SemVer
+		ctx                context.Context
+		defaultBranch      string
+		expectedExitCode   int
+		expectedNotifyCall *notify.Release
+	}{
+		{
+			name: "UsersUserFails",
+			users: &MockUserService{
+				UserMocks: []UserMock{
+					{OutError: errors.New("github error")},
+				},
+			},
+			version:          version,
+			ctx:              context.Background(),
+			defaultBranch:    "main",
+			expectedExitCode: command.GitHubError,
+		},
+		{
+			name: "RepoPermissionFails",
+			users: &MockUserService{
+				UserMocks: []UserMock{
+					{OutUser: &user, OutResponse: &github.Response{}},
 				},
-				ArtifactsMocks: []ArtifactsMock{
-					{OutArtifacts: artifacts},
+			},
+			repo: &MockRepoService{
+				PermissionMocks: []PermissionMock{
+					{OutError: errors.New("github error")},
 				},
 			},
 			version:          version,
@@ -809,14 +1049,24 @@ func TestCommand_directRelease(t *testing.T) {
 			expectedExitCode: command.GitHubError,
 		},
 		{
-			name:      "GitPushFails",
-			gitAdd:    successRunnerFunc,
-			gitCommit: successRunnerFunc,
-			gitTag:    successRunnerFunc,
-			goList:    successRunnerFunc,
-			gitPush: func(context.Context, ...string) (int, string, error) {
-				return 1, "", errors.New("git error")
+			name: "InvalidUserPermission",
+			users: &MockUserService{
+				UserMocks: []UserMock{
+					{OutUser: &user, OutResponse: &github.Response{}},
+				},
+			},
+			repo: &MockRepoService{
+				PermissionMocks: []PermissionMock{
+					{OutPermission: github.PermissionWrite, OutResponse: &github.Response{}},
+				},
 			},
+			version:          version,
+			ctx:              context.Background(),
+			defaultBranch:    "main",
+			expectedExitCode: command.GitHubError,
+		},
+		{
+			name: "CreateReleaseFails",
 			users: &MockUserService{
 				UserMocks: []UserMock{
 					{OutUser: &user, OutResponse: &github.Response{}},
@@ -826,16 +1076,62 @@ func TestCommand_directRelease(t *testing.T) {
 				PermissionMocks: []PermissionMock{
 					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
 				},
-				BranchProtectionMocks: []BranchProtectionMock{
-					{OutResponse: &github.Response{}},
+			},
+			releases: &MockReleaseService{
+				CreateMocks: []ReleaseCreateMock{
+					{OutError: errors.New("github error")},
+				},
+			},
+			version:          version,
+			ctx:              context.Background(),
+			defaultBranch:    "main",
+			expectedExitCode: command.GitHubError,
+		},
+		{
+			name: "ChangelogGenerateFails",
+			users: &MockUserService{
+				UserMocks: []UserMock{
+					{OutUser: &user, OutResponse: &github.Response{}},
+				},
+			},
+			repo: &MockRepoService{
+				PermissionMocks: []PermissionMock{
+					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
 				},
 			},
 			releases: &MockReleaseService{
 				CreateMocks: []ReleaseCreateMock{
 					{OutRelease: &draftRelease, OutResponse: &github.Response{}},
 				},
-				UploadAssetMocks: []ReleaseUploadAssetMock{
-					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
+			},
+			changelog: &MockChangelogService{
+				GenerateMocks: []GenerateMock{
+					{OutError: errors.New("changelog error")},
+				},
+			},
+			version:          version,
+			ctx:              context.Background(),
+			defaultBranch:    "main",
+			expectedExitCode: command.ChangelogError,
+		},
+		{
+			name: "GitAddFails",
+			gitAdd: func(context.Context, ...string) (int, string, error) {
+				return 1, "", errors.New("git error")
+			},
+			users: &MockUserService{
+				UserMocks: []UserMock{
+					{OutUser: &user, OutResponse: &github.Response{}},
+				},
+			},
+			repo: &MockRepoService{
+				PermissionMocks: []PermissionMock{
+					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				CreateMocks: []ReleaseCreateMock{
+					{OutRelease: &draftRelease, OutResponse: &github.Response{}},
 				},
 			},
 			changelog: &MockChangelogService{
@@ -843,12 +1139,35 @@ func TestCommand_directRelease(t *testing.T) {
 					{OutContent: "changelog content"},
 				},
 			},
-			build: &MockBuildCommand{
-				RunMocks: []BuildRunMock{
-					{OutCode: command.Success},
+			version:          version,
+			ctx:              context.Background(),
+			defaultBranch:    "main",
+			expectedExitCode: command.GitError,
+		},
+		{
+			name:   "GitCommitFails",
+			gitAdd: successRunnerFunc,
+			gitCommit: func(context.Context, ...string) (int, string, error) {
+				return 1, "", errors.New("git error")
+			},
+			users: &MockUserService{
+				UserMocks: []UserMock{
+					{OutUser: &user, OutResponse: &github.Response{}},
+				},
+			},
+			repo: &MockRepoService{
+				PermissionMocks: []PermissionMock{
+					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				CreateMocks: []ReleaseCreateMock{
+					{OutRelease: &draftRelease, OutResponse: &github.Response{}},
 				},
-				ArtifactsMocks: []ArtifactsMock{
-					{OutArtifacts: artifacts},
+			},
+			changelog: &MockChangelogService{
+				GenerateMocks: []GenerateMock{
+					{OutContent: "changelog content"},
 				},
 			},
 			version:          version,
@@ -857,13 +1176,10 @@ func TestCommand_directRelease(t *testing.T) {
 			expectedExitCode: command.GitError,
 		},
 		{
-			name:      "GitPushTagFails",
+			name:      "GitTagFails",
 			gitAdd:    successRunnerFunc,
 			gitCommit: successRunnerFunc,
-			gitTag:    successRunnerFunc,
-			goList:    successRunnerFunc,
-			gitPush:   successRunnerFunc,
-			gitPushTag: func(context.Context, ...string) (int, string, error) {
+			gitTag: func(context.Context, ...string) (int, string, error) {
 				return 1, "", errors.New("git error")
 			},
 			users: &MockUserService{
@@ -875,44 +1191,28 @@ func TestCommand_directRelease(t *testing.T) {
 				PermissionMocks: []PermissionMock{
 					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
 				},
-				BranchProtectionMocks: []BranchProtectionMock{
-					{OutResponse: &github.Response{}},
-				},
 			},
 			releases: &MockReleaseService{
 				CreateMocks: []ReleaseCreateMock{
 					{OutRelease: &draftRelease, OutResponse: &github.Response{}},
 				},
-				UploadAssetMocks: []ReleaseUploadAssetMock{
-					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
-				},
 			},
 			changelog: &MockChangelogService{
 				GenerateMocks: []GenerateMock{
 					{OutContent: "changelog content"},
 				},
 			},
-			build: &MockBuildCommand{
-				RunMocks: []BuildRunMock{
-					{OutCode: command.Success},
-				},
-				ArtifactsMocks: []ArtifactsMock{
-					{OutArtifacts: artifacts},
-				},
-			},
 			version:          version,
 			ctx:              context.Background(),
 			defaultBranch:    "main",
 			expectedExitCode: command.GitError,
 		},
 		{
-			name:       "UpdateReleaseFails",
-			gitAdd:     successRunnerFunc,
-			gitCommit:  successRunnerFunc,
-			gitTag:     successRunnerFunc,
-			goList:     successRunnerFunc,
-			gitPush:    successRunnerFunc,
-			gitPushTag: successRunnerFunc,
+			name:      "BuildRunFails",
+			gitAdd:    successRunnerFunc,
+			gitCommit: successRunnerFunc,
+			gitTag:    successRunnerFunc,
+			goList:    successRunnerFunc,
 			users: &MockUserService{
 				UserMocks: []UserMock{
 					{OutUser: &user, OutResponse: &github.Response{}},
@@ -922,20 +1222,11 @@ func TestCommand_directRelease(t *testing.T) {
 				PermissionMocks: []PermissionMock{
 					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
 				},
-				BranchProtectionMocks: []BranchProtectionMock{
-					{OutResponse: &github.Response{}},
-				},
 			},
 			releases: &MockReleaseService{
 				CreateMocks: []ReleaseCreateMock{
 					{OutRelease: &draftRelease, OutResponse: &github.Response{}},
 				},
-				UploadAssetMocks: []ReleaseUploadAssetMock{
-					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
-				},
-				UpdateMocks: []ReleaseUpdateMock{
-					{OutError: errors.New("github error")},
-				},
 			},
 			changelog: &MockChangelogService{
 				GenerateMocks: []GenerateMock{
@@ -944,25 +1235,20 @@ func TestCommand_directRelease(t *testing.T) {
 			},
 			build: &MockBuildCommand{
 				RunMocks: []BuildRunMock{
-					{OutCode: command.Success},
-				},
-				ArtifactsMocks: []ArtifactsMock{
-					{OutArtifacts: artifacts},
+					{OutCode: command.GoError},
 				},
 			},
 			version:          version,
 			ctx:              context.Background(),
 			defaultBranch:    "main",
-			expectedExitCode: command.GitHubError,
+			expectedExitCode: command.GoError,
 		},
 		{
-			name:       "EnableBranchProtectionFails",
-			gitAdd:     successRunnerFunc,
-			gitCommit:  successRunnerFunc,
-			gitTag:     successRunnerFunc,
-			goList:     successRunnerFunc,
-			gitPush:    successRunnerFunc,
-			gitPushTag: successRunnerFunc,
+			name:      "UploadReleaseAssetFails",
+			gitAdd:    successRunnerFunc,
+			gitCommit: successRunnerFunc,
+			gitTag:    successRunnerFunc,
+			goList:    successRunnerFunc,
 			users: &MockUserService{
 				UserMocks: []UserMock{
 					{OutUser: &user, OutResponse: &github.Response{}},
@@ -972,20 +1258,13 @@ func TestCommand_directRelease(t *testing.T) {
 				PermissionMocks: []PermissionMock{
 					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
 				},
-				BranchProtectionMocks: []BranchProtectionMock{
-					{OutResponse: &github.Response{}},
-					{OutError: errors.New("github error")},
-				},
 			},
 			releases: &MockReleaseService{
 				CreateMocks: []ReleaseCreateMock{
 					{OutRelease: &draftRelease, OutResponse: &github.Response{}},
 				},
 				UploadAssetMocks: []ReleaseUploadAssetMock{
-					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
-				},
-				UpdateMocks: []ReleaseUpdateMock{
-					{OutRelease: &release, OutResponse: &github.Response{}},
+					{OutError: errors.New("github error")},
 				},
 			},
 			changelog: &MockChangelogService{
@@ -997,8 +1276,8 @@ func TestCommand_directRelease(t *testing.T) {
 				RunMocks: []BuildRunMock{
 					{OutCode: command.Success},
 				},
-				ArtifactsMocks: []ArtifactsMock{
-					{OutArtifacts: artifacts},
+				ManifestMocks: []ManifestMock{
+					{OutManifest: manifest},
 				},
 			},
 			version:          version,
@@ -1007,14 +1286,11 @@ func TestCommand_directRelease(t *testing.T) {
 			expectedExitCode: command.GitHubError,
 		},
 		{
-			name:        "Success",
-			commentFlag: "description",
-			gitAdd:      successRunnerFunc,
-			gitCommit:   successRunnerFunc,
-			gitTag:      successRunnerFunc,
-			goList:      successRunnerFunc,
-			gitPush:     successRunnerFunc,
-			gitPushTag:  successRunnerFunc,
+			name:      "DisableBranchProtectionFails",
+			gitAdd:    successRunnerFunc,
+			gitCommit: successRunnerFunc,
+			gitTag:    successRunnerFunc,
+			goList:    successRunnerFunc,
 			users: &MockUserService{
 				UserMocks: []UserMock{
 					{OutUser: &user, OutResponse: &github.Response{}},
@@ -1025,8 +1301,7 @@ func TestCommand_directRelease(t *testing.T) {
 					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
 				},
 				BranchProtectionMocks: []BranchProtectionMock{
-					{OutResponse: &github.Response{}},
-					{OutResponse: &github.Response{}},
+					{OutError: errors.New("github error")},
 				},
 			},
 			releases: &MockReleaseService{
@@ -1036,9 +1311,6 @@ func TestCommand_directRelease(t *testing.T) {
 				UploadAssetMocks: []ReleaseUploadAssetMock{
 					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
 				},
-				UpdateMocks: []ReleaseUpdateMock{
-					{OutRelease: &release, OutResponse: &github.Response{}},
-				},
 			},
 			changelog: &MockChangelogService{
 				GenerateMocks: []GenerateMock{
@@ -1049,50 +1321,923 @@ func TestCommand_directRelease(t *testing.T) {
 				RunMocks: []BuildRunMock{
 					{OutCode: command.Success},
 				},
-				ArtifactsMocks: []ArtifactsMock{
-					{OutArtifacts: artifacts},
+				ManifestMocks: []ManifestMock{
+					{OutManifest: manifest},
+				},
+			},
+			version:          version,
+			ctx:              context.Background(),
+			defaultBranch:    "main",
+			expectedExitCode: command.GitHubError,
+		},
+		{
+			name:      "GitPushFails",
+			gitAdd:    successRunnerFunc,
+			gitCommit: successRunnerFunc,
+			gitTag:    successRunnerFunc,
+			goList:    successRunnerFunc,
+			gitPush: func(context.Context, ...string) (int, string, error) {
+				return 1, "", errors.New("git error")
+			},
+			users: &MockUserService{
+				UserMocks: []UserMock{
+					{OutUser: &user, OutResponse: &github.Response{}},
+				},
+			},
+			repo: &MockRepoService{
+				PermissionMocks: []PermissionMock{
+					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				},
+				BranchProtectionMocks: []BranchProtectionMock{
+					{OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				CreateMocks: []ReleaseCreateMock{
+					{OutRelease: &draftRelease, OutResponse: &github.Response{}},
+				},
+				UploadAssetMocks: []ReleaseUploadAssetMock{
+					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
+				},
+			},
+			changelog: &MockChangelogService{
+				GenerateMocks: []GenerateMock{
+					{OutContent: "changelog content"},
+				},
+			},
+			build: &MockBuildCommand{
+				RunMocks: []BuildRunMock{
+					{OutCode: command.Success},
+				},
+				ManifestMocks: []ManifestMock{
+					{OutManifest: manifest},
+				},
+			},
+			version:          version,
+			ctx:              context.Background(),
+			defaultBranch:    "main",
+			expectedExitCode: command.GitError,
+		},
+		{
+			name:      "GitPushTagFails",
+			gitAdd:    successRunnerFunc,
+			gitCommit: successRunnerFunc,
+			gitTag:    successRunnerFunc,
+			goList:    successRunnerFunc,
+			gitPush:   successRunnerFunc,
+			gitPushTag: func(context.Context, ...string) (int, string, error) {
+				return 1, "", errors.New("git error")
+			},
+			users: &MockUserService{
+				UserMocks: []UserMock{
+					{OutUser: &user, OutResponse: &github.Response{}},
+				},
+			},
+			repo: &MockRepoService{
+				PermissionMocks: []PermissionMock{
+					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				},
+				BranchProtectionMocks: []BranchProtectionMock{
+					{OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				CreateMocks: []ReleaseCreateMock{
+					{OutRelease: &draftRelease, OutResponse: &github.Response{}},
+				},
+				UploadAssetMocks: []ReleaseUploadAssetMock{
+					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
+				},
+			},
+			changelog: &MockChangelogService{
+				GenerateMocks: []GenerateMock{
+					{OutContent: "changelog content"},
+				},
+			},
+			build: &MockBuildCommand{
+				RunMocks: []BuildRunMock{
+					{OutCode: command.Success},
+				},
+				ManifestMocks: []ManifestMock{
+					{OutManifest: manifest},
+				},
+			},
+			version:          version,
+			ctx:              context.Background(),
+			defaultBranch:    "main",
+			expectedExitCode: command.GitError,
+		},
+		{
+			name: "AfterTagHookFails",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Release: spec.Release{
+						Hooks: spec.Hooks{
+							AfterTag: []string{"./scripts/after-tag.sh"},
+						},
+					},
+				},
+			},
+			gitAdd:     successRunnerFunc,
+			gitCommit:  successRunnerFunc,
+			gitTag:     successRunnerFunc,
+			goList:     successRunnerFunc,
+			gitPush:    successRunnerFunc,
+			gitPushTag: successRunnerFunc,
+			hook: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+				return 1, "", errors.New("hook error")
+			},
+			users: &MockUserService{
+				UserMocks: []UserMock{
+					{OutUser: &user, OutResponse: &github.Response{}},
+				},
+			},
+			repo: &MockRepoService{
+				PermissionMocks: []PermissionMock{
+					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				},
+				BranchProtectionMocks: []BranchProtectionMock{
+					{OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				CreateMocks: []ReleaseCreateMock{
+					{OutRelease: &draftRelease, OutResponse: &github.Response{}},
+				},
+				UploadAssetMocks: []ReleaseUploadAssetMock{
+					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
+				},
+			},
+			changelog: &MockChangelogService{
+				GenerateMocks: []GenerateMock{
+					{OutContent: "changelog content"},
+				},
+			},
+			build: &MockBuildCommand{
+				RunMocks: []BuildRunMock{
+					{OutCode: command.Success},
+				},
+				ManifestMocks: []ManifestMock{
+					{OutManifest: manifest},
+				},
+			},
+			version:          version,
+			ctx:              context.Background(),
+			defaultBranch:    "main",
+			expectedExitCode: command.HookError,
+		},
+		{
+			name:       "UpdateReleaseFails",
+			gitAdd:     successRunnerFunc,
+			gitCommit:  successRunnerFunc,
+			gitTag:     successRunnerFunc,
+			goList:     successRunnerFunc,
+			gitPush:    successRunnerFunc,
+			gitPushTag: successRunnerFunc,
+			users: &MockUserService{
+				UserMocks: []UserMock{
+					{OutUser: &user, OutResponse: &github.Response{}},
+				},
+			},
+			repo: &MockRepoService{
+				PermissionMocks: []PermissionMock{
+					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				},
+				BranchProtectionMocks: []BranchProtectionMock{
+					{OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				CreateMocks: []ReleaseCreateMock{
+					{OutRelease: &draftRelease, OutResponse: &github.Response{}},
+				},
+				UploadAssetMocks: []ReleaseUploadAssetMock{
+					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
+				},
+				UpdateMocks: []ReleaseUpdateMock{
+					{OutError: errors.New("github error")},
+				},
+			},
+			changelog: &MockChangelogService{
+				GenerateMocks: []GenerateMock{
+					{OutContent: "changelog content"},
+				},
+			},
+			build: &MockBuildCommand{
+				RunMocks: []BuildRunMock{
+					{OutCode: command.Success},
+				},
+				ManifestMocks: []ManifestMock{
+					{OutManifest: manifest},
+				},
+			},
+			version:          version,
+			ctx:              context.Background(),
+			defaultBranch:    "main",
+			expectedExitCode: command.GitHubError,
+		},
+		{
+			name:       "EnableBranchProtectionFails",
+			gitAdd:     successRunnerFunc,
+			gitCommit:  successRunnerFunc,
+			gitTag:     successRunnerFunc,
+			goList:     successRunnerFunc,
+			gitPush:    successRunnerFunc,
+			gitPushTag: successRunnerFunc,
+			users: &MockUserService{
+				UserMocks: []UserMock{
+					{OutUser: &user, OutResponse: &github.Response{}},
+				},
+			},
+			repo: &MockRepoService{
+				PermissionMocks: []PermissionMock{
+					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				},
+				BranchProtectionMocks: []BranchProtectionMock{
+					{OutResponse: &github.Response{}},
+					{OutError: errors.New("github error")},
+				},
+			},
+			releases: &MockReleaseService{
+				CreateMocks: []ReleaseCreateMock{
+					{OutRelease: &draftRelease, OutResponse: &github.Response{}},
+				},
+				UploadAssetMocks: []ReleaseUploadAssetMock{
+					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
+				},
+				UpdateMocks: []ReleaseUpdateMock{
+					{OutRelease: &release, OutResponse: &github.Response{}},
+				},
+			},
+			changelog: &MockChangelogService{
+				GenerateMocks: []GenerateMock{
+					{OutContent: "changelog content"},
+				},
+			},
+			build: &MockBuildCommand{
+				RunMocks: []BuildRunMock{
+					{OutCode: command.Success},
+				},
+				ManifestMocks: []ManifestMock{
+					{OutManifest: manifest},
+				},
+			},
+			version:          version,
+			ctx:              context.Background(),
+			defaultBranch:    "main",
+			expectedExitCode: command.GitHubError,
+		},
+		{
+			name:        "Success",
+			commentFlag: "description",
+			gitAdd:      successRunnerFunc,
+			gitCommit:   successRunnerFunc,
+			gitTag:      successRunnerFunc,
+			goList:      successRunnerFunc,
+			gitPush:     successRunnerFunc,
+			gitPushTag:  successRunnerFunc,
+			users: &MockUserService{
+				UserMocks: []UserMock{
+					{OutUser: &user, OutResponse: &github.Response{}},
+				},
+			},
+			repo: &MockRepoService{
+				PermissionMocks: []PermissionMock{
+					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				},
+				BranchProtectionMocks: []BranchProtectionMock{
+					{OutResponse: &github.Response{}},
+					{OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				CreateMocks: []ReleaseCreateMock{
+					{OutRelease: &draftRelease, OutResponse: &github.Response{}},
+				},
+				UploadAssetMocks: []ReleaseUploadAssetMock{
+					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
+				},
+				UpdateMocks: []ReleaseUpdateMock{
+					{OutRelease: &release, OutResponse: &github.Response{}},
+				},
+			},
+			changelog: &MockChangelogService{
+				GenerateMocks: []GenerateMock{
+					{OutContent: "changelog content"},
+				},
+			},
+			build: &MockBuildCommand{
+				RunMocks: []BuildRunMock{
+					{OutCode: command.Success},
+				},
+				ManifestMocks: []ManifestMock{
+					{OutManifest: manifest},
+				},
+			},
+			version:       version,
+			ctx:           context.Background(),
+			defaultBranch: "main",
+			notify: &MockNotifyService{
+				EndpointsMocks: []EndpointsMock{{}},
+			},
+			expectedExitCode: command.Success,
+		},
+		{
+			name:        "PreRelease",
+			commentFlag: "description",
+			gitAdd:      successRunnerFunc,
+			gitCommit:   successRunnerFunc,
+			gitTag:      successRunnerFunc,
+			goList:      successRunnerFunc,
+			gitPush:     successRunnerFunc,
+			gitPushTag:  successRunnerFunc,
+			users: &MockUserService{
+				UserMocks: []UserMock{
+					{OutUser: &user, OutResponse: &github.Response{}},
+				},
+			},
+			repo: &MockRepoService{
+				PermissionMocks: []PermissionMock{
+					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				},
+				BranchProtectionMocks: []BranchProtectionMock{
+					{OutResponse: &github.Response{}},
+					{OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				CreateMocks: []ReleaseCreateMock{
+					{OutRelease: &draftRelease, OutResponse: &github.Response{}},
+				},
+				UploadAssetMocks: []ReleaseUploadAssetMock{
+					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
+				},
+				UpdateMocks: []ReleaseUpdateMock{
+					{OutRelease: &release, OutResponse: &github.Response{}},
+				},
+			},
+			changelog: &MockChangelogService{
+				GenerateMocks: []GenerateMock{
+					{OutContent: "changelog content"},
+				},
+			},
+			build: &MockBuildCommand{
+				RunMocks: []BuildRunMock{
+					{OutCode: command.Success},
+				},
+				ManifestMocks: []ManifestMock{
+					{OutManifest: manifest},
+				},
+			},
+			version:       semver.SemVer{Major: 0, Minor: 2, Patch: 0, Prerelease: []string{"rc", "1"}},
+			ctx:           context.Background(),
+			defaultBranch: "main",
+			notify: &MockNotifyService{
+				EndpointsMocks: []EndpointsMock{{}},
+			},
+			expectedExitCode: command.Success,
+		},
+		{
+			name: "JournalSaveFails",
+			users: &MockUserService{
+				UserMocks: []UserMock{
+					{OutUser: &user, OutResponse: &github.Response{}},
+				},
+			},
+			repo: &MockRepoService{
+				PermissionMocks: []PermissionMock{
+					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				CreateMocks: []ReleaseCreateMock{
+					{OutRelease: &draftRelease, OutResponse: &github.Response{}},
+				},
+			},
+			gitDir:           "/dev/null",
+			version:          version,
+			ctx:              context.Background(),
+			defaultBranch:    "main",
+			expectedExitCode: command.OSError,
+		},
+		{
+			name: "ResumeAfterTagPushed",
+			users: &MockUserService{
+				UserMocks: []UserMock{
+					{OutUser: &user, OutResponse: &github.Response{}},
+				},
+			},
+			repo: &MockRepoService{
+				PermissionMocks: []PermissionMock{
+					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				},
+				BranchProtectionMocks: []BranchProtectionMock{
+					{OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				UpdateMocks: []ReleaseUpdateMock{
+					{OutRelease: &release, OutResponse: &github.Response{}},
+				},
+			},
+			journal: &releaseJournal{
+				Mode:       spec.ReleaseModeDirect,
+				Version:    "0.1.0",
+				Branch:     "main",
+				BaseCommit: "25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378",
+				ReleaseID:  1,
+				Changelog:  "changelog content",
+				Steps: []releaseStep{
+					stepDraftCreated, stepChangelogGenerated, stepCommitCreated, stepTagCreated,
+					stepAssetsUploaded, stepProtectionDisabled, stepCommitPushed, stepTagPushed,
+				},
+			},
+			version:       version,
+			ctx:           context.Background(),
+			defaultBranch: "main",
+			notify: &MockNotifyService{
+				EndpointsMocks: []EndpointsMock{{}},
+			},
+			expectedExitCode: command.Success,
+		},
+		{
+			name: "ResumeAfterReleasePublished_PushImagesFails",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Build: spec.Build{
+						Image: spec.Image{Enabled: true, Push: true},
+					},
+				},
+			},
+			users: &MockUserService{
+				UserMocks: []UserMock{
+					{OutUser: &user, OutResponse: &github.Response{}},
+				},
+			},
+			repo: &MockRepoService{
+				PermissionMocks: []PermissionMock{
+					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				},
+			},
+			goList: func(context.Context, ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			build: &MockBuildCommand{
+				RunMocks: []BuildRunMock{
+					{OutCode: command.Success},
+				},
+				PushImagesMocks: []PushImagesMock{
+					{OutError: errors.New("registry error")},
+				},
+			},
+			journal: &releaseJournal{
+				Mode:       spec.ReleaseModeDirect,
+				Version:    "0.1.0",
+				Branch:     "main",
+				BaseCommit: "25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378",
+				ReleaseID:  1,
+				Changelog:  "changelog content",
+				Steps: []releaseStep{
+					stepDraftCreated, stepChangelogGenerated, stepCommitCreated, stepTagCreated,
+					stepAssetsUploaded, stepProtectionDisabled, stepCommitPushed, stepTagPushed,
+					stepReleasePublished, stepProtectionEnabled,
+				},
+			},
+			version:          version,
+			ctx:              context.Background(),
+			defaultBranch:    "main",
+			expectedExitCode: command.ImageError,
+		},
+		{
+			name: "ResumeAfterReleasePublished_PushImages",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Build: spec.Build{
+						Image: spec.Image{Enabled: true, Push: true},
+					},
+				},
+			},
+			users: &MockUserService{
+				UserMocks: []UserMock{
+					{OutUser: &user, OutResponse: &github.Response{}},
+				},
+			},
+			repo: &MockRepoService{
+				PermissionMocks: []PermissionMock{
+					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				},
+			},
+			goList: func(context.Context, ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			build: &MockBuildCommand{
+				RunMocks: []BuildRunMock{
+					{OutCode: command.Success},
+				},
+				PushImagesMocks: []PushImagesMock{
+					{OutError: nil},
+				},
+			},
+			journal: &releaseJournal{
+				Mode:       spec.ReleaseModeDirect,
+				Version:    "0.1.0",
+				Branch:     "main",
+				BaseCommit: "25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378",
+				ReleaseID:  1,
+				Changelog:  "changelog content",
+				Steps: []releaseStep{
+					stepDraftCreated, stepChangelogGenerated, stepCommitCreated, stepTagCreated,
+					stepAssetsUploaded, stepProtectionDisabled, stepCommitPushed, stepTagPushed,
+					stepReleasePublished, stepProtectionEnabled,
+				},
+			},
+			version:       version,
+			ctx:           context.Background(),
+			defaultBranch: "main",
+			notify: &MockNotifyService{
+				EndpointsMocks: []EndpointsMock{{}, {}},
+			},
+			expectedExitCode: command.Success,
+		},
+		{
+			name: "ResumeAfterReleasePublished_Notify",
+			users: &MockUserService{
+				UserMocks: []UserMock{
+					{OutUser: &user, OutResponse: &github.Response{}},
+				},
+			},
+			repo: &MockRepoService{
+				PermissionMocks: []PermissionMock{
+					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				ListMocks: []ReleaseListMock{
+					{
+						OutReleases: []github.Release{
+							{
+								ID:      1,
+								Name:    "0.1.0",
+								TagName: "v0.1.0",
+								Body:    "changelog content",
+								HTMLURL: "https://github.com/octocat/Hello-World/releases/tag/v0.1.0",
+								Assets: []github.ReleaseAsset{
+									{Name: "app-linux-amd64", DownloadURL: "https://github.com/octocat/Hello-World/releases/download/v0.1.0/app-linux-amd64"},
+								},
+							},
+						},
+						OutResponse: &github.Response{},
+					},
+				},
+			},
+			journal: &releaseJournal{
+				Mode:       spec.ReleaseModeDirect,
+				Version:    "0.1.0",
+				Branch:     "main",
+				BaseCommit: "25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378",
+				ReleaseID:  1,
+				Changelog:  "changelog content",
+				Steps: []releaseStep{
+					stepDraftCreated, stepChangelogGenerated, stepCommitCreated, stepTagCreated,
+					stepAssetsUploaded, stepProtectionDisabled, stepCommitPushed, stepTagPushed,
+					stepReleasePublished, stepProtectionEnabled,
+				},
+			},
+			version:       version,
+			ctx:           context.Background(),
+			defaultBranch: "main",
+			notify: &MockNotifyService{
+				EndpointsMocks: []EndpointsMock{
+					{OutEndpoints: []string{"slack"}},
+					{OutEndpoints: []string{"slack"}},
+				},
+				NotifyMocks: []NotifyMock{{}},
+			},
+			expectedExitCode: command.Success,
+			expectedNotifyCall: &notify.Release{
+				Repo:      "octocat/Hello-World",
+				Name:      "0.1.0",
+				Version:   "0.1.0",
+				Tag:       "v0.1.0",
+				URL:       "https://github.com/octocat/Hello-World/releases/tag/v0.1.0",
+				Changelog: "changelog content",
+				Artifacts: []notify.Artifact{
+					{Name: "app-linux-amd64", URL: "https://github.com/octocat/Hello-World/releases/download/v0.1.0/app-linux-amd64"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Command{
+				ui:   ui.NewNop(),
+				spec: tc.spec,
+			}
+
+			c.flags.comment = tc.commentFlag
+
+			c.data.owner = "octocat"
+			c.data.repo = "Hello-World"
+			c.data.changelogSpec = changelogspec.Spec{
+				General: changelogspec.General{
+					File: "CHANGELOG.md",
+				},
+			}
+
+			c.funcs.gitRevParse = func(context.Context, ...string) (int, string, error) {
+				return 0, "25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378", nil
+			}
+			c.funcs.gitAdd = tc.gitAdd
+			c.funcs.gitCommit = tc.gitCommit
+			c.funcs.gitTag = tc.gitTag
+			c.funcs.goList = tc.goList
+			c.funcs.gitPush = tc.gitPush
+			c.funcs.gitPushTag = tc.gitPushTag
+			c.funcs.hook = tc.hook
+			c.services.users = tc.users
+			c.services.repo = tc.repo
+			c.services.releases = tc.releases
+			c.services.changelog = tc.changelog
+			c.services.notify = tc.notify
+			c.commands.build = tc.build
+
+			gitDir := tc.gitDir
+			if gitDir == "" {
+				gitDir = t.TempDir()
+			}
+			c.services.journal = newFileJournal(gitDir)
+
+			c.outputs.version = tc.version
+
+			journal := tc.journal
+			if journal == nil {
+				journal = &releaseJournal{Mode: spec.ReleaseModeDirect, Version: tc.version.String(), Branch: tc.defaultBranch}
+			}
+
+			exitCode := c.directRelease(tc.ctx, tc.defaultBranch, journal)
+
+			assert.Equal(t, tc.expectedExitCode, exitCode)
+
+			if tc.releases != nil && tc.releases.CreateIndex > 0 {
+				assert.Equal(t, tc.version.IsPrerelease(), tc.releases.CreateMocks[0].InParams.Prerelease)
+			}
+
+			// The quality gates are not run again for building the release artifacts
+			if tc.build != nil && tc.build.RunIndex > 0 {
+				assert.Equal(t, []string{"-skip-gates", "-push=false", "-module", "."}, tc.build.RunMocks[0].InArgs)
+			}
+
+			// The images are pushed only once the release is published
+			if tc.build != nil {
+				assert.Equal(t, len(tc.build.PushImagesMocks), tc.build.PushImagesIndex)
+			}
+
+			if tc.expectedNotifyCall != nil {
+				assert.Equal(t, *tc.expectedNotifyCall, tc.notify.NotifyMocks[0].InRelease)
+			}
+		})
+	}
+}
+
+func TestCommand_abortRelease(t *testing.T) {
+	journal := func(steps ...releaseStep) *releaseJournal {
+		return &releaseJournal{
+			Mode:       spec.ReleaseModeDirect,
+			Version:    "0.1.0",
+			Branch:     "main",
+			BaseCommit: "25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378",
+			ReleaseID:  1,
+			Steps:      steps,
+		}
+	}
+
+	tests := []struct {
+		name             string
+		journal          *releaseJournal
+		gitRevBranch     shell.RunnerFunc
+		gitTag           shell.RunnerFunc
+		gitReset         shell.RunnerFunc
+		repo             *MockRepoService
+		releases         *MockReleaseService
+		expectedExitCode int
+		expectedSteps    []releaseStep
+	}{
+		{
+			name:             "InvalidVersion",
+			journal:          &releaseJournal{Version: "invalid"},
+			expectedExitCode: command.GenericError,
+		},
+		{
+			name:    "BranchProtectionFails",
+			journal: journal(stepDraftCreated, stepProtectionDisabled),
+			repo: &MockRepoService{
+				BranchProtectionMocks: []BranchProtectionMock{
+					{OutError: errors.New("github error")},
+				},
+			},
+			expectedExitCode: command.GitHubError,
+		},
+		{
+			name:    "DeleteReleaseFails",
+			journal: journal(stepDraftCreated),
+			releases: &MockReleaseService{
+				DeleteMocks: []ReleaseDeleteMock{
+					{OutError: errors.New("github error")},
+				},
+			},
+			expectedExitCode: command.GitHubError,
+			expectedSteps:    []releaseStep{stepDraftCreated},
+		},
+		{
+			name:    "GitTagFails",
+			journal: journal(stepDraftCreated, stepChangelogGenerated, stepCommitCreated, stepTagCreated),
+			gitTag: func(context.Context, ...string) (int, string, error) {
+				return 1, "", errors.New("git error")
+			},
+			releases: &MockReleaseService{
+				DeleteMocks: []ReleaseDeleteMock{
+					{OutResponse: &github.Response{}},
+				},
+			},
+			expectedExitCode: command.GitError,
+			expectedSteps:    []releaseStep{stepChangelogGenerated, stepCommitCreated, stepTagCreated},
+		},
+		{
+			name:    "NotOnReleaseBranch",
+			journal: journal(stepDraftCreated, stepChangelogGenerated, stepCommitCreated),
+			gitRevBranch: func(context.Context, ...string) (int, string, error) {
+				return 0, "feature-branch", nil
+			},
+			releases: &MockReleaseService{
+				DeleteMocks: []ReleaseDeleteMock{
+					{OutResponse: &github.Response{}},
+				},
+			},
+			expectedExitCode: command.GitError,
+			expectedSteps:    []releaseStep{stepChangelogGenerated, stepCommitCreated},
+		},
+		{
+			name:    "GitResetFails",
+			journal: journal(stepDraftCreated, stepChangelogGenerated, stepCommitCreated),
+			gitRevBranch: func(context.Context, ...string) (int, string, error) {
+				return 0, "main", nil
+			},
+			gitReset: func(context.Context, ...string) (int, string, error) {
+				return 1, "", errors.New("git error")
+			},
+			releases: &MockReleaseService{
+				DeleteMocks: []ReleaseDeleteMock{
+					{OutResponse: &github.Response{}},
+				},
+			},
+			expectedExitCode: command.GitError,
+			expectedSteps:    []releaseStep{stepChangelogGenerated, stepCommitCreated},
+		},
+		{
+			name:    "Success",
+			journal: journal(stepDraftCreated, stepChangelogGenerated, stepCommitCreated, stepTagCreated, stepAssetsUploaded, stepProtectionDisabled),
+			gitRevBranch: func(context.Context, ...string) (int, string, error) {
+				return 0, "main", nil
+			},
+			gitTag:   successRunnerFunc,
+			gitReset: successRunnerFunc,
+			repo: &MockRepoService{
+				BranchProtectionMocks: []BranchProtectionMock{
+					{OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				DeleteMocks: []ReleaseDeleteMock{
+					{OutResponse: &github.Response{}},
+				},
+			},
+			expectedExitCode: command.Success,
+		},
+		{
+			name: "Module_DeletesPrefixedTag",
+			journal: &releaseJournal{
+				Mode:      spec.ReleaseModeDirect,
+				Version:   "0.1.0",
+				TagPrefix: "services/billing/",
+				Branch:    "main",
+				Steps:     []releaseStep{stepTagCreated},
+			},
+			gitTag: func(_ context.Context, args ...string) (int, string, error) {
+				if len(args) != 2 || args[1] != "services/billing/v0.1.0" {
+					return 1, "", errors.New("unexpected tag")
+				}
+				return 0, "", nil
+			},
+			expectedExitCode: command.Success,
+		},
+		{
+			name:             "AlreadyPushed",
+			journal:          journal(stepDraftCreated, stepChangelogGenerated, stepCommitCreated, stepTagCreated, stepAssetsUploaded, stepProtectionDisabled, stepCommitPushed, stepTagPushed, stepReleasePublished, stepProtectionEnabled),
+			expectedExitCode: command.Success,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Command{
+				ui: ui.NewNop(),
+			}
+
+			c.funcs.gitRevBranch = tc.gitRevBranch
+			c.funcs.gitTag = tc.gitTag
+			c.funcs.gitReset = tc.gitReset
+			c.services.repo = tc.repo
+			c.services.releases = tc.releases
+
+			fj := newFileJournal(t.TempDir())
+			assert.NoError(t, fj.Save(tc.journal))
+			c.services.journal = fj
+
+			exitCode := c.abortRelease(context.Background(), tc.journal)
+
+			assert.Equal(t, tc.expectedExitCode, exitCode)
+
+			j, err := fj.Load()
+			assert.NoError(t, err)
+			if tc.expectedExitCode == command.Success {
+				assert.Nil(t, j)
+			} else if tc.expectedSteps != nil {
+				assert.Equal(t, tc.expectedSteps, j.Steps)
+			}
+		})
+	}
+}
+
+func TestCommand_searchPull(t *testing.T) {
+	tests := []struct {
+		name          string
+		search        *MockSearchService
+		expectedError string
+		expectedPull  *github.Issue
+	}{
+		{
+			name: "SearchFails",
+			search: &MockSearchService{
+				SearchIssuesMocks: []SearchIssuesMock{
+					{OutError: errors.New("github error")},
+				},
+			},
+			expectedError: "github error",
+		},
+		{
+			name: "NotFound",
+			search: &MockSearchService{
+				SearchIssuesMocks: []SearchIssuesMock{
+					{OutResult: emptySearchResult, OutResponse: &github.Response{}},
+				},
+			},
+			expectedPull: nil,
+		},
+		{
+			name: "ExactTitleOnNextPage",
+			search: &MockSearchService{
+				SearchIssuesMocks: []SearchIssuesMock{
+					{
+						OutResult: &github.SearchIssuesResult{
+							Items: []github.Issue{
+								{Number: 1002, Title: "RELEASE 0.1.0-rc.1"},
+							},
+						},
+						OutResponse: &github.Response{
+							Pages: github.Pages{Next: 2, Last: 2},
+						},
+					},
+					{
+						OutResult: &github.SearchIssuesResult{
+							Items: []github.Issue{
+								{Number: 1001, Title: "RELEASE 0.1.0"},
+							},
+						},
+						OutResponse: &github.Response{
+							Pages: github.Pages{Prev: 1, Last: 2},
+						},
+					},
 				},
 			},
-			version:          version,
-			ctx:              context.Background(),
-			defaultBranch:    "main",
-			expectedExitCode: command.Success,
+			expectedPull: &github.Issue{Number: 1001, Title: "RELEASE 0.1.0"},
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			c := &Command{
-				ui: ui.NewNop(),
-			}
+			c := &Command{ui: ui.NewNop()}
+			c.data.owner, c.data.repo = "octocat", "Hello-World"
+			c.services.search = tc.search
 
-			c.flags.comment = tc.commentFlag
+			pull, err := c.searchPull(context.Background(), github.QualifierIsMerged, "RELEASE 0.1.0")
 
-			c.data.owner = "octocat"
-			c.data.repo = "Hello-World"
-			c.data.changelogSpec = changelogspec.Spec{
-				General: changelogspec.General{
-					File: "CHANGELOG.md",
-				},
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedPull, pull)
 			}
-
-			c.funcs.gitAdd = tc.gitAdd
-			c.funcs.gitCommit = tc.gitCommit
-			c.funcs.gitTag = tc.gitTag
-			c.funcs.goList = tc.goList
-			c.funcs.gitPush = tc.gitPush
-			c.funcs.gitPushTag = tc.gitPushTag
-			c.services.users = tc.users
-			c.services.repo = tc.repo
-			c.services.releases = tc.releases
-			c.services.changelog = tc.changelog
-			c.commands.build = tc.build
-
-			c.outputs.version = tc.version
-
-			exitCode := c.directRelease(tc.ctx, tc.defaultBranch)
-
-			assert.Equal(t, tc.expectedExitCode, exitCode)
 		})
 	}
 }
@@ -1115,6 +2260,7 @@ func TestCommand_indirectRelease(t *testing.T) {
 		releases         *MockReleaseService
 		changelog        *MockChangelogService
 		build            *MockBuildCommand
+		notify           *MockNotifyService
 		version          semver.SemVer
 		ctx              context.Context
 		defaultBranch    string
@@ -1308,8 +2454,8 @@ func TestCommand_indirectRelease(t *testing.T) {
 				RunMocks: []BuildRunMock{
 					{OutCode: command.Success},
 				},
-				ArtifactsMocks: []ArtifactsMock{
-					{OutArtifacts: artifacts},
+				ManifestMocks: []ManifestMock{
+					{OutManifest: manifest},
 				},
 			},
 			version:          version,
@@ -1348,8 +2494,8 @@ func TestCommand_indirectRelease(t *testing.T) {
 				RunMocks: []BuildRunMock{
 					{OutCode: command.Success},
 				},
-				ArtifactsMocks: []ArtifactsMock{
-					{OutArtifacts: artifacts},
+				ManifestMocks: []ManifestMock{
+					{OutManifest: manifest},
 				},
 			},
 			version:          version,
@@ -1388,13 +2534,16 @@ func TestCommand_indirectRelease(t *testing.T) {
 				RunMocks: []BuildRunMock{
 					{OutCode: command.Success},
 				},
-				ArtifactsMocks: []ArtifactsMock{
-					{OutArtifacts: artifacts},
+				ManifestMocks: []ManifestMock{
+					{OutManifest: manifest},
 				},
 			},
-			version:          version,
-			ctx:              context.Background(),
-			defaultBranch:    "main",
+			version:       version,
+			ctx:           context.Background(),
+			defaultBranch: "main",
+			notify: &MockNotifyService{
+				EndpointsMocks: []EndpointsMock{{}},
+			},
 			expectedExitCode: command.Success,
 		},
 		{
@@ -1990,6 +3139,7 @@ func TestCommand_indirectRelease(t *testing.T) {
 			c.services.pulls = tc.pulls
 			c.services.releases = tc.releases
 			c.services.changelog = tc.changelog
+			c.services.notify = tc.notify
 			c.commands.build = tc.build
 
 			c.outputs.version = tc.version
@@ -2001,6 +3151,428 @@ func TestCommand_indirectRelease(t *testing.T) {
 	}
 }
 
+func TestCommand_runHooks(t *testing.T) {
+	tests := []struct {
+		name             string
+		commands         []string
+		hook             shell.RunnerWithFunc
+		expectedExitCode int
+		expectedCommands []string
+	}{
+		{
+			name:             "NoCommand",
+			commands:         nil,
+			expectedExitCode: command.Success,
+		},
+		{
+			name:     "HookFails",
+			commands: []string{"go test ./...", "make docs"},
+			hook: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+				return 1, "", errors.New("exit status 1")
+			},
+			expectedExitCode: command.HookError,
+			expectedCommands: []string{"go test ./..."},
+		},
+		{
+			name:     "Success",
+			commands: []string{"go test ./...", "make docs"},
+			hook: func(context.Context, shell.RunOptions, ...string) (int, string, error) {
+				return 0, "ok", nil
+			},
+			expectedExitCode: command.Success,
+			expectedCommands: []string{"go test ./...", "make docs"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var commands []string
+			var envs []map[string]string
+
+			c := &Command{ui: ui.NewNop()}
+			c.data.tagPrefix = "services/billing/"
+			c.outputs.version = semver.SemVer{Major: 1, Minor: 2, Patch: 3}
+			c.funcs.hook = func(ctx context.Context, opts shell.RunOptions, args ...string) (int, string, error) {
+				commands = append(commands, args...)
+				envs = append(envs, opts.Environment)
+				return tc.hook(ctx, opts, args...)
+			}
+
+			exitCode := c.runHooks(context.Background(), hookBeforeCommit, tc.commands)
+
+			assert.Equal(t, tc.expectedExitCode, exitCode)
+			assert.Equal(t, tc.expectedCommands, commands)
+
+			for _, env := range envs {
+				assert.Equal(t, map[string]string{
+					"BASIL_VERSION": "1.2.3",
+					"BASIL_TAG":     "services/billing/v1.2.3",
+				}, env)
+			}
+		})
+	}
+}
+
+func TestCommand_notifyRelease(t *testing.T) {
+	published := &github.Release{
+		Name:    "0.1.0",
+		TagName: "v0.1.0",
+		Body:    "changelog content",
+		HTMLURL: "https://github.com/octocat/Hello-World/releases/tag/v0.1.0",
+		Assets: []github.ReleaseAsset{
+			{
+				Name:        "app-linux-amd64",
+				DownloadURL: "https://github.com/octocat/Hello-World/releases/download/v0.1.0/app-linux-amd64",
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		notify          *MockNotifyService
+		expectedRelease *notify.Release
+	}{
+		{
+			name: "NoEndpoint",
+			notify: &MockNotifyService{
+				EndpointsMocks: []EndpointsMock{{}},
+			},
+		},
+		{
+			name: "NotifyFails",
+			notify: &MockNotifyService{
+				EndpointsMocks: []EndpointsMock{
+					{OutEndpoints: []string{"https://hooks.slack.com", "https://chat.example.com"}},
+				},
+				NotifyMocks: []NotifyMock{
+					{OutError: errors.New("notification to https://hooks.slack.com failed: unexpected status code: 500\nnotification to https://chat.example.com failed: unexpected status code: 404")},
+				},
+			},
+			expectedRelease: &notify.Release{
+				Repo:      "octocat/Hello-World",
+				Name:      "0.1.0",
+				Version:   "0.1.0",
+				Tag:       "v0.1.0",
+				URL:       "https://github.com/octocat/Hello-World/releases/tag/v0.1.0",
+				Changelog: "changelog content",
+				Artifacts: []notify.Artifact{
+					{
+						Name: "app-linux-amd64",
+						URL:  "https://github.com/octocat/Hello-World/releases/download/v0.1.0/app-linux-amd64",
+					},
+				},
+			},
+		},
+		{
+			name: "Success",
+			notify: &MockNotifyService{
+				EndpointsMocks: []EndpointsMock{
+					{OutEndpoints: []string{"https://hooks.slack.com"}},
+				},
+				NotifyMocks: []NotifyMock{
+					{OutError: nil},
+				},
+			},
+			expectedRelease: &notify.Release{
+				Repo:      "octocat/Hello-World",
+				Name:      "0.1.0",
+				Version:   "0.1.0",
+				Tag:       "v0.1.0",
+				URL:       "https://github.com/octocat/Hello-World/releases/tag/v0.1.0",
+				Changelog: "changelog content",
+				Artifacts: []notify.Artifact{
+					{
+						Name: "app-linux-amd64",
+						URL:  "https://github.com/octocat/Hello-World/releases/download/v0.1.0/app-linux-amd64",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Command{ui: ui.NewNop()}
+			c.data.owner = "octocat"
+			c.data.repo = "Hello-World"
+			c.outputs.version = version
+			c.services.notify = tc.notify
+
+			c.notifyRelease(context.Background(), published)
+
+			if tc.expectedRelease == nil {
+				assert.Equal(t, 0, tc.notify.NotifyIndex)
+			} else {
+				assert.Equal(t, 1, tc.notify.NotifyIndex)
+				assert.Equal(t, *tc.expectedRelease, tc.notify.NotifyMocks[0].InRelease)
+			}
+		})
+	}
+}
+
+func TestCommand_gitAddRelease(t *testing.T) {
+	tests := []struct {
+		name          string
+		hooks         spec.Hooks
+		gitAdd        shell.RunnerFunc
+		gitAddAll     shell.RunnerFunc
+		expectedError string
+	}{
+		{
+			name: "GitAddFails",
+			gitAdd: func(context.Context, ...string) (int, string, error) {
+				return 1, "", errors.New("git error")
+			},
+			expectedError: "git error",
+		},
+		{
+			name:   "NoHook",
+			gitAdd: successRunnerFunc,
+			gitAddAll: func(context.Context, ...string) (int, string, error) {
+				return 1, "", errors.New("unexpected git add --all")
+			},
+		},
+		{
+			name: "GitAddAllFails",
+			hooks: spec.Hooks{
+				BeforeCommit: []string{"make docs"},
+			},
+			gitAdd: successRunnerFunc,
+			gitAddAll: func(context.Context, ...string) (int, string, error) {
+				return 1, "", errors.New("git error")
+			},
+			expectedError: "git error",
+		},
+		{
+			name: "WithHooks",
+			hooks: spec.Hooks{
+				BeforeChangelog: []string{"make docs"},
+			},
+			gitAdd:    successRunnerFunc,
+			gitAddAll: successRunnerFunc,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Command{ui: ui.NewNop()}
+			c.spec.Project.Release.Hooks = tc.hooks
+			c.funcs.gitAdd = tc.gitAdd
+			c.funcs.gitAddAll = tc.gitAddAll
+
+			err := c.gitAddRelease(context.Background())
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCommand_tagName(t *testing.T) {
+	tests := []struct {
+		name                string
+		tagPrefix           string
+		version             semver.SemVer
+		expectedTagName     string
+		expectedReleaseName string
+	}{
+		{
+			name:                "RootModule",
+			tagPrefix:           "",
+			version:             semver.SemVer{Major: 1, Minor: 2, Patch: 3},
+			expectedTagName:     "v1.2.3",
+			expectedReleaseName: "1.2.3",
+		},
+		{
+			name:                "SubModule",
+			tagPrefix:           "services/billing/",
+			version:             semver.SemVer{Major: 1, Minor: 2, Patch: 3},
+			expectedTagName:     "services/billing/v1.2.3",
+			expectedReleaseName: "services/billing/1.2.3",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := new(Command)
+			c.data.tagPrefix = tc.tagPrefix
+			c.outputs.version = tc.version
+
+			assert.Equal(t, tc.expectedTagName, c.tagName())
+			assert.Equal(t, tc.expectedReleaseName, c.releaseName())
+		})
+	}
+}
+
+func TestCommand_scopeChangelog(t *testing.T) {
+	tests := []struct {
+		name             string
+		tagPrefix        string
+		git              *MockGitService
+		expectedExitCode int
+		expectedExclude  []string
+	}{
+		{
+			name:             "RootModule",
+			tagPrefix:        "",
+			expectedExitCode: command.Success,
+		},
+		{
+			name:      "TagsFails",
+			tagPrefix: "services/billing/",
+			git: &MockGitService{
+				TagsMocks: []GitTagsMock{
+					{OutError: errors.New("git error")},
+				},
+			},
+			expectedExitCode: command.GitError,
+		},
+		{
+			name:      "SubModule",
+			tagPrefix: "services/billing/",
+			git: &MockGitService{
+				TagsMocks: []GitTagsMock{
+					{
+						OutTags: git.Tags{
+							{Name: "services/billing/v0.2.0"},
+							{Name: "services/billing/api/v0.1.0"},
+							{Name: "services/payment/v0.1.0"},
+							{Name: "v1.0.0"},
+						},
+					},
+				},
+			},
+			expectedExitCode: command.Success,
+			expectedExclude:  []string{"services/billing/api/v0.1.0", "services/payment/v0.1.0", "v1.0.0"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Command{ui: ui.NewNop()}
+			c.data.tagPrefix = tc.tagPrefix
+			c.services.git = tc.git
+
+			exitCode := c.scopeChangelog()
+
+			assert.Equal(t, tc.expectedExitCode, exitCode)
+			assert.Equal(t, tc.expectedExclude, c.data.changelogSpec.Tags.Exclude)
+		})
+	}
+}
+
+func TestCommand_releaseArtifacts(t *testing.T) {
+	tests := []struct {
+		name              string
+		sign              bool
+		signingKey        string
+		gpgSign           shell.RunnerFunc
+		build             *MockBuildCommand
+		expectedExitCode  int
+		expectedArtifacts []buildcmd.Artifact
+		expectedArgs      [][]string
+	}{
+		{
+			name: "SigningDisabled",
+			build: &MockBuildCommand{
+				ManifestMocks: []ManifestMock{
+					{OutManifest: manifest},
+				},
+			},
+			expectedExitCode:  command.Success,
+			expectedArtifacts: artifacts,
+		},
+		{
+			name: "ManifestFails",
+			build: &MockBuildCommand{
+				ManifestMocks: []ManifestMock{
+					{OutError: errors.New("unexpected end of JSON input")},
+				},
+			},
+			expectedExitCode: command.OSError,
+		},
+		{
+			name: "NoArtifact",
+			sign: true,
+			build: &MockBuildCommand{
+				ManifestMocks: []ManifestMock{
+					{OutManifest: buildcmd.Manifest{}},
+				},
+			},
+			expectedExitCode: command.Success,
+		},
+		{
+			name: "GPGSignFails",
+			sign: true,
+			gpgSign: func(context.Context, ...string) (int, string, error) {
+				return 2, "", errors.New("gpg error")
+			},
+			build: &MockBuildCommand{
+				ManifestMocks: []ManifestMock{
+					{OutManifest: manifest},
+				},
+			},
+			expectedExitCode: command.GPGError,
+			expectedArgs: [][]string{
+				{"--output", "bin/app.asc", "bin/app"},
+			},
+		},
+		{
+			name:       "Success",
+			sign:       true,
+			signingKey: "octocat@example.com",
+			gpgSign:    successRunnerFunc,
+			build: &MockBuildCommand{
+				ManifestMocks: []ManifestMock{
+					{
+						OutManifest: buildcmd.Manifest{
+							Artifacts: []buildcmd.ManifestEntry{
+								{Path: "bin/app"},
+								{Path: "bin/checksums.txt"},
+							},
+						},
+					},
+				},
+			},
+			expectedExitCode: command.Success,
+			expectedArtifacts: []buildcmd.Artifact{
+				{Path: "bin/app"},
+				{Path: "bin/checksums.txt"},
+				{Path: "bin/app.asc"},
+				{Path: "bin/checksums.txt.asc"},
+			},
+			expectedArgs: [][]string{
+				{"--local-user", "octocat@example.com", "--output", "bin/app.asc", "bin/app"},
+				{"--local-user", "octocat@example.com", "--output", "bin/checksums.txt.asc", "bin/checksums.txt"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Command{ui: ui.NewNop()}
+			c.spec.Project.Release.Sign = tc.sign
+			c.spec.Project.Release.SigningKey = tc.signingKey
+			c.commands.build = tc.build
+
+			var args [][]string
+			c.funcs.gpgSign = func(ctx context.Context, a ...string) (int, string, error) {
+				args = append(args, a)
+				return tc.gpgSign(ctx, a...)
+			}
+
+			artifacts, exitCode := c.releaseArtifacts(context.Background())
+
+			assert.Equal(t, tc.expectedExitCode, exitCode)
+			assert.Equal(t, tc.expectedArtifacts, artifacts)
+			assert.Equal(t, tc.expectedArgs, args)
+		})
+	}
+}
+
 func TestCommand_findDraftRelease(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -0,0 +1,400 @@
+package release
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/gardenbed/go-github"
+	"github.com/mitchellh/cli"
+
+	"github.com/gardenbed/basil-cli/internal/command"
+	"github.com/gardenbed/basil-cli/internal/config"
+	"github.com/gardenbed/basil-cli/internal/semver"
+	"github.com/gardenbed/basil-cli/internal/spec"
+	"github.com/gardenbed/basil-cli/internal/ui"
+)
+
+const (
+	statusSynopsis = `Show the status of a release in progress`
+	statusHelp     = `
+  Use this command for inspecting a release in progress.
+
+  For a direct release, the completed steps are read from the release journal (.git/basil/release.json).
+  For an indirect release, the release pull request and the draft release are looked up on GitHub/GitLab.
+  The pending version, the state and checks of the pull request, the assets of the draft release,
+  and the next action for completing the release are reported.
+
+  For a module in a sub-directory of a repository (monorepo), run this command from the module directory.
+
+  Usage:  basil project release status [flags]
+
+  Flags:
+    -json  print the status in JSON format
+
+  Examples:
+    basil project release status
+    basil project release status -json
+  `
+)
+
+const (
+	noReleaseAction = "No release is in progress. Run basil project release to create a new release."
+
+	pullStateOpen   = "open"
+	pullStateMerged = "merged"
+
+	checkSuccess = "success"
+	checkFailure = "failure"
+	checkPending = "pending"
+)
+
+type (
+	// pullCheck is a check (e.g. a CI job) of a pull request.
+	pullCheck struct {
+		Name  string `json:"name"`
+		State string `json:"state"`
+	}
+
+	// pullStatus is the status of a release pull request.
+	pullStatus struct {
+		Number int         `json:"number"`
+		Title  string      `json:"title"`
+		State  string      `json:"state"`
+		URL    string      `json:"url"`
+		Checks []pullCheck `json:"checks"`
+	}
+
+	// draftStatus is the status of a draft release.
+	draftStatus struct {
+		Name   string   `json:"name"`
+		Tag    string   `json:"tag"`
+		Target string   `json:"target"`
+		URL    string   `json:"url"`
+		Assets []string `json:"assets"`
+	}
+
+	// releaseStatus is the status of a release in progress.
+	releaseStatus struct {
+		Mode        spec.ReleaseMode `json:"mode,omitempty"`
+		Version     string           `json:"version,omitempty"`
+		Tag         string           `json:"tag,omitempty"`
+		Steps       []releaseStep    `json:"steps,omitempty"`
+		PullRequest *pullStatus      `json:"pullRequest,omitempty"`
+		Release     *draftStatus     `json:"draftRelease,omitempty"`
+		NextAction  string           `json:"nextAction"`
+	}
+)
+
+// toCheckState normalizes the state of a GitHub check run, a GitHub commit status, or a GitLab pipeline.
+func toCheckState(state string) string {
+	switch state {
+	case "success", "neutral", "skipped":
+		return checkSuccess
+	case "failure", "failed", "error", "cancelled", "canceled", "timed_out", "action_required", "startup_failure", "stale":
+		return checkFailure
+	default:
+		return checkPending
+	}
+}
+
+// StatusCommand is the cli.Command implementation for release status command.
+// It shares the dependencies of the release command.
+type StatusCommand struct {
+	*Command
+	options struct {
+		json bool
+	}
+	status releaseStatus
+}
+
+// NewStatus creates a new command.
+func NewStatus(ui ui.UI, config config.Config, spec spec.Spec) *StatusCommand {
+	return &StatusCommand{
+		Command: New(ui, config, spec),
+	}
+}
+
+// NewStatusFactory returns a cli.CommandFactory for creating a new command.
+func NewStatusFactory(ui ui.UI, config config.Config, spec spec.Spec) cli.CommandFactory {
+	return func() (cli.Command, error) {
+		return NewStatus(ui, config, spec), nil
+	}
+}
+
+// Synopsis returns a short one-line synopsis for the command.
+func (c *StatusCommand) Synopsis() string {
+	return statusSynopsis
+}
+
+// Help returns a long help text including usage, description, and list of flags for the command.
+func (c *StatusCommand) Help() string {
+	return statusHelp
+}
+
+// Run runs the actual command with the given command-line arguments.
+// This method is used as a proxy for creating dependencies and the actual command execution is delegated to the run method for testing purposes.
+func (c *StatusCommand) Run(args []string) int {
+	if code := c.parseFlags(args); code != command.Success {
+		return code
+	}
+
+	if code := c.setup(); code != command.Success {
+		return code
+	}
+
+	return c.exec()
+}
+
+func (c *StatusCommand) parseFlags(args []string) int {
+	fs := flag.NewFlagSet("release status", flag.ContinueOnError)
+	fs.BoolVar(&c.options.json, "json", false, "")
+
+	fs.Usage = func() {
+		c.ui.Printf(c.Help())
+	}
+
+	if err := fs.Parse(args); err != nil {
+		// In case of error, the error and help will be printed by the Parse method
+		return command.FlagError
+	}
+
+	return command.Success
+}
+
+// exec in an auxiliary method, so we can test the business logic with mock dependencies.
+func (c *StatusCommand) exec() int {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if code := c.resolveStatus(ctx); code != command.Success {
+		return code
+	}
+
+	return c.printStatus()
+}
+
+func (c *StatusCommand) resolveStatus(ctx context.Context) int {
+	status := &c.status
+
+	// ==============================> CHECK FOR A DIRECT RELEASE <==============================
+
+	journal, err := c.services.journal.Load()
+	if err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.OSError
+	}
+
+	if journal != nil {
+		status.Mode = journal.Mode
+		status.Version = journal.TagPrefix + journal.Version
+		status.Tag = journal.TagPrefix
+		if version, ok := semver.Parse(journal.Version); ok {
+			status.Tag += version.TagName()
+		}
+		status.Steps = journal.Steps
+		status.NextAction = "Run basil project release -resume to continue the release or basil project release -abort to undo it."
+		return command.Success
+	}
+
+	// ==============================> CHECK FOR AN INDIRECT RELEASE <==============================
+
+	// Run semver command
+	if code := c.commands.semver.Run(nil); code != command.Success {
+		return code
+	}
+
+	c.data.tagPrefix = c.commands.semver.TagPrefix()
+
+	// The most recent release pull request is either open or merged but not yet finished
+	item, code := c.findReleasePull(ctx, github.QualifierIsOpen)
+	if code != command.Success {
+		return code
+	}
+
+	if item == nil {
+		if item, code = c.findReleasePull(ctx, github.QualifierIsMerged); code != command.Success {
+			return code
+		}
+	}
+
+	if item == nil {
+		status.NextAction = noReleaseAction
+		return command.Success
+	}
+
+	_, version, _ := parseReleaseName(item.Title)
+	c.outputs.version = version
+
+	pull, _, err := c.services.pulls.Get(ctx, item.Number)
+	if err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.GitHubError
+	}
+
+	release, err := c.lookupDraftRelease(ctx, c.tagName())
+	if err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.GitHubError
+	}
+
+	// A merged release pull request without a draft release is already finished
+	if pull.Merged && release == nil {
+		status.NextAction = noReleaseAction
+		return command.Success
+	}
+
+	status.Mode = spec.ReleaseModeIndirect
+	status.Version = c.releaseName()
+	status.Tag = c.tagName()
+	status.PullRequest = &pullStatus{
+		Number: pull.Number,
+		Title:  pull.Title,
+		State:  pullStateOpen,
+		URL:    pull.HTMLURL,
+		Checks: []pullCheck{},
+	}
+
+	if pull.Merged {
+		status.PullRequest.State = pullStateMerged
+	} else {
+		checks, err := c.services.checks.Checks(ctx, pull)
+		if err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GitHubError
+		}
+		status.PullRequest.Checks = checks
+	}
+
+	if release != nil {
+		status.Release = &draftStatus{
+			Name:   release.Name,
+			Tag:    release.TagName,
+			Target: release.Target,
+			URL:    release.HTMLURL,
+			Assets: []string{},
+		}
+
+		for _, asset := range release.Assets {
+			status.Release.Assets = append(status.Release.Assets, asset.Name)
+		}
+	}
+
+	status.NextAction = nextAction(status)
+
+	return command.Success
+}
+
+// findReleasePull finds the most recent release pull request of the module in a given state.
+func (c *StatusCommand) findReleasePull(ctx context.Context, state github.Qualifier) (*github.Issue, int) {
+	query := github.SearchQuery{}
+	query.IncludeQualifiers(
+		github.QualifierIsPR,
+		state,
+		github.QualifierRepo(c.data.owner, c.data.repo),
+	)
+
+	search := func(ctx context.Context, pageSize, pageNo int) ([]github.Issue, *github.Response, error) {
+		result, resp, err := c.services.search.SearchIssues(ctx, pageSize, pageNo, github.SortByCreated, github.DescOrder, query)
+		if err != nil {
+			return nil, nil, err
+		}
+		return result.Items, resp, nil
+	}
+
+	p := newPaginator(search)
+	p.maxPages = searchMaxPages
+
+	item, err := p.find(ctx, func(item github.Issue) bool {
+		if !releaseTitleRE.MatchString(item.Title) {
+			return false
+		}
+
+		prefix, _, ok := parseReleaseName(item.Title)
+		return ok && prefix == c.data.tagPrefix
+	})
+
+	if err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return nil, command.GitHubError
+	}
+
+	return item, command.Success
+}
+
+// nextAction determines the next action for completing an indirect release.
+func nextAction(status *releaseStatus) string {
+	pull := status.PullRequest
+
+	if pull.State == pullStateMerged {
+		return fmt.Sprintf("Run basil project release finish %d to tag and publish the release.", pull.Number)
+	}
+
+	if status.Release == nil {
+		return "Run basil project release to create the draft release."
+	}
+
+	var pending bool
+	for _, check := range pull.Checks {
+		switch check.State {
+		case checkFailure:
+			return fmt.Sprintf("Fix the failing checks of pull request #%d.", pull.Number)
+		case checkPending:
+			pending = true
+		}
+	}
+
+	if pending {
+		return fmt.Sprintf("Wait for the checks of pull request #%d to complete.", pull.Number)
+	}
+
+	return fmt.Sprintf("Review and merge pull request #%d, then run basil project release finish %d.", pull.Number, pull.Number)
+}
+
+func (c *StatusCommand) printStatus() int {
+	status := c.status
+
+	if c.options.json {
+		b, err := json.MarshalIndent(status, "", "  ")
+		if err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GenericError
+		}
+
+		c.ui.Printf("%s", b)
+		return command.Success
+	}
+
+	if status.Version != "" {
+		c.ui.Printf("Release:        %s (%s mode)", status.Version, status.Mode)
+		c.ui.Printf("Tag:            %s", status.Tag)
+	}
+
+	if len(status.Steps) > 0 {
+		steps := make([]string, len(status.Steps))
+		for i, step := range status.Steps {
+			steps[i] = string(step)
+		}
+		c.ui.Printf("Completed:      %s", strings.Join(steps, ", "))
+	}
+
+	if pull := status.PullRequest; pull != nil {
+		c.ui.Printf("Pull request:   #%d (%s) %s", pull.Number, pull.State, pull.URL)
+		for _, check := range pull.Checks {
+			c.ui.Printf("  %-12s  %s", check.State, check.Name)
+		}
+	}
+
+	if release := status.Release; release != nil {
+		c.ui.Printf("Draft release:  %s %s", release.Name, release.URL)
+		for _, asset := range release.Assets {
+			c.ui.Printf("  %s", asset)
+		}
+	}
+
+	c.ui.Printf("Next action:    %s", status.NextAction)
+
+	return command.Success
+}
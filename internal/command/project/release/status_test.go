@@ -0,0 +1,664 @@
+package release
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gardenbed/go-github"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/basil-cli/internal/command"
+	"github.com/gardenbed/basil-cli/internal/config"
+	"github.com/gardenbed/basil-cli/internal/spec"
+	"github.com/gardenbed/basil-cli/internal/ui"
+)
+
+var (
+	releasePullsSearchResult = &github.SearchIssuesResult{
+		TotalCount: 3,
+		Items: []github.Issue{
+			{Number: 1003, Title: "Fix bugs"},
+			{Number: 1002, Title: "RELEASE services/billing/0.2.0"},
+			{Number: 1001, Title: "RELEASE 0.1.0"},
+		},
+	}
+
+	openReleasePull = &github.Pull{
+		ID:      1,
+		Number:  1001,
+		State:   "open",
+		Title:   "RELEASE 0.1.0",
+		Head:    github.PullBranch{Ref: "release-0.1.0", SHA: "25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378"},
+		HTMLURL: "https://github.com/octocat/Hello-World/pull/1001",
+	}
+
+	uploadedDraftRelease = github.Release{
+		Name:    "0.1.0",
+		TagName: "v0.1.0",
+		Target:  "main",
+		Draft:   true,
+		HTMLURL: "https://github.com/octocat/Hello-World/releases/tag/untagged-1",
+		Assets: []github.ReleaseAsset{
+			{Name: "app-linux-amd64"},
+			{Name: "checksums.txt"},
+		},
+	}
+)
+
+func TestNewStatus(t *testing.T) {
+	ui := ui.NewNop()
+	config := config.Config{}
+	spec := spec.Spec{}
+	c := NewStatus(ui, config, spec)
+
+	assert.NotNil(t, c)
+}
+
+func TestNewStatusFactory(t *testing.T) {
+	ui := ui.NewNop()
+	config := config.Config{}
+	spec := spec.Spec{}
+	c, err := NewStatusFactory(ui, config, spec)()
+
+	assert.NoError(t, err)
+	assert.NotNil(t, c)
+}
+
+func TestStatusCommand_Synopsis(t *testing.T) {
+	c := NewStatus(ui.NewNop(), config.Config{}, spec.Spec{})
+	synopsis := c.Synopsis()
+
+	assert.NotEmpty(t, synopsis)
+}
+
+func TestStatusCommand_Help(t *testing.T) {
+	c := NewStatus(ui.NewNop(), config.Config{}, spec.Spec{})
+	help := c.Help()
+
+	assert.NotEmpty(t, help)
+}
+
+func TestStatusCommand_Run(t *testing.T) {
+	t.Run("InvalidFlag", func(t *testing.T) {
+		c := NewStatus(ui.NewNop(), config.Config{}, spec.Spec{})
+		exitCode := c.Run([]string{"-undefined"})
+
+		assert.Equal(t, command.FlagError, exitCode)
+	})
+}
+
+func TestStatusCommand_parseFlags(t *testing.T) {
+	tests := []struct {
+		name             string
+		args             []string
+		expectedExitCode int
+		expectedJSON     bool
+	}{
+		{
+			name:             "InvalidFlag",
+			args:             []string{"-undefined"},
+			expectedExitCode: command.FlagError,
+		},
+		{
+			name:             "NoFlag",
+			args:             []string{},
+			expectedExitCode: command.Success,
+		},
+		{
+			name:             "JSON",
+			args:             []string{"-json"},
+			expectedExitCode: command.Success,
+			expectedJSON:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewStatus(ui.NewNop(), config.Config{}, spec.Spec{})
+			exitCode := c.parseFlags(tc.args)
+
+			assert.Equal(t, tc.expectedExitCode, exitCode)
+			assert.Equal(t, tc.expectedJSON, c.options.json)
+		})
+	}
+}
+
+func TestStatusCommand_exec(t *testing.T) {
+	tests := []struct {
+		name             string
+		json             bool
+		journal          *MockJournalService
+		semver           *MockSemverCommand
+		search           *MockSearchService
+		pulls            *MockPullService
+		releases         *MockReleaseService
+		checks           *MockCheckService
+		expectedExitCode int
+		expectedStatus   releaseStatus
+	}{
+		{
+			name: "JournalLoadFails",
+			journal: &MockJournalService{
+				LoadMocks: []JournalLoadMock{
+					{OutError: errors.New("io error")},
+				},
+			},
+			expectedExitCode: command.OSError,
+		},
+		{
+			name: "DirectRelease",
+			journal: &MockJournalService{
+				LoadMocks: []JournalLoadMock{
+					{
+						OutJournal: &releaseJournal{
+							Mode:      spec.ReleaseModeDirect,
+							Version:   "0.1.0",
+							TagPrefix: "services/billing/",
+							Steps:     []releaseStep{stepDraftCreated, stepChangelogGenerated},
+						},
+					},
+				},
+			},
+			expectedExitCode: command.Success,
+			expectedStatus: releaseStatus{
+				Mode:       spec.ReleaseModeDirect,
+				Version:    "services/billing/0.1.0",
+				Tag:        "services/billing/v0.1.0",
+				Steps:      []releaseStep{stepDraftCreated, stepChangelogGenerated},
+				NextAction: "Run basil project release -resume to continue the release or basil project release -abort to undo it.",
+			},
+		},
+		{
+			name: "SemverRunFails",
+			journal: &MockJournalService{
+				LoadMocks: []JournalLoadMock{{}},
+			},
+			semver: &MockSemverCommand{
+				RunMocks: []SemverRunMock{
+					{OutCode: command.GitError},
+				},
+			},
+			expectedExitCode: command.GitError,
+		},
+		{
+			name: "SearchIssuesFails",
+			journal: &MockJournalService{
+				LoadMocks: []JournalLoadMock{{}},
+			},
+			semver: &MockSemverCommand{
+				RunMocks:       []SemverRunMock{{OutCode: command.Success}},
+				TagPrefixMocks: []TagPrefixMock{{OutTagPrefix: ""}},
+			},
+			search: &MockSearchService{
+				SearchIssuesMocks: []SearchIssuesMock{
+					{OutError: errors.New("github error")},
+				},
+			},
+			expectedExitCode: command.GitHubError,
+		},
+		{
+			name: "NoReleasePull",
+			journal: &MockJournalService{
+				LoadMocks: []JournalLoadMock{{}},
+			},
+			semver: &MockSemverCommand{
+				RunMocks:       []SemverRunMock{{OutCode: command.Success}},
+				TagPrefixMocks: []TagPrefixMock{{OutTagPrefix: ""}},
+			},
+			search: &MockSearchService{
+				SearchIssuesMocks: []SearchIssuesMock{
+					{OutResult: emptySearchResult, OutResponse: &github.Response{}},
+					{OutResult: emptySearchResult, OutResponse: &github.Response{}},
+				},
+			},
+			expectedExitCode: command.Success,
+			expectedStatus: releaseStatus{
+				NextAction: noReleaseAction,
+			},
+		},
+		{
+			name: "GetPullFails",
+			journal: &MockJournalService{
+				LoadMocks: []JournalLoadMock{{}},
+			},
+			semver: &MockSemverCommand{
+				RunMocks:       []SemverRunMock{{OutCode: command.Success}},
+				TagPrefixMocks: []TagPrefixMock{{OutTagPrefix: ""}},
+			},
+			search: &MockSearchService{
+				SearchIssuesMocks: []SearchIssuesMock{
+					{OutResult: releasePullsSearchResult, OutResponse: &github.Response{}},
+				},
+			},
+			pulls: &MockPullService{
+				GetMocks: []PullGetMock{
+					{OutError: errors.New("github error")},
+				},
+			},
+			expectedExitCode: command.GitHubError,
+		},
+		{
+			name: "LookupDraftReleaseFails",
+			journal: &MockJournalService{
+				LoadMocks: []JournalLoadMock{{}},
+			},
+			semver: &MockSemverCommand{
+				RunMocks:       []SemverRunMock{{OutCode: command.Success}},
+				TagPrefixMocks: []TagPrefixMock{{OutTagPrefix: ""}},
+			},
+			search: &MockSearchService{
+				SearchIssuesMocks: []SearchIssuesMock{
+					{OutResult: releasePullsSearchResult, OutResponse: &github.Response{}},
+				},
+			},
+			pulls: &MockPullService{
+				GetMocks: []PullGetMock{
+					{OutPull: openReleasePull, OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				ListMocks: []ReleaseListMock{
+					{OutError: errors.New("github error")},
+				},
+			},
+			expectedExitCode: command.GitHubError,
+		},
+		{
+			name: "ChecksFails",
+			journal: &MockJournalService{
+				LoadMocks: []JournalLoadMock{{}},
+			},
+			semver: &MockSemverCommand{
+				RunMocks:       []SemverRunMock{{OutCode: command.Success}},
+				TagPrefixMocks: []TagPrefixMock{{OutTagPrefix: ""}},
+			},
+			search: &MockSearchService{
+				SearchIssuesMocks: []SearchIssuesMock{
+					{OutResult: releasePullsSearchResult, OutResponse: &github.Response{}},
+				},
+			},
+			pulls: &MockPullService{
+				GetMocks: []PullGetMock{
+					{OutPull: openReleasePull, OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				ListMocks: []ReleaseListMock{
+					{OutReleases: []github.Release{draftRelease}, OutResponse: &github.Response{}},
+				},
+			},
+			checks: &MockCheckService{
+				ChecksMocks: []ChecksMock{
+					{OutError: errors.New("github error")},
+				},
+			},
+			expectedExitCode: command.GitHubError,
+		},
+		{
+			name: "OpenPull_NoDraftRelease",
+			journal: &MockJournalService{
+				LoadMocks: []JournalLoadMock{{}},
+			},
+			semver: &MockSemverCommand{
+				RunMocks:       []SemverRunMock{{OutCode: command.Success}},
+				TagPrefixMocks: []TagPrefixMock{{OutTagPrefix: ""}},
+			},
+			search: &MockSearchService{
+				SearchIssuesMocks: []SearchIssuesMock{
+					{OutResult: releasePullsSearchResult, OutResponse: &github.Response{}},
+				},
+			},
+			pulls: &MockPullService{
+				GetMocks: []PullGetMock{
+					{OutPull: openReleasePull, OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				ListMocks: []ReleaseListMock{
+					{OutReleases: []github.Release{}, OutResponse: &github.Response{}},
+				},
+			},
+			checks: &MockCheckService{
+				ChecksMocks: []ChecksMock{
+					{OutChecks: []pullCheck{}},
+				},
+			},
+			expectedExitCode: command.Success,
+			expectedStatus: releaseStatus{
+				Mode:    spec.ReleaseModeIndirect,
+				Version: "0.1.0",
+				Tag:     "v0.1.0",
+				PullRequest: &pullStatus{
+					Number: 1001,
+					Title:  "RELEASE 0.1.0",
+					State:  "open",
+					URL:    "https://github.com/octocat/Hello-World/pull/1001",
+					Checks: []pullCheck{},
+				},
+				NextAction: "Run basil project release to create the draft release.",
+			},
+		},
+		{
+			name: "OpenPull_FailingChecks",
+			journal: &MockJournalService{
+				LoadMocks: []JournalLoadMock{{}},
+			},
+			semver: &MockSemverCommand{
+				RunMocks:       []SemverRunMock{{OutCode: command.Success}},
+				TagPrefixMocks: []TagPrefixMock{{OutTagPrefix: ""}},
+			},
+			search: &MockSearchService{
+				SearchIssuesMocks: []SearchIssuesMock{
+					{OutResult: releasePullsSearchResult, OutResponse: &github.Response{}},
+				},
+			},
+			pulls: &MockPullService{
+				GetMocks: []PullGetMock{
+					{OutPull: openReleasePull, OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				ListMocks: []ReleaseListMock{
+					{OutReleases: []github.Release{draftRelease}, OutResponse: &github.Response{}},
+				},
+			},
+			checks: &MockCheckService{
+				ChecksMocks: []ChecksMock{
+					{
+						OutChecks: []pullCheck{
+							{Name: "build", State: checkPending},
+							{Name: "test", State: checkFailure},
+						},
+					},
+				},
+			},
+			expectedExitCode: command.Success,
+			expectedStatus: releaseStatus{
+				Mode:    spec.ReleaseModeIndirect,
+				Version: "0.1.0",
+				Tag:     "v0.1.0",
+				PullRequest: &pullStatus{
+					Number: 1001,
+					Title:  "RELEASE 0.1.0",
+					State:  "open",
+					URL:    "https://github.com/octocat/Hello-World/pull/1001",
+					Checks: []pullCheck{
+						{Name: "build", State: checkPending},
+						{Name: "test", State: checkFailure},
+					},
+				},
+				Release: &draftStatus{
+					Name:   "0.1.0",
+					Tag:    "v0.1.0",
+					Target: "main",
+					Assets: []string{},
+				},
+				NextAction: "Fix the failing checks of pull request #1001.",
+			},
+		},
+		{
+			name: "OpenPull_PendingChecks",
+			journal: &MockJournalService{
+				LoadMocks: []JournalLoadMock{{}},
+			},
+			semver: &MockSemverCommand{
+				RunMocks:       []SemverRunMock{{OutCode: command.Success}},
+				TagPrefixMocks: []TagPrefixMock{{OutTagPrefix: ""}},
+			},
+			search: &MockSearchService{
+				SearchIssuesMocks: []SearchIssuesMock{
+					{OutResult: releasePullsSearchResult, OutResponse: &github.Response{}},
+				},
+			},
+			pulls: &MockPullService{
+				GetMocks: []PullGetMock{
+					{OutPull: openReleasePull, OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				ListMocks: []ReleaseListMock{
+					{OutReleases: []github.Release{draftRelease}, OutResponse: &github.Response{}},
+				},
+			},
+			checks: &MockCheckService{
+				ChecksMocks: []ChecksMock{
+					{
+						OutChecks: []pullCheck{
+							{Name: "build", State: checkSuccess},
+							{Name: "test", State: checkPending},
+						},
+					},
+				},
+			},
+			expectedExitCode: command.Success,
+			expectedStatus: releaseStatus{
+				Mode:    spec.ReleaseModeIndirect,
+				Version: "0.1.0",
+				Tag:     "v0.1.0",
+				PullRequest: &pullStatus{
+					Number: 1001,
+					Title:  "RELEASE 0.1.0",
+					State:  "open",
+					URL:    "https://github.com/octocat/Hello-World/pull/1001",
+					Checks: []pullCheck{
+						{Name: "build", State: checkSuccess},
+						{Name: "test", State: checkPending},
+					},
+				},
+				Release: &draftStatus{
+					Name:   "0.1.0",
+					Tag:    "v0.1.0",
+					Target: "main",
+					Assets: []string{},
+				},
+				NextAction: "Wait for the checks of pull request #1001 to complete.",
+			},
+		},
+		{
+			name: "OpenPull_PassingChecks_JSON",
+			json: true,
+			journal: &MockJournalService{
+				LoadMocks: []JournalLoadMock{{}},
+			},
+			semver: &MockSemverCommand{
+				RunMocks:       []SemverRunMock{{OutCode: command.Success}},
+				TagPrefixMocks: []TagPrefixMock{{OutTagPrefix: ""}},
+			},
+			search: &MockSearchService{
+				SearchIssuesMocks: []SearchIssuesMock{
+					{OutResult: releasePullsSearchResult, OutResponse: &github.Response{}},
+				},
+			},
+			pulls: &MockPullService{
+				GetMocks: []PullGetMock{
+					{OutPull: openReleasePull, OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				ListMocks: []ReleaseListMock{
+					{OutReleases: []github.Release{draftRelease}, OutResponse: &github.Response{}},
+				},
+			},
+			checks: &MockCheckService{
+				ChecksMocks: []ChecksMock{
+					{
+						OutChecks: []pullCheck{
+							{Name: "build", State: checkSuccess},
+						},
+					},
+				},
+			},
+			expectedExitCode: command.Success,
+			expectedStatus: releaseStatus{
+				Mode:    spec.ReleaseModeIndirect,
+				Version: "0.1.0",
+				Tag:     "v0.1.0",
+				PullRequest: &pullStatus{
+					Number: 1001,
+					Title:  "RELEASE 0.1.0",
+					State:  "open",
+					URL:    "https://github.com/octocat/Hello-World/pull/1001",
+					Checks: []pullCheck{
+						{Name: "build", State: checkSuccess},
+					},
+				},
+				Release: &draftStatus{
+					Name:   "0.1.0",
+					Tag:    "v0.1.0",
+					Target: "main",
+					Assets: []string{},
+				},
+				NextAction: "Review and merge pull request #1001, then run basil project release finish 1001.",
+			},
+		},
+		{
+			name: "MergedPull_Finished",
+			journal: &MockJournalService{
+				LoadMocks: []JournalLoadMock{{}},
+			},
+			semver: &MockSemverCommand{
+				RunMocks:       []SemverRunMock{{OutCode: command.Success}},
+				TagPrefixMocks: []TagPrefixMock{{OutTagPrefix: ""}},
+			},
+			search: &MockSearchService{
+				SearchIssuesMocks: []SearchIssuesMock{
+					{OutResult: emptySearchResult, OutResponse: &github.Response{}},
+					{OutResult: releasePullsSearchResult, OutResponse: &github.Response{}},
+				},
+			},
+			pulls: &MockPullService{
+				GetMocks: []PullGetMock{
+					{OutPull: releasePull, OutResponse: &github.Response{}},
+				},
+			},
+			releases: &MockReleaseService{
+				ListMocks: []ReleaseListMock{
+					{OutReleases: []github.Release{release}, OutResponse: &github.Response{}},
+				},
+			},
+			expectedExitCode: command.Success,
+			expectedStatus: releaseStatus{
+				NextAction: noReleaseAction,
+			},
+		},
+		{
+			name: "MergedPull_Module",
+			journal: &MockJournalService{
+				LoadMocks: []JournalLoadMock{{}},
+			},
+			semver: &MockSemverCommand{
+				RunMocks:       []SemverRunMock{{OutCode: command.Success}},
+				TagPrefixMocks: []TagPrefixMock{{OutTagPrefix: "services/billing/"}},
+			},
+			search: &MockSearchService{
+				SearchIssuesMocks: []SearchIssuesMock{
+					{OutResult: emptySearchResult, OutResponse: &github.Response{}},
+					{OutResult: releasePullsSearchResult, OutResponse: &github.Response{}},
+				},
+			},
+			pulls: &MockPullService{
+				GetMocks: []PullGetMock{
+					{
+						OutPull: &github.Pull{
+							Number:  1002,
+							Title:   "RELEASE services/billing/0.2.0",
+							Merged:  true,
+							HTMLURL: "https://github.com/octocat/Hello-World/pull/1002",
+						},
+						OutResponse: &github.Response{},
+					},
+				},
+			},
+			releases: &MockReleaseService{
+				ListMocks: []ReleaseListMock{
+					{
+						OutReleases: []github.Release{
+							{
+								Name:    "services/billing/0.2.0",
+								TagName: "services/billing/v0.2.0",
+								Target:  "main",
+								Draft:   true,
+								HTMLURL: "https://github.com/octocat/Hello-World/releases/tag/untagged-1",
+								Assets:  uploadedDraftRelease.Assets,
+							},
+						},
+						OutResponse: &github.Response{},
+					},
+				},
+			},
+			expectedExitCode: command.Success,
+			expectedStatus: releaseStatus{
+				Mode:    spec.ReleaseModeIndirect,
+				Version: "services/billing/0.2.0",
+				Tag:     "services/billing/v0.2.0",
+				PullRequest: &pullStatus{
+					Number: 1002,
+					Title:  "RELEASE services/billing/0.2.0",
+					State:  "merged",
+					URL:    "https://github.com/octocat/Hello-World/pull/1002",
+					Checks: []pullCheck{},
+				},
+				Release: &draftStatus{
+					Name:   "services/billing/0.2.0",
+					Tag:    "services/billing/v0.2.0",
+					Target: "main",
+					URL:    "https://github.com/octocat/Hello-World/releases/tag/untagged-1",
+					Assets: []string{"app-linux-amd64", "checksums.txt"},
+				},
+				NextAction: "Run basil project release finish 1002 to tag and publish the release.",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewStatus(ui.NewNop(), config.Config{}, spec.Spec{})
+
+			c.options.json = tc.json
+
+			c.data.owner = "octocat"
+			c.data.repo = "Hello-World"
+
+			c.services.journal = tc.journal
+			c.services.search = tc.search
+			c.services.pulls = tc.pulls
+			c.services.releases = tc.releases
+			c.services.checks = tc.checks
+			c.commands.semver = tc.semver
+
+			exitCode := c.exec()
+
+			assert.Equal(t, tc.expectedExitCode, exitCode)
+
+			if tc.expectedExitCode == command.Success {
+				assert.Equal(t, tc.expectedStatus, c.status)
+			}
+		})
+	}
+}
+
+func TestToCheckState(t *testing.T) {
+	tests := []struct {
+		state         string
+		expectedState string
+	}{
+		{"success", checkSuccess},
+		{"neutral", checkSuccess},
+		{"skipped", checkSuccess},
+		{"failure", checkFailure},
+		{"failed", checkFailure},
+		{"error", checkFailure},
+		{"cancelled", checkFailure},
+		{"canceled", checkFailure},
+		{"timed_out", checkFailure},
+		{"pending", checkPending},
+		{"running", checkPending},
+		{"", checkPending},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.state, func(t *testing.T) {
+			assert.Equal(t, tc.expectedState, toCheckState(tc.state))
+		})
+	}
+}
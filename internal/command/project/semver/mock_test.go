@@ -14,12 +14,22 @@ type (
 		OutError   error
 	}
 
+	CommitsTouchingMock struct {
+		InRev      string
+		InPath     string
+		OutCommits git.Commits
+		OutError   error
+	}
+
 	MockGitService struct {
 		TagsIndex int
 		TagsMocks []TagsMock
 
 		CommitsInIndex int
 		CommitsInMocks []CommitsInMock
+
+		CommitsTouchingIndex int
+		CommitsTouchingMocks []CommitsTouchingMock
 	}
 )
 
@@ -35,3 +45,11 @@ func (m *MockGitService) CommitsIn(rev string) (git.Commits, error) {
 	m.CommitsInMocks[i].InRev = rev
 	return m.CommitsInMocks[i].OutCommits, m.CommitsInMocks[i].OutError
 }
+
+func (m *MockGitService) CommitsTouching(rev, path string) (git.Commits, error) {
+	i := m.CommitsTouchingIndex
+	m.CommitsTouchingIndex++
+	m.CommitsTouchingMocks[i].InRev = rev
+	m.CommitsTouchingMocks[i].InPath = path
+	return m.CommitsTouchingMocks[i].OutCommits, m.CommitsTouchingMocks[i].OutError
+}
@@ -4,7 +4,9 @@ package semver
 import (
 	"context"
 	"flag"
+	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gardenbed/charm/shell"
@@ -13,6 +15,7 @@ import (
 	"github.com/gardenbed/basil-cli/internal/command"
 	"github.com/gardenbed/basil-cli/internal/git"
 	"github.com/gardenbed/basil-cli/internal/semver"
+	"github.com/gardenbed/basil-cli/internal/spec"
 	"github.com/gardenbed/basil-cli/internal/ui"
 )
 
@@ -21,22 +24,48 @@ const (
 	synopsis = `Print the current semantic version`
 	help     = `
   Use this command for getting the current semantic version.
+  Pre-release tags (e.g. v1.3.0-rc.1) are considered as unreleased versions,
+  so the commits after a pre-release tag are on the same pre-release (e.g. 1.3.0-rc.1.2.abcdef0).
 
-  Usage:  basil project semver
+  The next release version is determined from the Conventional Commits since the most recent tag.
+  A breaking change (type! or BREAKING CHANGE:) results in a major release.
+  By default, feat results in a minor release and fix and perf result in a patch release.
+  This mapping can be configured under project.release.bumps in the spec file.
+
+  For a module in a sub-directory of a repository (monorepo), the tags are prefixed with the module path (e.g. services/billing/v1.2.3).
+  When running from the module directory, only the prefixed tags and the commits changing the module directory are considered.
+  The module directory can also be given with -dir instead of running from it.
+
+  Usage:  basil project semver [flags]
+
+  Flags:
+    -next    print the next release version and the commits determining it
+    -dir     the directory of the module (default: the current directory)
 
   Examples:
     basil project semver
+    basil project semver -next
+    basil project semver -dir services/billing
   `
 )
 
 type gitService interface {
 	Tags() (git.Tags, error)
 	CommitsIn(string) (git.Commits, error)
+	CommitsTouching(string, string) (git.Commits, error)
 }
 
 // Command is the cli.Command implementation for semver command.
 type Command struct {
 	ui    ui.UI
+	spec  spec.Spec
+	flags struct {
+		next bool
+		dir  string
+	}
+	data struct {
+		module string
+	}
 	funcs struct {
 		gitStatus shell.RunnerFunc
 		gitRevSHA shell.RunnerFunc
@@ -45,21 +74,24 @@ type Command struct {
 		git gitService
 	}
 	outputs struct {
-		semver semver.SemVer
+		semver      semver.SemVer
+		bump        semver.Bump
+		bumpCommits git.Commits
 	}
 }
 
 // New creates a new command.
-func New(ui ui.UI) *Command {
+func New(ui ui.UI, spec spec.Spec) *Command {
 	return &Command{
-		ui: ui,
+		ui:   ui,
+		spec: spec,
 	}
 }
 
 // NewFactory returns a cli.CommandFactory for creating a new command.
-func NewFactory(ui ui.UI) cli.CommandFactory {
+func NewFactory(ui ui.UI, spec spec.Spec) cli.CommandFactory {
 	return func() (cli.Command, error) {
-		return New(ui), nil
+		return New(ui, spec), nil
 	}
 }
 
@@ -80,12 +112,20 @@ func (c *Command) Run(args []string) int {
 		return code
 	}
 
-	git, err := git.Open(".")
+	git, err := git.Open(c.flags.dir)
 	if err != nil {
 		c.ui.Errorf(ui.Red, "%s", err)
 		return command.GitError
 	}
 
+	// The path of the module relative to the git repository (empty for the root module)
+	module, err := git.Rel(c.flags.dir)
+	if err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.GitError
+	}
+
+	c.data.module = module
 	c.funcs.gitStatus = shell.Runner("git", "status", "--porcelain")
 	c.funcs.gitRevSHA = shell.Runner("git", "rev-parse", "HEAD")
 	c.services.git = git
@@ -95,6 +135,8 @@ func (c *Command) Run(args []string) int {
 
 func (c *Command) parseFlags(args []string) int {
 	fs := flag.NewFlagSet("semver", flag.ContinueOnError)
+	fs.BoolVar(&c.flags.next, "next", false, "")
+	fs.StringVar(&c.flags.dir, "dir", ".", "")
 
 	fs.Usage = func() {
 		c.ui.Printf(c.Help())
@@ -141,6 +183,12 @@ func (c *Command) exec() int {
 		return command.GitError
 	}
 
+	// Keep track of the commits reachable from HEAD
+	reachable := make(map[string]bool, len(commits))
+	for _, c := range commits {
+		reachable[c.Hash] = true
+	}
+
 	// Get the most recent tag that is a semantic version
 	tag, _ := tags.First(func(t git.Tag) bool {
 		// Make sure the tag falls in the commits range
@@ -148,17 +196,31 @@ func (c *Command) exec() int {
 			return false
 		}
 
-		// Make sure the tag is a semantic version
-		if _, ok := semver.Parse(t.Name); !ok {
+		// Make sure the tag is reachable from HEAD (e.g. tags on other release branches are not)
+		if !reachable[t.Commit.Hash] {
+			return false
+		}
+
+		// Make sure the tag is a semantic version (prefixed with the module path for a module in a sub-directory)
+		if _, ok := c.parseTag(t.Name); !ok {
 			return false
 		}
 
 		return true
 	})
 
+	// For a module in a sub-directory, only the commits changing the module are considered
+	if c.data.module != "" {
+		if commits, err = c.services.git.CommitsTouching("HEAD", c.data.module); err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GitError
+		}
+	}
+
 	// ==============================> RESOLVE THE CURRENT SEMANTIC VERSION <==============================
 
 	var sv semver.SemVer
+	var count int
 
 	var signature string
 	if gitStatus == "" {
@@ -170,30 +232,80 @@ func (c *Command) exec() int {
 	if tag.IsZero() {
 		// No git tag and no previous semantic version -> using the default initial semantic version
 		sv = semver.SemVer{Major: 0, Minor: 1, Patch: 0}
-		count := strconv.Itoa(len(commits))
-		sv.Prerelease = append(sv.Prerelease, count, signature)
+		count = len(commits)
+		sv.Prerelease = append(sv.Prerelease, strconv.Itoa(count), signature)
 	} else {
 		// The most recent tag either points to the HEAD commit or is reachable from the HEAD commit
 		// The tag is guaranteed to be a valid semantic version thanks to the predicte for selecting it
-		sv, _ = semver.Parse(tag.Name)
-
-		// Count how many commits HEAD is ahead of the most recent tag
-		var count int
-		for i, c := range commits {
-			if c.Equal(tag.Commit) {
-				count = i
-				break
+		sv, _ = c.parseTag(tag.Name)
+
+		// Get all git commits reachable from the most recent tag (already released)
+		released, err := c.services.git.CommitsIn(tag.Commit.Hash)
+		if err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.GitError
+		}
+
+		isReleased := make(map[string]bool, len(released))
+		for _, c := range released {
+			isReleased[c.Hash] = true
+		}
+
+		// Keep only the commits HEAD is ahead of the most recent tag
+		// For a module in a sub-directory, the tag commit may not change the module, so ancestry is used instead of the tag commit itself
+		ahead := make(git.Commits, 0, len(commits))
+		for _, c := range commits {
+			if !isReleased[c.Hash] {
+				ahead = append(ahead, c)
 			}
 		}
 
+		commits = ahead
+		count = len(commits)
+
 		// If there are any changes since the most recent tag, we are on next semantic version
 		// If the the most recent tag points to the HEAD commit and the working tree is clean, we are just at current semantic version
 		if count > 0 || gitStatus != "" {
-			sv = sv.Next()
+			if _, number := sv.Channel(); number > 0 {
+				// The most recent tag is a pre-release (e.g. v1.3.0-rc.1) and its version is not released yet.
+				// So, we are still on the same pre-release (e.g. 1.3.0-rc.1.2.abcdef0).
+				sv.Metadata = nil
+			} else {
+				sv = sv.Next()
+			}
 			sv.Prerelease = append(sv.Prerelease, strconv.Itoa(count), signature)
 		}
 	}
 
+	// ==============================> DETECT THE NEXT RELEASE <==============================
+
+	bumps, err := c.bumps()
+	if err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.SpecError
+	}
+
+	// Determine the bump from the commits since the most recent tag
+	c.outputs.bump, c.outputs.bumpCommits = detectBump(commits, bumps)
+
+	if c.flags.next {
+		if len(c.outputs.bumpCommits) == 0 {
+			c.ui.Warnf(ui.Yellow, "No commit since the last release requires a release, defaulting to a patch release.")
+		} else {
+			c.ui.Printf("A %s release is determined by the following commits:", c.outputs.bump)
+			for _, commit := range c.outputs.bumpCommits {
+				c.ui.Printf("  %s", commit)
+			}
+		}
+
+		// If the current semantic version is not a pre-release, it is the most recent tag and it is already released.
+		if !sv.IsPrerelease() {
+			sv = sv.Next()
+		}
+
+		sv = sv.Release(c.outputs.bump, "")
+	}
+
 	c.outputs.semver = sv
 
 	c.ui.Infof(ui.Green, sv.String())
@@ -207,3 +319,76 @@ func (c *Command) exec() int {
 func (c *Command) SemVer() semver.SemVer {
 	return c.outputs.semver
 }
+
+// TagPrefix returns the prefix of release tags.
+// For a module in a sub-directory of a repository, it is the module path followed by a slash (e.g. services/billing/).
+// For the root module, it is empty.
+func (c *Command) TagPrefix() string {
+	if c.data.module == "" {
+		return ""
+	}
+	return c.data.module + "/"
+}
+
+// Bump returns the bump for the next release and the commits determining it.
+// If no commit since the most recent tag requires a release, the bump is patch and there is no commit.
+func (c *Command) Bump() (semver.Bump, git.Commits) {
+	return c.outputs.bump, c.outputs.bumpCommits
+}
+
+// parseTag parses a tag name as a semantic version.
+// For a module in a sub-directory, the tag name should be prefixed with the module path.
+func (c *Command) parseTag(name string) (semver.SemVer, bool) {
+	name, ok := strings.CutPrefix(name, c.TagPrefix())
+	if !ok {
+		return semver.SemVer{}, false
+	}
+
+	return semver.Parse(name)
+}
+
+// bumps returns the mapping of Conventional Commit types to bumps from the spec or the default one.
+func (c *Command) bumps() (map[string]semver.Bump, error) {
+	if len(c.spec.Project.Release.Bumps) == 0 {
+		return semver.DefaultBumps, nil
+	}
+
+	bumps := make(map[string]semver.Bump, len(c.spec.Project.Release.Bumps))
+	for commitType, name := range c.spec.Project.Release.Bumps {
+		bump, ok := semver.ParseBump(name)
+		if !ok {
+			return nil, fmt.Errorf("invalid bump for commit type %s: %s", commitType, name)
+		}
+		bumps[strings.ToLower(commitType)] = bump
+	}
+
+	return bumps, nil
+}
+
+// detectBump determines the bump for a release from Conventional Commits.
+// It returns the highest bump and the commits requiring it.
+func detectBump(commits git.Commits, bumps map[string]semver.Bump) (semver.Bump, git.Commits) {
+	bump := semver.Patch
+	var drivers git.Commits
+
+	for _, commit := range commits {
+		cc, ok := semver.ParseConventionalCommit(commit.Message)
+		if !ok {
+			continue
+		}
+
+		b, ok := cc.Bump(bumps)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case b > bump:
+			bump, drivers = b, git.Commits{commit}
+		case b == bump:
+			drivers = append(drivers, commit)
+		}
+	}
+
+	return bump, drivers
+}
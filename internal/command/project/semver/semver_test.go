@@ -12,19 +12,20 @@ import (
 	"github.com/gardenbed/basil-cli/internal/command"
 	"github.com/gardenbed/basil-cli/internal/git"
 	"github.com/gardenbed/basil-cli/internal/semver"
+	"github.com/gardenbed/basil-cli/internal/spec"
 	"github.com/gardenbed/basil-cli/internal/ui"
 )
 
 func TestNew(t *testing.T) {
 	ui := ui.NewNop()
-	c := New(ui)
+	c := New(ui, spec.Spec{})
 
 	assert.NotNil(t, c)
 }
 
 func TestNewFactory(t *testing.T) {
 	ui := ui.NewNop()
-	c, err := NewFactory(ui)()
+	c, err := NewFactory(ui, spec.Spec{})()
 
 	assert.NoError(t, err)
 	assert.NotNil(t, c)
@@ -62,6 +63,34 @@ func TestCommand_Run(t *testing.T) {
 	})
 }
 
+func TestCommand_TagPrefix(t *testing.T) {
+	tests := []struct {
+		name              string
+		module            string
+		expectedTagPrefix string
+	}{
+		{
+			name:              "RootModule",
+			module:            "",
+			expectedTagPrefix: "",
+		},
+		{
+			name:              "SubModule",
+			module:            "services/billing",
+			expectedTagPrefix: "services/billing/",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := new(Command)
+			c.data.module = tc.module
+
+			assert.Equal(t, tc.expectedTagPrefix, c.TagPrefix())
+		})
+	}
+}
+
 func TestCommand_parseFlags(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -78,6 +107,11 @@ func TestCommand_parseFlags(t *testing.T) {
 			args:             []string{},
 			expectedExitCode: command.Success,
 		},
+		{
+			name:             "ValidFlags",
+			args:             []string{"-next", "-dir", "services/billing"},
+			expectedExitCode: command.Success,
+		},
 	}
 
 	for _, tc := range tests {
@@ -93,11 +127,15 @@ func TestCommand_parseFlags(t *testing.T) {
 func TestCommand_exec(t *testing.T) {
 	tests := []struct {
 		name             string
+		spec             spec.Spec
+		module           string
+		nextFlag         bool
 		gitStatus        shell.RunnerFunc
 		gitRevSHA        shell.RunnerFunc
 		git              *MockGitService
 		expectedExitCode int
 		expectedSemver   string
+		expectedBump     semver.Bump
 	}{
 		{
 			name: "GitStatusFails",
@@ -252,6 +290,12 @@ func TestCommand_exec(t *testing.T) {
 							{Hash: "3a1960ec0cec18d2dca14d270d11c5bc4138abf6"},
 						},
 					},
+					{
+						OutCommits: git.Commits{
+							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+							{Hash: "3a1960ec0cec18d2dca14d270d11c5bc4138abf6"},
+						},
+					},
 				},
 			},
 			expectedExitCode: command.Success,
@@ -283,6 +327,12 @@ func TestCommand_exec(t *testing.T) {
 							{Hash: "3a1960ec0cec18d2dca14d270d11c5bc4138abf6"},
 						},
 					},
+					{
+						OutCommits: git.Commits{
+							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+							{Hash: "3a1960ec0cec18d2dca14d270d11c5bc4138abf6"},
+						},
+					},
 				},
 			},
 			expectedExitCode: command.Success,
@@ -316,6 +366,12 @@ func TestCommand_exec(t *testing.T) {
 							{Hash: "3a1960ec0cec18d2dca14d270d11c5bc4138abf6"},
 						},
 					},
+					{
+						OutCommits: git.Commits{
+							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+							{Hash: "3a1960ec0cec18d2dca14d270d11c5bc4138abf6"},
+						},
+					},
 				},
 			},
 			expectedExitCode: command.Success,
@@ -349,6 +405,12 @@ func TestCommand_exec(t *testing.T) {
 							{Hash: "3a1960ec0cec18d2dca14d270d11c5bc4138abf6"},
 						},
 					},
+					{
+						OutCommits: git.Commits{
+							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+							{Hash: "3a1960ec0cec18d2dca14d270d11c5bc4138abf6"},
+						},
+					},
 				},
 			},
 			expectedExitCode: command.Success,
@@ -385,6 +447,12 @@ func TestCommand_exec(t *testing.T) {
 							{Hash: "3a1960ec0cec18d2dca14d270d11c5bc4138abf6"},
 						},
 					},
+					{
+						OutCommits: git.Commits{
+							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+							{Hash: "3a1960ec0cec18d2dca14d270d11c5bc4138abf6"},
+						},
+					},
 				},
 			},
 			expectedExitCode: command.Success,
@@ -452,19 +520,522 @@ func TestCommand_exec(t *testing.T) {
 							},
 						},
 					},
+					{
+						OutCommits: git.Commits{
+							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+							{Hash: "3a1960ec0cec18d2dca14d270d11c5bc4138abf6"},
+						},
+					},
 				},
 			},
 			expectedExitCode: command.Success,
 			expectedSemver:   "0.1.1-2.605a46c",
 		},
+		{
+			name: "WithPrereleaseTag_WithoutNewCommits_WorkingTreeClean",
+			gitStatus: func(context.Context, ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			gitRevSHA: func(context.Context, ...string) (int, string, error) {
+				return 0, "605a46c79d2500fef8d34145e4831624a7244bd1", nil
+			},
+			git: &MockGitService{
+				TagsMocks: []TagsMock{
+					{
+						OutTags: git.Tags{
+							{
+								Name:   "v0.2.0-rc.1",
+								Commit: git.Commit{Hash: "605a46c79d2500fef8d34145e4831624a7244bd1"},
+							},
+							{
+								Name:   "v0.1.0",
+								Commit: git.Commit{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+							},
+						},
+					},
+				},
+				CommitsInMocks: []CommitsInMock{
+					{
+						OutCommits: git.Commits{
+							{Hash: "605a46c79d2500fef8d34145e4831624a7244bd1"},
+							{Hash: "7fa23333fbc158af08d5b8073fa4828addde9c6b"},
+							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+						},
+					},
+					{
+						OutCommits: git.Commits{
+							{Hash: "605a46c79d2500fef8d34145e4831624a7244bd1"},
+							{Hash: "7fa23333fbc158af08d5b8073fa4828addde9c6b"},
+							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+						},
+					},
+				},
+			},
+			expectedExitCode: command.Success,
+			expectedSemver:   "0.2.0-rc.1",
+		},
+		{
+			name: "WithPrereleaseTag_WithNewCommits_WorkingTreeClean",
+			gitStatus: func(context.Context, ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			gitRevSHA: func(context.Context, ...string) (int, string, error) {
+				return 0, "605a46c79d2500fef8d34145e4831624a7244bd1", nil
+			},
+			git: &MockGitService{
+				TagsMocks: []TagsMock{
+					{
+						OutTags: git.Tags{
+							{
+								Name:   "v0.2.0-rc.1",
+								Commit: git.Commit{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+							},
+						},
+					},
+				},
+				CommitsInMocks: []CommitsInMock{
+					{
+						OutCommits: git.Commits{
+							{Hash: "605a46c79d2500fef8d34145e4831624a7244bd1"},
+							{Hash: "7fa23333fbc158af08d5b8073fa4828addde9c6b"},
+							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+						},
+					},
+					{
+						OutCommits: git.Commits{
+							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+						},
+					},
+				},
+			},
+			expectedExitCode: command.Success,
+			expectedSemver:   "0.2.0-rc.1.2.605a46c",
+		},
+
+		{
+			name: "InvalidBumps",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Release: spec.Release{
+						Bumps: map[string]string{"feat": "huge"},
+					},
+				},
+			},
+			gitStatus: func(context.Context, ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			gitRevSHA: func(context.Context, ...string) (int, string, error) {
+				return 0, "605a46c79d2500fef8d34145e4831624a7244bd1", nil
+			},
+			git: &MockGitService{
+				TagsMocks: []TagsMock{
+					{OutTags: git.Tags{}},
+				},
+				CommitsInMocks: []CommitsInMock{
+					{
+						OutCommits: git.Commits{
+							{Hash: "605a46c79d2500fef8d34145e4831624a7244bd1"},
+						},
+					},
+				},
+			},
+			expectedExitCode: command.SpecError,
+		},
+		{
+			name:   "Module_GitCommitsTouchingFails",
+			module: "services/billing",
+			gitStatus: func(context.Context, ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			gitRevSHA: func(context.Context, ...string) (int, string, error) {
+				return 0, "605a46c79d2500fef8d34145e4831624a7244bd1", nil
+			},
+			git: &MockGitService{
+				TagsMocks: []TagsMock{
+					{OutTags: git.Tags{}},
+				},
+				CommitsInMocks: []CommitsInMock{
+					{
+						OutCommits: git.Commits{
+							{Hash: "605a46c79d2500fef8d34145e4831624a7244bd1"},
+						},
+					},
+				},
+				CommitsTouchingMocks: []CommitsTouchingMock{
+					{OutError: errors.New("git error")},
+				},
+			},
+			expectedExitCode: command.GitError,
+		},
+		{
+			name:   "Module_WithPrefixedTags_WithNewCommits",
+			module: "services/billing",
+			gitStatus: func(context.Context, ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			gitRevSHA: func(context.Context, ...string) (int, string, error) {
+				return 0, "605a46c79d2500fef8d34145e4831624a7244bd1", nil
+			},
+			git: &MockGitService{
+				TagsMocks: []TagsMock{
+					{
+						OutTags: git.Tags{
+							{
+								Name: "v0.5.0",
+								Commit: git.Commit{
+									Hash: "7fa23333fbc158af08d5b8073fa4828addde9c6b",
+									Committer: git.Signature{
+										Time: time.Date(2020, time.November, 15, 12, 0, 0, 0, time.UTC),
+									},
+								},
+							},
+							{
+								Name: "services/billing/v0.2.0",
+								Commit: git.Commit{
+									Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14",
+									Committer: git.Signature{
+										Time: time.Date(2020, time.November, 10, 12, 0, 0, 0, time.UTC),
+									},
+								},
+							},
+						},
+					},
+				},
+				CommitsInMocks: []CommitsInMock{
+					{
+						OutCommits: git.Commits{
+							{
+								Hash: "605a46c79d2500fef8d34145e4831624a7244bd1",
+								Committer: git.Signature{
+									Time: time.Date(2020, time.November, 20, 12, 0, 0, 0, time.UTC),
+								},
+							},
+							{
+								Hash: "7fa23333fbc158af08d5b8073fa4828addde9c6b",
+								Committer: git.Signature{
+									Time: time.Date(2020, time.November, 15, 12, 0, 0, 0, time.UTC),
+								},
+							},
+							{
+								Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14",
+								Committer: git.Signature{
+									Time: time.Date(2020, time.November, 10, 12, 0, 0, 0, time.UTC),
+								},
+							},
+							{
+								Hash: "3a1960ec0cec18d2dca14d270d11c5bc4138abf6",
+								Committer: git.Signature{
+									Time: time.Date(2020, time.November, 5, 12, 0, 0, 0, time.UTC),
+								},
+							},
+						},
+					},
+					{
+						OutCommits: git.Commits{
+							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+							{Hash: "3a1960ec0cec18d2dca14d270d11c5bc4138abf6"},
+						},
+					},
+				},
+				CommitsTouchingMocks: []CommitsTouchingMock{
+					{
+						// The release commit does not change the module directory
+						OutCommits: git.Commits{
+							{
+								Hash: "605a46c79d2500fef8d34145e4831624a7244bd1",
+								Committer: git.Signature{
+									Time: time.Date(2020, time.November, 20, 12, 0, 0, 0, time.UTC),
+								},
+							},
+							{
+								Hash: "3a1960ec0cec18d2dca14d270d11c5bc4138abf6",
+								Committer: git.Signature{
+									Time: time.Date(2020, time.November, 5, 12, 0, 0, 0, time.UTC),
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedExitCode: command.Success,
+			expectedSemver:   "0.2.1-1.605a46c",
+		},
+		{
+			name:   "Module_WithTagNotTouchingModule",
+			module: "services/billing",
+			gitStatus: func(context.Context, ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			gitRevSHA: func(context.Context, ...string) (int, string, error) {
+				return 0, "605a46c79d2500fef8d34145e4831624a7244bd1", nil
+			},
+			git: &MockGitService{
+				TagsMocks: []TagsMock{
+					{
+						OutTags: git.Tags{
+							{
+								Name:   "services/billing/v0.2.0",
+								Commit: git.Commit{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+							},
+						},
+					},
+				},
+				CommitsInMocks: []CommitsInMock{
+					{
+						OutCommits: git.Commits{
+							{Hash: "605a46c79d2500fef8d34145e4831624a7244bd1"},
+							{Hash: "7fa23333fbc158af08d5b8073fa4828addde9c6b"},
+							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+							{Hash: "3a1960ec0cec18d2dca14d270d11c5bc4138abf6"},
+						},
+					},
+					{
+						OutCommits: git.Commits{
+							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+							{Hash: "3a1960ec0cec18d2dca14d270d11c5bc4138abf6"},
+						},
+					},
+				},
+				CommitsTouchingMocks: []CommitsTouchingMock{
+					{
+						// The tag commit does not change the module directory and all module commits are after it
+						OutCommits: git.Commits{
+							{Hash: "605a46c79d2500fef8d34145e4831624a7244bd1", Message: "fix: fix a bug"},
+							{Hash: "7fa23333fbc158af08d5b8073fa4828addde9c6b", Message: "feat: add a feature"},
+						},
+					},
+				},
+			},
+			expectedExitCode: command.Success,
+			expectedSemver:   "0.2.1-2.605a46c",
+			expectedBump:     semver.Minor,
+		},
+		{
+			name: "WithTags_GitCommitsInTagFails",
+			gitStatus: func(context.Context, ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			gitRevSHA: func(context.Context, ...string) (int, string, error) {
+				return 0, "605a46c79d2500fef8d34145e4831624a7244bd1", nil
+			},
+			git: &MockGitService{
+				TagsMocks: []TagsMock{
+					{
+						OutTags: git.Tags{
+							{
+								Name:   "v0.1.0",
+								Commit: git.Commit{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+							},
+						},
+					},
+				},
+				CommitsInMocks: []CommitsInMock{
+					{
+						OutCommits: git.Commits{
+							{Hash: "605a46c79d2500fef8d34145e4831624a7244bd1"},
+							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+						},
+					},
+					{OutError: errors.New("git error")},
+				},
+			},
+			expectedExitCode: command.GitError,
+		},
+		{
+			name: "WithTags_WithTagNotReachable",
+			gitStatus: func(context.Context, ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			gitRevSHA: func(context.Context, ...string) (int, string, error) {
+				return 0, "605a46c79d2500fef8d34145e4831624a7244bd1", nil
+			},
+			git: &MockGitService{
+				TagsMocks: []TagsMock{
+					{
+						OutTags: git.Tags{
+							{
+								// Tag on another branch
+								Name: "v2.0.0",
+								Commit: git.Commit{
+									Hash: "9df3723fd334bbff67db8149e6e0893769d5a9d3",
+									Committer: git.Signature{
+										Time: time.Date(2020, time.November, 15, 12, 0, 0, 0, time.UTC),
+									},
+								},
+							},
+							{
+								Name: "v1.4.2",
+								Commit: git.Commit{
+									Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14",
+									Committer: git.Signature{
+										Time: time.Date(2020, time.November, 10, 12, 0, 0, 0, time.UTC),
+									},
+								},
+							},
+						},
+					},
+				},
+				CommitsInMocks: []CommitsInMock{
+					{
+						OutCommits: git.Commits{
+							{
+								Hash: "605a46c79d2500fef8d34145e4831624a7244bd1",
+								Committer: git.Signature{
+									Time: time.Date(2020, time.November, 20, 12, 0, 0, 0, time.UTC),
+								},
+							},
+							{
+								Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14",
+								Committer: git.Signature{
+									Time: time.Date(2020, time.November, 10, 12, 0, 0, 0, time.UTC),
+								},
+							},
+						},
+					},
+					{
+						OutCommits: git.Commits{
+							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+						},
+					},
+				},
+			},
+			expectedExitCode: command.Success,
+			expectedSemver:   "1.4.3-1.605a46c",
+		},
+		{
+			name:     "Next_WithTags_WithoutNewCommits",
+			nextFlag: true,
+			gitStatus: func(context.Context, ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			gitRevSHA: func(context.Context, ...string) (int, string, error) {
+				return 0, "605a46c79d2500fef8d34145e4831624a7244bd1", nil
+			},
+			git: &MockGitService{
+				TagsMocks: []TagsMock{
+					{
+						OutTags: git.Tags{
+							{
+								Name:   "v0.1.0",
+								Commit: git.Commit{Hash: "605a46c79d2500fef8d34145e4831624a7244bd1"},
+							},
+						},
+					},
+				},
+				CommitsInMocks: []CommitsInMock{
+					{
+						OutCommits: git.Commits{
+							{Hash: "605a46c79d2500fef8d34145e4831624a7244bd1", Message: "feat: initial features"},
+						},
+					},
+					{
+						OutCommits: git.Commits{
+							{Hash: "605a46c79d2500fef8d34145e4831624a7244bd1"},
+						},
+					},
+				},
+			},
+			expectedExitCode: command.Success,
+			expectedSemver:   "0.1.1",
+			expectedBump:     semver.Patch,
+		},
+		{
+			name:     "Next_WithTags_WithNewCommits",
+			nextFlag: true,
+			gitStatus: func(context.Context, ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			gitRevSHA: func(context.Context, ...string) (int, string, error) {
+				return 0, "605a46c79d2500fef8d34145e4831624a7244bd1", nil
+			},
+			git: &MockGitService{
+				TagsMocks: []TagsMock{
+					{
+						OutTags: git.Tags{
+							{
+								Name:   "v0.1.0",
+								Commit: git.Commit{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+							},
+						},
+					},
+				},
+				CommitsInMocks: []CommitsInMock{
+					{
+						OutCommits: git.Commits{
+							{Hash: "605a46c79d2500fef8d34145e4831624a7244bd1", Message: "fix: fix a bug"},
+							{Hash: "7fa23333fbc158af08d5b8073fa4828addde9c6b", Message: "feat: add a feature"},
+							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14", Message: "feat!: initial release"},
+						},
+					},
+					{
+						OutCommits: git.Commits{
+							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+						},
+					},
+				},
+			},
+			expectedExitCode: command.Success,
+			expectedSemver:   "0.2.0",
+			expectedBump:     semver.Minor,
+		},
+		{
+			name: "Next_WithPrereleaseTag_CustomBumps",
+			spec: spec.Spec{
+				Project: spec.Project{
+					Release: spec.Release{
+						Bumps: map[string]string{"docs": "patch"},
+					},
+				},
+			},
+			nextFlag: true,
+			gitStatus: func(context.Context, ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			gitRevSHA: func(context.Context, ...string) (int, string, error) {
+				return 0, "605a46c79d2500fef8d34145e4831624a7244bd1", nil
+			},
+			git: &MockGitService{
+				TagsMocks: []TagsMock{
+					{
+						OutTags: git.Tags{
+							{
+								Name:   "v0.2.0-rc.1",
+								Commit: git.Commit{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+							},
+						},
+					},
+				},
+				CommitsInMocks: []CommitsInMock{
+					{
+						OutCommits: git.Commits{
+							{Hash: "605a46c79d2500fef8d34145e4831624a7244bd1", Message: "docs: update docs"},
+							{Hash: "7fa23333fbc158af08d5b8073fa4828addde9c6b", Message: "feat: add a feature"},
+							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14", Message: "feat: add a feature"},
+						},
+					},
+					{
+						OutCommits: git.Commits{
+							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+						},
+					},
+				},
+			},
+			expectedExitCode: command.Success,
+			expectedSemver:   "0.2.0",
+			expectedBump:     semver.Patch,
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			c := &Command{
-				ui: ui.NewNop(),
+				ui:   ui.NewNop(),
+				spec: tc.spec,
 			}
 
+			c.flags.next = tc.nextFlag
+			c.data.module = tc.module
+
 			c.funcs.gitStatus = tc.gitStatus
 			c.funcs.gitRevSHA = tc.gitRevSHA
 			c.services.git = tc.git
@@ -475,6 +1046,7 @@ func TestCommand_exec(t *testing.T) {
 
 			if tc.expectedExitCode == command.Success {
 				assert.Equal(t, tc.expectedSemver, c.outputs.semver.String())
+				assert.Equal(t, tc.expectedBump, c.outputs.bump)
 			} else {
 				assert.Empty(t, c.outputs.semver)
 			}
@@ -493,3 +1065,84 @@ func TestCommand_SemVer(t *testing.T) {
 
 	assert.Equal(t, smv, c.SemVer())
 }
+
+func TestCommand_Bump(t *testing.T) {
+	commits := git.Commits{
+		{Hash: "605a46c79d2500fef8d34145e4831624a7244bd1", Message: "feat: add a feature"},
+	}
+
+	c := new(Command)
+	c.outputs.bump = semver.Minor
+	c.outputs.bumpCommits = commits
+
+	bump, bumpCommits := c.Bump()
+
+	assert.Equal(t, semver.Minor, bump)
+	assert.Equal(t, commits, bumpCommits)
+}
+
+func TestDetectBump(t *testing.T) {
+	fix := git.Commit{Hash: "605a46c79d2500fef8d34145e4831624a7244bd1", Message: "fix: fix a bug"}
+	feat1 := git.Commit{Hash: "7fa23333fbc158af08d5b8073fa4828addde9c6b", Message: "feat(cli): add a flag"}
+	feat2 := git.Commit{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14", Message: "feat: add a command"}
+	breaking := git.Commit{Hash: "c414d1004154c6c324bd78c69d10ee101e676059", Message: "refactor: remove a flag\n\nBREAKING CHANGE: the flag is removed."}
+	docs := git.Commit{Hash: "25aa2bdbaf10fa30b6db40c2c0a15d280ad9f378", Message: "Update README"}
+
+	tests := []struct {
+		name            string
+		commits         git.Commits
+		bumps           map[string]semver.Bump
+		expectedBump    semver.Bump
+		expectedCommits git.Commits
+	}{
+		{
+			name:         "NoCommit",
+			commits:      git.Commits{},
+			bumps:        semver.DefaultBumps,
+			expectedBump: semver.Patch,
+		},
+		{
+			name:         "NoReleaseCommit",
+			commits:      git.Commits{docs},
+			bumps:        semver.DefaultBumps,
+			expectedBump: semver.Patch,
+		},
+		{
+			name:            "Patch",
+			commits:         git.Commits{docs, fix},
+			bumps:           semver.DefaultBumps,
+			expectedBump:    semver.Patch,
+			expectedCommits: git.Commits{fix},
+		},
+		{
+			name:            "Minor",
+			commits:         git.Commits{feat1, fix, feat2},
+			bumps:           semver.DefaultBumps,
+			expectedBump:    semver.Minor,
+			expectedCommits: git.Commits{feat1, feat2},
+		},
+		{
+			name:            "Major",
+			commits:         git.Commits{feat1, breaking, fix},
+			bumps:           semver.DefaultBumps,
+			expectedBump:    semver.Major,
+			expectedCommits: git.Commits{breaking},
+		},
+		{
+			name:            "CustomBumps",
+			commits:         git.Commits{feat1, fix},
+			bumps:           map[string]semver.Bump{"fix": semver.Minor},
+			expectedBump:    semver.Minor,
+			expectedCommits: git.Commits{fix},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			bump, commits := detectBump(tc.commits, tc.bumps)
+
+			assert.Equal(t, tc.expectedBump, bump)
+			assert.Equal(t, tc.expectedCommits, commits)
+		})
+	}
+}
@@ -20,6 +20,7 @@ type (
 		InReleaseTag string
 		InAssetName  string
 		InWriter     io.Writer
+		OutContent   string
 		OutResponse  *github.Response
 		OutError     error
 	}
@@ -47,5 +48,6 @@ func (m *MockReleaseService) DownloadAsset(ctx context.Context, releaseTag, asse
 	m.DownloadAssetMocks[i].InReleaseTag = releaseTag
 	m.DownloadAssetMocks[i].InAssetName = assetName
 	m.DownloadAssetMocks[i].InWriter = writer
+	_, _ = io.WriteString(writer, m.DownloadAssetMocks[i].OutContent)
 	return m.DownloadAssetMocks[i].OutResponse, m.DownloadAssetMocks[i].OutError
 }
@@ -2,6 +2,7 @@
 package update
 
 import (
+	"bytes"
 	"context"
 	"flag"
 	"fmt"
@@ -14,6 +15,7 @@ import (
 	"github.com/gardenbed/go-github"
 	"github.com/mitchellh/cli"
 
+	"github.com/gardenbed/basil-cli/internal/checksum"
 	"github.com/gardenbed/basil-cli/internal/command"
 	"github.com/gardenbed/basil-cli/internal/config"
 	"github.com/gardenbed/basil-cli/internal/ui"
@@ -24,17 +26,24 @@ const (
 	synopsis = `Update Basil`
 	help     = `
   Use this command for updating basil to the latest release.
+  The downloaded binary is verified against the SHA-256 checksums of the release before installing it.
+  Releases published without checksums are not installed, unless verification is explicitly skipped with -skip-verify.
 
-  Usage:  basil update
+  Usage:  basil update [flags]
+
+  Flags:
+    -skip-verify    install a release published without checksums without verifying it
 
   Examples:
     basil update
+    basil update -skip-verify
   `
 )
 
 const (
-	owner = "gardenbed"
-	repo  = "basil-cli"
+	owner         = "gardenbed"
+	repo          = "basil-cli"
+	checksumsFile = "checksums.txt"
 )
 
 type (
@@ -46,8 +55,11 @@ type (
 
 // Command is the cli.Command implementation for update command.
 type Command struct {
-	ui       ui.UI
-	config   config.Config
+	ui     ui.UI
+	config config.Config
+	flags  struct {
+		skipVerify bool
+	}
 	services struct {
 		releases releaseService
 	}
@@ -94,6 +106,7 @@ func (c *Command) Run(args []string) int {
 
 func (c *Command) parseFlags(args []string) int {
 	fs := flag.NewFlagSet("update", flag.ContinueOnError)
+	fs.BoolVar(&c.flags.skipVerify, "skip-verify", false, "")
 
 	fs.Usage = func() {
 		c.ui.Printf(c.Help())
@@ -134,17 +147,62 @@ func (c *Command) exec() int {
 
 	assetName := fmt.Sprintf("basil-%s-%s", runtime.GOOS, runtime.GOARCH)
 
-	f, err := os.OpenFile(binPath, os.O_WRONLY, 0755)
+	// Older releases are published without checksums and they are installed only if verification is explicitly skipped
+	verify := hasAsset(release, checksumsFile)
+	if !verify && !c.flags.skipVerify {
+		c.ui.Errorf(ui.Red, "The release %s has no %s, so Basil binary cannot be verified (use -skip-verify to install it anyway).", release.TagName, checksumsFile)
+		return command.ChecksumError
+	}
+
+	checksumsBuf := new(bytes.Buffer)
+	if verify {
+		if _, err := c.services.releases.DownloadAsset(ctx, release.TagName, checksumsFile, checksumsBuf); err != nil {
+			c.ui.Errorf(ui.Red, "Failed to download the checksums: %s", err)
+			return command.GitHubError
+		}
+	}
+
+	binBuf := new(bytes.Buffer)
+	if _, err := c.services.releases.DownloadAsset(ctx, release.TagName, assetName, binBuf); err != nil {
+		c.ui.Errorf(ui.Red, "Failed to download Basil binary: %s", err)
+		return command.GitHubError
+	}
+
+	// ==============================> VERIFY THE DOWNLOADED BINARY <==============================
+
+	if verify {
+		checksums, err := checksum.Parse(checksumsBuf)
+		if err != nil {
+			c.ui.Errorf(ui.Red, "Failed to read the checksums: %s", err)
+			return command.ChecksumError
+		}
+
+		sum, err := checksum.Sum(checksum.SHA256, bytes.NewReader(binBuf.Bytes()))
+		if err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.ChecksumError
+		}
+
+		if err := checksums.Verify(assetName, sum); err != nil {
+			c.ui.Errorf(ui.Red, "Failed to verify Basil binary: %s", err)
+			return command.ChecksumError
+		}
+	} else {
+		c.ui.Warnf(ui.Yellow, "The release %s has no %s, so Basil binary is installed without verification.", release.TagName, checksumsFile)
+	}
+
+	// ==============================> INSTALL THE LATEST BINARY <==============================
+
+	f, err := os.OpenFile(binPath, os.O_WRONLY|os.O_TRUNC, 0755)
 	if err != nil {
 		c.ui.Errorf(ui.Red, "Cannot open file for writing: %s", err)
 		return command.OSError
 	}
 
-	_, err = c.services.releases.DownloadAsset(ctx, release.TagName, assetName, f)
-	if err != nil {
-		c.ui.Errorf(ui.Red, "Failed to download and update Basil binary: %s", err)
+	if _, err := binBuf.WriteTo(f); err != nil {
+		c.ui.Errorf(ui.Red, "Failed to update Basil binary: %s", err)
 		_ = f.Close() // Ignore error here since we are already failing.
-		return command.GitHubError
+		return command.OSError
 	}
 
 	if err := f.Close(); err != nil {
@@ -158,3 +216,14 @@ func (c *Command) exec() int {
 
 	return command.Success
 }
+
+// hasAsset determines whether or not a release has an asset with the given name.
+func hasAsset(release *github.Release, name string) bool {
+	for _, asset := range release.Assets {
+		if asset.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
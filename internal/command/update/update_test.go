@@ -2,7 +2,9 @@ package update
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"runtime"
 	"testing"
 
 	"github.com/gardenbed/go-github"
@@ -76,6 +78,11 @@ func TestCommand_parseFlags(t *testing.T) {
 			args:             []string{},
 			expectedExitCode: command.Success,
 		},
+		{
+			name:             "ValidFlags",
+			args:             []string{"-skip-verify"},
+			expectedExitCode: command.Success,
+		},
 	}
 
 	for _, tc := range tests {
@@ -100,8 +107,14 @@ func TestCommand_exec(t *testing.T) {
 		assert.Equal(t, command.OSError, c.exec())
 	})
 
+	// SHA-256 checksum of "binary"
+	assetName := fmt.Sprintf("basil-%s-%s", runtime.GOOS, runtime.GOARCH)
+	checksums := "9a3a45d01531a20e89ac6ae10b0b0beb0492acd7216a368aa062d1a5fecaf9cd  " + assetName + "\n"
+	assets := []github.ReleaseAsset{{Name: checksumsFile}, {Name: assetName}}
+
 	tests := []struct {
 		name             string
+		skipVerify       bool
 		releases         *MockReleaseService
 		expectedExitCode int
 	}{
@@ -114,6 +127,25 @@ func TestCommand_exec(t *testing.T) {
 			},
 			expectedExitCode: command.GitHubError,
 		},
+		{
+			name: "DownloadChecksumsFails",
+			releases: &MockReleaseService{
+				LatestMocks: []LatestMock{
+					{
+						OutRelease: &github.Release{
+							Name:    "1.0.0",
+							TagName: "v1.0.0",
+							Assets:  assets,
+						},
+						OutResponse: &github.Response{},
+					},
+				},
+				DownloadAssetMocks: []DownloadAssetMock{
+					{OutError: errors.New("error on downloading the release asset")},
+				},
+			},
+			expectedExitCode: command.GitHubError,
+		},
 		{
 			name: "DownloadAssetFails",
 			releases: &MockReleaseService{
@@ -122,16 +154,58 @@ func TestCommand_exec(t *testing.T) {
 						OutRelease: &github.Release{
 							Name:    "1.0.0",
 							TagName: "v1.0.0",
+							Assets:  assets,
 						},
 						OutResponse: &github.Response{},
 					},
 				},
 				DownloadAssetMocks: []DownloadAssetMock{
+					{OutContent: checksums, OutResponse: &github.Response{}},
 					{OutError: errors.New("error on downloading the release asset")},
 				},
 			},
 			expectedExitCode: command.GitHubError,
 		},
+		{
+			name: "InvalidChecksums",
+			releases: &MockReleaseService{
+				LatestMocks: []LatestMock{
+					{
+						OutRelease: &github.Release{
+							Name:    "1.0.0",
+							TagName: "v1.0.0",
+							Assets:  assets,
+						},
+						OutResponse: &github.Response{},
+					},
+				},
+				DownloadAssetMocks: []DownloadAssetMock{
+					{OutContent: "invalid", OutResponse: &github.Response{}},
+					{OutContent: "binary", OutResponse: &github.Response{}},
+				},
+			},
+			expectedExitCode: command.ChecksumError,
+		},
+		{
+			name: "ChecksumMismatch",
+			releases: &MockReleaseService{
+				LatestMocks: []LatestMock{
+					{
+						OutRelease: &github.Release{
+							Name:    "1.0.0",
+							TagName: "v1.0.0",
+							Assets:  assets,
+						},
+						OutResponse: &github.Response{},
+					},
+				},
+				DownloadAssetMocks: []DownloadAssetMock{
+					{OutContent: checksums, OutResponse: &github.Response{}},
+					{OutContent: "tampered", OutResponse: &github.Response{}},
+				},
+			},
+			expectedExitCode: command.ChecksumError,
+		},
 		{
 			name: "Success",
 			releases: &MockReleaseService{
@@ -140,15 +214,51 @@ func TestCommand_exec(t *testing.T) {
 						OutRelease: &github.Release{
 							Name:    "1.0.0",
 							TagName: "v1.0.0",
+							Assets:  assets,
 						},
 						OutResponse: &github.Response{},
 					},
 				},
 				DownloadAssetMocks: []DownloadAssetMock{
+					{OutContent: checksums, OutResponse: &github.Response{}},
+					{OutContent: "binary", OutResponse: &github.Response{}},
+				},
+			},
+			expectedExitCode: command.Success,
+		},
+		{
+			name: "NoChecksums",
+			releases: &MockReleaseService{
+				LatestMocks: []LatestMock{
+					{
+						OutRelease: &github.Release{
+							Name:    "0.9.0",
+							TagName: "v0.9.0",
+							Assets:  []github.ReleaseAsset{{Name: assetName}},
+						},
+						OutResponse: &github.Response{},
+					},
+				},
+			},
+			expectedExitCode: command.ChecksumError,
+		},
+		{
+			name:       "NoChecksums_SkipVerify",
+			skipVerify: true,
+			releases: &MockReleaseService{
+				LatestMocks: []LatestMock{
 					{
+						OutRelease: &github.Release{
+							Name:    "0.9.0",
+							TagName: "v0.9.0",
+							Assets:  []github.ReleaseAsset{{Name: assetName}},
+						},
 						OutResponse: &github.Response{},
 					},
 				},
+				DownloadAssetMocks: []DownloadAssetMock{
+					{OutContent: "binary", OutResponse: &github.Response{}},
+				},
 			},
 			expectedExitCode: command.Success,
 		},
@@ -182,11 +292,18 @@ func TestCommand_exec(t *testing.T) {
 				ui: ui.NewNop(),
 			}
 
+			c.flags.skipVerify = tc.skipVerify
 			c.services.releases = tc.releases
 
 			exitCode := c.exec()
 
 			assert.Equal(t, tc.expectedExitCode, exitCode)
+
+			if tc.expectedExitCode == command.Success {
+				b, err := os.ReadFile(f.Name())
+				assert.NoError(t, err)
+				assert.Equal(t, "binary", string(b))
+			}
 		})
 	}
 }
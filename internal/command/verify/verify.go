@@ -0,0 +1,186 @@
+// Package verify implements the command for verifying downloaded release artifacts.
+package verify
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gardenbed/charm/shell"
+	"github.com/mitchellh/cli"
+
+	"github.com/gardenbed/basil-cli/internal/checksum"
+	"github.com/gardenbed/basil-cli/internal/command"
+	"github.com/gardenbed/basil-cli/internal/ui"
+)
+
+const (
+	timeout  = time.Minute
+	synopsis = `Verify a release artifact`
+	help     = `
+  Use this command for verifying a downloaded release artifact against its gpg signature.
+  The public key of the signer should be already imported into the local gpg keyring.
+  Optionally, the artifact can also be verified against a checksums file (sha256sum format).
+
+  Usage:  basil verify [flags] <artifact>
+
+  Flags:
+    -signature    the detached signature of the artifact (default: <artifact>.asc)
+    -checksums    the checksums file for verifying the checksum of the artifact (e.g. checksums.txt)
+
+  Examples:
+    basil verify basil-linux-amd64
+    basil verify -signature basil-linux-amd64.sig basil-linux-amd64
+    basil verify -checksums checksums.txt basil-linux-amd64
+  `
+)
+
+const (
+	signatureExt = ".asc"
+)
+
+// Command is the cli.Command implementation for verify command.
+type Command struct {
+	ui    ui.UI
+	flags struct {
+		signature string
+		checksums string
+	}
+	args struct {
+		artifact string
+	}
+	funcs struct {
+		gpgVerify shell.RunnerFunc
+	}
+}
+
+// New creates a new command.
+func New(ui ui.UI) *Command {
+	return &Command{
+		ui: ui,
+	}
+}
+
+// NewFactory returns a cli.CommandFactory for creating a new command.
+func NewFactory(ui ui.UI) cli.CommandFactory {
+	return func() (cli.Command, error) {
+		return New(ui), nil
+	}
+}
+
+// Synopsis returns a short one-line synopsis for the command.
+func (c *Command) Synopsis() string {
+	return synopsis
+}
+
+// Help returns a long help text including usage, description, and list of flags for the command.
+func (c *Command) Help() string {
+	return help
+}
+
+// Run runs the actual command with the given command-line arguments.
+// This method is used as a proxy for creating dependencies and the actual command execution is delegated to the run method for testing purposes.
+func (c *Command) Run(args []string) int {
+	if code := c.parseFlags(args); code != command.Success {
+		return code
+	}
+
+	c.funcs.gpgVerify = shell.Runner("gpg", "--verify")
+
+	return c.exec()
+}
+
+func (c *Command) parseFlags(args []string) int {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	fs.StringVar(&c.flags.signature, "signature", "", "")
+	fs.StringVar(&c.flags.checksums, "checksums", "", "")
+
+	fs.Usage = func() {
+		c.ui.Printf(c.Help())
+	}
+
+	if err := fs.Parse(args); err != nil {
+		// In case of error, the error and help will be printed by the Parse method
+		return command.FlagError
+	}
+
+	if fs.NArg() != 1 {
+		c.ui.Errorf(ui.Red, "Exactly one artifact should be specified.")
+		return command.FlagError
+	}
+
+	c.args.artifact = fs.Arg(0)
+
+	if c.flags.signature == "" {
+		c.flags.signature = c.args.artifact + signatureExt
+	}
+
+	return command.Success
+}
+
+// exec in an auxiliary method, so we can test the business logic with mock dependencies.
+func (c *Command) exec() int {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// ==============================> RUN PREFLIGHT CHECKS <==============================
+
+	checklist := command.PreflightChecklist{
+		GPG: true,
+	}
+
+	if _, err := command.RunPreflightChecks(ctx, checklist); err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.PreflightError
+	}
+
+	// ==============================> VERIFY CHECKSUM <==============================
+
+	if c.flags.checksums != "" {
+		f, err := os.Open(c.flags.checksums)
+		if err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.OSError
+		}
+
+		checksums, err := checksum.Parse(f)
+		_ = f.Close()
+		if err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.ChecksumError
+		}
+
+		sum, err := checksum.SumFile(checksum.SHA256, c.args.artifact)
+		if err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.OSError
+		}
+
+		if err := checksums.Verify(filepath.Base(c.args.artifact), sum); err != nil {
+			c.ui.Errorf(ui.Red, "%s", err)
+			return command.ChecksumError
+		}
+
+		c.ui.Infof(ui.Green, "Checksum verified: %s", sum)
+	}
+
+	// ==============================> VERIFY SIGNATURE <==============================
+
+	_, out, err := c.funcs.gpgVerify(ctx, c.flags.signature, c.args.artifact)
+	if err != nil {
+		c.ui.Errorf(ui.Red, "%s", err)
+		return command.GPGError
+	}
+
+	if out != "" {
+		c.ui.Printf("%s", out)
+	}
+
+	// ==============================> DONE <==============================
+
+	c.ui.Infof(ui.Green, "🔏 %s verified against %s", c.args.artifact, c.flags.signature)
+
+	return command.Success
+}
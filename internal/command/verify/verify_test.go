@@ -0,0 +1,196 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gardenbed/charm/shell"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/basil-cli/internal/command"
+	"github.com/gardenbed/basil-cli/internal/ui"
+)
+
+func TestNew(t *testing.T) {
+	ui := ui.NewNop()
+	c := New(ui)
+
+	assert.NotNil(t, c)
+}
+
+func TestNewFactory(t *testing.T) {
+	ui := ui.NewNop()
+	c, err := NewFactory(ui)()
+
+	assert.NoError(t, err)
+	assert.NotNil(t, c)
+}
+
+func TestCommand_Synopsis(t *testing.T) {
+	c := new(Command)
+	synopsis := c.Synopsis()
+
+	assert.NotEmpty(t, synopsis)
+}
+
+func TestCommand_Help(t *testing.T) {
+	c := new(Command)
+	help := c.Help()
+
+	assert.NotEmpty(t, help)
+}
+
+func TestCommand_Run(t *testing.T) {
+	t.Run("InvalidFlag", func(t *testing.T) {
+		c := &Command{ui: ui.NewNop()}
+		exitCode := c.Run([]string{"-undefined"})
+
+		assert.Equal(t, command.FlagError, exitCode)
+	})
+
+	t.Run("OK", func(t *testing.T) {
+		c := &Command{ui: ui.NewNop()}
+		c.Run([]string{"missing"})
+
+		assert.NotNil(t, c.funcs.gpgVerify)
+	})
+}
+
+func TestCommand_parseFlags(t *testing.T) {
+	tests := []struct {
+		name              string
+		args              []string
+		expectedExitCode  int
+		expectedArtifact  string
+		expectedSignature string
+	}{
+		{
+			name:             "InvalidFlag",
+			args:             []string{"-undefined"},
+			expectedExitCode: command.FlagError,
+		},
+		{
+			name:             "NoArtifact",
+			args:             []string{},
+			expectedExitCode: command.FlagError,
+		},
+		{
+			name:             "TooManyArtifacts",
+			args:             []string{"app-linux-amd64", "app-darwin-arm64"},
+			expectedExitCode: command.FlagError,
+		},
+		{
+			name:              "DefaultSignature",
+			args:              []string{"app-linux-amd64"},
+			expectedExitCode:  command.Success,
+			expectedArtifact:  "app-linux-amd64",
+			expectedSignature: "app-linux-amd64.asc",
+		},
+		{
+			name:              "ValidFlags",
+			args:              []string{"-signature", "app.sig", "-checksums", "checksums.txt", "app-linux-amd64"},
+			expectedExitCode:  command.Success,
+			expectedArtifact:  "app-linux-amd64",
+			expectedSignature: "app.sig",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Command{ui: ui.NewNop()}
+			exitCode := c.parseFlags(tc.args)
+
+			assert.Equal(t, tc.expectedExitCode, exitCode)
+
+			if tc.expectedExitCode == command.Success {
+				assert.Equal(t, tc.expectedArtifact, c.args.artifact)
+				assert.Equal(t, tc.expectedSignature, c.flags.signature)
+			}
+		})
+	}
+}
+
+func TestCommand_exec(t *testing.T) {
+	dir := t.TempDir()
+
+	// SHA-256 checksum of "binary"
+	artifact := filepath.Join(dir, "app-linux-amd64")
+	assert.NoError(t, os.WriteFile(artifact, []byte("binary"), 0755))
+
+	validChecksums := filepath.Join(dir, "checksums.txt")
+	assert.NoError(t, os.WriteFile(validChecksums, []byte("9a3a45d01531a20e89ac6ae10b0b0beb0492acd7216a368aa062d1a5fecaf9cd  app-linux-amd64\n"), 0644))
+
+	invalidChecksums := filepath.Join(dir, "invalid.txt")
+	assert.NoError(t, os.WriteFile(invalidChecksums, []byte("invalid\n"), 0644))
+
+	mismatchChecksums := filepath.Join(dir, "mismatch.txt")
+	assert.NoError(t, os.WriteFile(mismatchChecksums, []byte("2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824  app-linux-amd64\n"), 0644))
+
+	tests := []struct {
+		name             string
+		artifact         string
+		checksums        string
+		gpgVerify        shell.RunnerFunc
+		expectedExitCode int
+	}{
+		{
+			name:             "ChecksumsNotFound",
+			artifact:         artifact,
+			checksums:        filepath.Join(dir, "missing.txt"),
+			expectedExitCode: command.OSError,
+		},
+		{
+			name:             "InvalidChecksums",
+			artifact:         artifact,
+			checksums:        invalidChecksums,
+			expectedExitCode: command.ChecksumError,
+		},
+		{
+			name:             "ArtifactNotFound",
+			artifact:         filepath.Join(dir, "missing"),
+			checksums:        validChecksums,
+			expectedExitCode: command.OSError,
+		},
+		{
+			name:             "ChecksumMismatch",
+			artifact:         artifact,
+			checksums:        mismatchChecksums,
+			expectedExitCode: command.ChecksumError,
+		},
+		{
+			name:     "GPGVerifyFails",
+			artifact: artifact,
+			gpgVerify: func(context.Context, ...string) (int, string, error) {
+				return 1, "", errors.New("gpg: BAD signature")
+			},
+			expectedExitCode: command.GPGError,
+		},
+		{
+			name:      "Success",
+			artifact:  artifact,
+			checksums: validChecksums,
+			gpgVerify: func(context.Context, ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			expectedExitCode: command.Success,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Command{ui: ui.NewNop()}
+
+			c.args.artifact = tc.artifact
+			c.flags.signature = tc.artifact + signatureExt
+			c.flags.checksums = tc.checksums
+			c.funcs.gpgVerify = tc.gpgVerify
+
+			exitCode := c.exec()
+
+			assert.Equal(t, tc.expectedExitCode, exitCode)
+		})
+	}
+}
@@ -13,6 +13,7 @@ var configFiles = []string{".basil.yml", ".basil.yaml"}
 // Config is the model for all configurations.
 type Config struct {
 	GitHub GitHub `yaml:"github"`
+	GitLab GitLab `yaml:"gitlab"`
 }
 
 // GitHub has the configurations for GitHub.
@@ -20,6 +21,13 @@ type GitHub struct {
 	AccessToken string `yaml:"access_token"`
 }
 
+// GitLab has the configurations for GitLab.
+// Domain is only required for self-hosted GitLab instances.
+type GitLab struct {
+	Domain      string `yaml:"domain"`
+	AccessToken string `yaml:"access_token"`
+}
+
 func findFile(useDefault bool) string {
 	homeDir, _ := os.UserHomeDir()
 
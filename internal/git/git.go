@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strings"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
@@ -82,6 +83,38 @@ func (g *Git) Path() (string, error) {
 	return worktree.Filesystem.Root(), nil
 }
 
+// Rel returns the slash-separated path of a directory relative to the root path of the Git repository.
+// For the root directory of the repository, it returns an empty string.
+func (g *Git) Rel(path string) (string, error) {
+	root, err := g.Path()
+	if err != nil {
+		return "", err
+	}
+
+	if root, err = filepath.Abs(root); err != nil {
+		return "", err
+	}
+
+	if path, err = filepath.Abs(path); err != nil {
+		return "", err
+	}
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return "", err
+	}
+
+	if rel == "." {
+		return "", nil
+	}
+
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is not in the git repository", path)
+	}
+
+	return filepath.ToSlash(rel), nil
+}
+
 // Remote returns the domain part and path part of a Git remote repository URL.
 // It assumes the remote repository is named origin.
 func (g *Git) Remote(name string) (string, string, error) {
@@ -191,6 +224,44 @@ func (g *Git) CommitsIn(rev string) (Commits, error) {
 	return commits, nil
 }
 
+// CommitsTouching returns all commits reachable from a revision that change a file or directory.
+// The path is slash-separated and relative to the root path of the Git repository.
+func (g *Git) CommitsTouching(rev, path string) (Commits, error) {
+	hash, err := g.repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := g.repo.Log(&git.LogOptions{
+		From: *hash,
+		PathFilter: func(p string) bool {
+			return p == path || strings.HasPrefix(p, path+"/")
+		},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	commits := make([]Commit, 0)
+	err = iter.ForEach(func(c *object.Commit) error {
+		commits = append(commits, toCommit(c))
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Sort commits
+	sort.Slice(commits, func(i, j int) bool {
+		// The order of the commits should be from the most recent to the least recent
+		return commits[i].Committer.After(commits[j].Committer)
+	})
+
+	return commits, nil
+}
+
 func (g *Git) parentCommits(commitsMap map[plumbing.Hash]*object.Commit, h plumbing.Hash) error {
 	if _, ok := commitsMap[h]; ok {
 		return nil
@@ -238,6 +238,50 @@ func TestGit_Path(t *testing.T) {
 	assert.Equal(t, testPath, path)
 }
 
+func TestGit_Rel(t *testing.T) {
+	repo, cleanup, err := setupGitRepo()
+	assert.NoError(t, err)
+	defer cleanup()
+
+	g := &Git{repo: repo}
+
+	tests := []struct {
+		name          string
+		path          string
+		expectedRel   string
+		expectedError string
+	}{
+		{
+			name:        "Root",
+			path:        testPath,
+			expectedRel: "",
+		},
+		{
+			name:        "SubDirectory",
+			path:        testPath + "/services/billing",
+			expectedRel: "services/billing",
+		},
+		{
+			name:          "OutsideRepo",
+			path:          ".",
+			expectedError: "is not in the git repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rel, err := g.Rel(tc.path)
+
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedRel, rel)
+			}
+		})
+	}
+}
+
 func TestGit_Remote(t *testing.T) {
 	repo, cleanup, err := setupGitRepo()
 	assert.NoError(t, err)
@@ -376,3 +420,41 @@ func TestGit_CommitsIn(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, commits, 3)
 }
+
+func TestGit_CommitsTouching(t *testing.T) {
+	repo, cleanup, err := setupGitRepo()
+	assert.NoError(t, err)
+	defer cleanup()
+
+	g := &Git{repo: repo}
+
+	tests := []struct {
+		name            string
+		path            string
+		expectedCommits []string
+	}{
+		{
+			name:            "File",
+			path:            "LICENSE",
+			expectedCommits: []string{"Second commit"},
+		},
+		{
+			name:            "NotFound",
+			path:            "services/billing",
+			expectedCommits: []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			commits, err := g.CommitsTouching("HEAD", tc.path)
+			assert.NoError(t, err)
+
+			messages := []string{}
+			for _, c := range commits {
+				messages = append(messages, c.Message)
+			}
+			assert.Equal(t, tc.expectedCommits, messages)
+		})
+	}
+}
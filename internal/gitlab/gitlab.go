@@ -0,0 +1,236 @@
+// Package gitlab provides a minimal client for GitLab REST API v4.
+// It only covers the APIs required for creating releases.
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	apiPath       = "/api/v4/"
+	headerToken   = "PRIVATE-TOKEN"
+	headerNext    = "X-Next-Page"
+	headerPrev    = "X-Prev-Page"
+	headerTotal   = "X-Total-Pages"
+	contentTypeJS = "application/json"
+)
+
+// Pages represents the pagination information for GitLab API v4.
+type Pages struct {
+	Prev  int
+	Next  int
+	Total int
+}
+
+// Response represents an HTTP response for GitLab API v4.
+type Response struct {
+	*http.Response
+
+	Pages Pages
+}
+
+func newResponse(resp *http.Response) *Response {
+	r := &Response{
+		Response: resp,
+	}
+
+	h := resp.Header
+	r.Pages.Prev, _ = strconv.Atoi(h.Get(headerPrev))
+	r.Pages.Next, _ = strconv.Atoi(h.Get(headerNext))
+	r.Pages.Total, _ = strconv.Atoi(h.Get(headerTotal))
+
+	return r
+}
+
+// ResponseError is a generic error for HTTP calls to GitLab API v4.
+type ResponseError struct {
+	Response *http.Response
+	Message  string
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("%s %s: %d %s",
+		e.Response.Request.Method, e.Response.Request.URL.Path,
+		e.Response.StatusCode, e.Message,
+	)
+}
+
+// IsNotFound determines whether or not an error is a GitLab 404 error.
+func IsNotFound(err error) bool {
+	e, ok := err.(*ResponseError)
+	return ok && e.Response.StatusCode == http.StatusNotFound
+}
+
+// Client is used for making API calls to GitLab API v4.
+type Client struct {
+	httpClient  *http.Client
+	baseURL     *url.URL
+	apiURL      *url.URL
+	accessToken string
+
+	// Services
+	Users *UserService
+}
+
+// NewClient creates a new client for calling GitLab API v4.
+// baseURL is the address of the GitLab instance (i.e. https://gitlab.com).
+func NewClient(baseURL, accessToken string) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid gitlab url: %s", baseURL)
+	}
+
+	apiURL, _ := u.Parse(apiPath)
+
+	c := &Client{
+		httpClient:  &http.Client{},
+		baseURL:     u,
+		apiURL:      apiURL,
+		accessToken: accessToken,
+	}
+
+	c.Users = &UserService{
+		client: c,
+	}
+
+	return c, nil
+}
+
+// WebURL returns the web address of a path on the GitLab instance.
+func (c *Client) WebURL(path string) string {
+	return c.baseURL.String() + "/" + strings.TrimPrefix(path, "/")
+}
+
+// Project creates a new service for a project (repository) by its full path (i.e. group/project).
+func (c *Client) Project(path string) *ProjectService {
+	id := url.PathEscape(path)
+
+	return &ProjectService{
+		client: c,
+		path:   path,
+		id:     id,
+		MergeRequests: &MergeRequestService{
+			client: c,
+			id:     id,
+		},
+		Releases: &ReleaseService{
+			client: c,
+			id:     id,
+		},
+	}
+}
+
+// NewRequest creates a new HTTP request for a GitLab API v4.
+// If body implements the io.Reader interface, the raw request body will be read.
+// Otherwise, the request body will be JSON-encoded.
+func (c *Client) NewRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
+	// The project id is URL-encoded and should not be decoded when resolving the URL.
+	u, err := url.Parse(c.apiURL.String() + strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	var r io.Reader
+	var contentType string
+
+	if body != nil {
+		if br, ok := body.(io.Reader); ok {
+			r = br
+		} else {
+			b, err := json.Marshal(body)
+			if err != nil {
+				return nil, err
+			}
+			r = strings.NewReader(string(b))
+			contentType = contentTypeJS
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), r)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", contentTypeJS)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.accessToken != "" {
+		req.Header.Set(headerToken, c.accessToken)
+	}
+
+	return req, nil
+}
+
+// NewPageRequest creates a new HTTP request for a GitLab API v4 with page parameters.
+func (c *Client) NewPageRequest(ctx context.Context, method, path string, pageSize, pageNo int, body interface{}) (*http.Request, error) {
+	req, err := c.NewRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Add("per_page", strconv.Itoa(pageSize))
+	q.Add("page", strconv.Itoa(pageNo))
+	req.URL.RawQuery = q.Encode()
+
+	return req, nil
+}
+
+// Do makes an HTTP request and returns the API response.
+// The response body will be JSON-decoded into body if it is not nil.
+func (c *Client) Do(req *http.Request, body interface{}) (*Response, error) {
+	r, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		// Ensure we fully read and close the response body, so the underlying TCP connection can be reused.
+		_, _ = io.Copy(io.Discard, r.Body)
+		r.Body.Close()
+	}()
+
+	resp := newResponse(r)
+
+	if r.StatusCode < 200 || r.StatusCode > 299 {
+		respErr := &ResponseError{
+			Response: r,
+		}
+
+		// GitLab returns the error message in either message or error field.
+		var e struct {
+			Message interface{} `json:"message"`
+			Error   string      `json:"error"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&e); err == nil {
+			if e.Message != nil {
+				respErr.Message = fmt.Sprintf("%v", e.Message)
+			} else {
+				respErr.Message = e.Error
+			}
+		}
+
+		return nil, respErr
+	}
+
+	if body != nil {
+		if err := json.NewDecoder(r.Body).Decode(body); err != nil && err != io.EOF {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
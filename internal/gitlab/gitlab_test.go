@@ -0,0 +1,156 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestServer creates a fake GitLab server that serves a single route.
+func newTestServer(t *testing.T, method, path string, statusCode int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, method, r.Method)
+		assert.Equal(t, path, r.URL.EscapedPath())
+		assert.Equal(t, "token", r.Header.Get(headerToken))
+
+		w.Header().Set(headerNext, "2")
+		w.Header().Set(headerTotal, "3")
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+func newTestClient(t *testing.T, ts *httptest.Server) *Client {
+	c, err := NewClient(ts.URL, "token")
+	assert.NoError(t, err)
+	return c
+}
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name          string
+		baseURL       string
+		expectedError string
+	}{
+		{
+			name:          "InvalidURL",
+			baseURL:       ":invalid",
+			expectedError: "missing protocol scheme",
+		},
+		{
+			name:          "NoHost",
+			baseURL:       "gitlab.com",
+			expectedError: "invalid gitlab url: gitlab.com",
+		},
+		{
+			name:    "Success",
+			baseURL: "https://gitlab.com/",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := NewClient(tc.baseURL, "token")
+
+			if tc.expectedError != "" {
+				assert.Nil(t, c)
+				assert.ErrorContains(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, c)
+				assert.NotNil(t, c.Users)
+				assert.Equal(t, "https://gitlab.com/api/v4/", c.apiURL.String())
+			}
+		})
+	}
+}
+
+func TestClient_WebURL(t *testing.T) {
+	c, err := NewClient("https://gitlab.example.com", "")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "https://gitlab.example.com/group/project", c.WebURL("/group/project"))
+}
+
+func TestClient_Project(t *testing.T) {
+	c, err := NewClient("https://gitlab.example.com", "")
+	assert.NoError(t, err)
+
+	p := c.Project("group/project")
+
+	assert.Equal(t, "group%2Fproject", p.id)
+	assert.NotNil(t, p.MergeRequests)
+	assert.NotNil(t, p.Releases)
+}
+
+func TestClient_Do(t *testing.T) {
+	tests := []struct {
+		name          string
+		statusCode    int
+		body          string
+		expectedPages Pages
+		expectedError string
+	}{
+		{
+			name:          "MessageError",
+			statusCode:    http.StatusBadRequest,
+			body:          `{"message": "bad request"}`,
+			expectedError: "GET /api/v4/user: 400 bad request",
+		},
+		{
+			name:          "ErrorField",
+			statusCode:    http.StatusUnauthorized,
+			body:          `{"error": "invalid_token"}`,
+			expectedError: "GET /api/v4/user: 401 invalid_token",
+		},
+		{
+			name:          "InvalidBody",
+			statusCode:    http.StatusOK,
+			body:          `{`,
+			expectedError: "unexpected EOF",
+		},
+		{
+			name:          "Success",
+			statusCode:    http.StatusOK,
+			body:          `{"id": 1}`,
+			expectedPages: Pages{Next: 2, Total: 3},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := newTestServer(t, "GET", "/api/v4/user", tc.statusCode, tc.body)
+			defer ts.Close()
+
+			c := newTestClient(t, ts)
+			req, err := c.NewRequest(context.Background(), "GET", "/user", nil)
+			assert.NoError(t, err)
+
+			var user User
+			resp, err := c.Do(req, &user)
+
+			if tc.expectedError != "" {
+				assert.Nil(t, resp)
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedPages, resp.Pages)
+				assert.Equal(t, 1, user.ID)
+			}
+		})
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	ts := newTestServer(t, "GET", "/api/v4/user", http.StatusNotFound, `{"message": "404 Not Found"}`)
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	_, _, err := c.Users.User(context.Background())
+
+	assert.True(t, IsNotFound(err))
+	assert.False(t, IsNotFound(assert.AnError))
+}
@@ -0,0 +1,170 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// MergeRequestService provides GitLab APIs for merge requests in a project.
+// See https://docs.gitlab.com/ee/api/merge_requests.html
+type MergeRequestService struct {
+	client *Client
+	id     string
+}
+
+type (
+	// MergeRequest is a GitLab merge request object.
+	MergeRequest struct {
+		ID              int        `json:"id"`
+		IID             int        `json:"iid"`
+		State           string     `json:"state"`
+		Title           string     `json:"title"`
+		Description     string     `json:"description"`
+		SourceBranch    string     `json:"source_branch"`
+		TargetBranch    string     `json:"target_branch"`
+		SHA             string     `json:"sha"`
+		MergeCommitSHA  string     `json:"merge_commit_sha"`
+		SquashCommitSHA string     `json:"squash_commit_sha"`
+		WebURL          string     `json:"web_url"`
+		CreatedAt       time.Time  `json:"created_at"`
+		UpdatedAt       time.Time  `json:"updated_at"`
+		MergedAt        *time.Time `json:"merged_at"`
+		HeadPipeline    *Pipeline  `json:"head_pipeline"`
+	}
+
+	// Pipeline is a GitLab pipeline object.
+	Pipeline struct {
+		ID     int    `json:"id"`
+		Status string `json:"status"` // Either created, pending, running, success, failed, canceled, skipped, or manual
+		Ref    string `json:"ref"`
+		SHA    string `json:"sha"`
+		WebURL string `json:"web_url"`
+	}
+
+	// CreateMergeRequestParams is used for creating a merge request.
+	CreateMergeRequestParams struct {
+		Title        string `json:"title"`
+		Description  string `json:"description"`
+		SourceBranch string `json:"source_branch"`
+		TargetBranch string `json:"target_branch"`
+	}
+
+	// UpdateMergeRequestParams is used for updating a merge request.
+	UpdateMergeRequestParams struct {
+		Title        string `json:"title,omitempty"`
+		Description  string `json:"description,omitempty"`
+		TargetBranch string `json:"target_branch,omitempty"`
+		StateEvent   string `json:"state_event,omitempty"` // Either close or reopen
+	}
+
+	// MergeRequestsFilter is used for filtering merge requests.
+	MergeRequestsFilter struct {
+		State  string // Either opened, closed, locked, or merged
+		Search string // Matched against the title
+	}
+)
+
+// IsMerged determines whether or not a merge request is merged.
+func (m MergeRequest) IsMerged() bool {
+	return m.State == "merged"
+}
+
+// CommitSHA returns the commit that a merged merge request has landed on the target branch.
+func (m MergeRequest) CommitSHA() string {
+	switch {
+	case m.MergeCommitSHA != "":
+		return m.MergeCommitSHA
+	case m.SquashCommitSHA != "":
+		return m.SquashCommitSHA
+	default:
+		// Fast-forward merges do not create a new commit.
+		return m.SHA
+	}
+}
+
+// Get retrieves a merge request in the project by its internal id.
+// See https://docs.gitlab.com/ee/api/merge_requests.html#get-single-mr
+func (s *MergeRequestService) Get(ctx context.Context, iid int) (*MergeRequest, *Response, error) {
+	url := fmt.Sprintf("/projects/%s/merge_requests/%d", s.id, iid)
+	req, err := s.client.NewRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mr := new(MergeRequest)
+
+	resp, err := s.client.Do(req, mr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return mr, resp, nil
+}
+
+// List retrieves merge requests in the project page by page.
+// See https://docs.gitlab.com/ee/api/merge_requests.html#list-project-merge-requests
+func (s *MergeRequestService) List(ctx context.Context, pageSize, pageNo int, filter MergeRequestsFilter) ([]MergeRequest, *Response, error) {
+	url := fmt.Sprintf("/projects/%s/merge_requests", s.id)
+	req, err := s.client.NewPageRequest(ctx, "GET", url, pageSize, pageNo, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	q := req.URL.Query()
+	if filter.State != "" {
+		q.Add("state", filter.State)
+	}
+	if filter.Search != "" {
+		q.Add("search", filter.Search)
+		q.Add("in", "title")
+	}
+	req.URL.RawQuery = q.Encode()
+
+	mrs := []MergeRequest{}
+
+	resp, err := s.client.Do(req, &mrs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return mrs, resp, nil
+}
+
+// Create creates a new merge request.
+// See https://docs.gitlab.com/ee/api/merge_requests.html#create-mr
+func (s *MergeRequestService) Create(ctx context.Context, params CreateMergeRequestParams) (*MergeRequest, *Response, error) {
+	url := fmt.Sprintf("/projects/%s/merge_requests", s.id)
+	req, err := s.client.NewRequest(ctx, "POST", url, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mr := new(MergeRequest)
+
+	resp, err := s.client.Do(req, mr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return mr, resp, nil
+}
+
+// Update updates an existing merge request by its internal id.
+// See https://docs.gitlab.com/ee/api/merge_requests.html#update-mr
+func (s *MergeRequestService) Update(ctx context.Context, iid int, params UpdateMergeRequestParams) (*MergeRequest, *Response, error) {
+	url := fmt.Sprintf("/projects/%s/merge_requests/%d", s.id, iid)
+	req, err := s.client.NewRequest(ctx, "PUT", url, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mr := new(MergeRequest)
+
+	resp, err := s.client.Do(req, mr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return mr, resp, nil
+}
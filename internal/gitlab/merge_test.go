@@ -0,0 +1,157 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeRequest_IsMerged(t *testing.T) {
+	assert.True(t, MergeRequest{State: "merged"}.IsMerged())
+	assert.False(t, MergeRequest{State: "opened"}.IsMerged())
+}
+
+func TestMergeRequest_CommitSHA(t *testing.T) {
+	tests := []struct {
+		name        string
+		mr          MergeRequest
+		expectedSHA string
+	}{
+		{
+			name:        "MergeCommit",
+			mr:          MergeRequest{SHA: "aaaa", MergeCommitSHA: "bbbb", SquashCommitSHA: "cccc"},
+			expectedSHA: "bbbb",
+		},
+		{
+			name:        "SquashCommit",
+			mr:          MergeRequest{SHA: "aaaa", SquashCommitSHA: "cccc"},
+			expectedSHA: "cccc",
+		},
+		{
+			name:        "FastForward",
+			mr:          MergeRequest{SHA: "aaaa"},
+			expectedSHA: "aaaa",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedSHA, tc.mr.CommitSHA())
+		})
+	}
+}
+
+func TestMergeRequestService_Get(t *testing.T) {
+	t.Run("Fails", func(t *testing.T) {
+		ts := newTestServer(t, "GET", "/api/v4/projects/group%2Fproject/merge_requests/1", http.StatusNotFound, `{"message": "404 Not found"}`)
+		defer ts.Close()
+
+		mr, _, err := newTestClient(t, ts).Project("group/project").MergeRequests.Get(context.Background(), 1)
+
+		assert.Nil(t, mr)
+		assert.Error(t, err)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		ts := newTestServer(t, "GET", "/api/v4/projects/group%2Fproject/merge_requests/1", http.StatusOK, `{"id": 10, "iid": 1, "state": "merged"}`)
+		defer ts.Close()
+
+		mr, resp, err := newTestClient(t, ts).Project("group/project").MergeRequests.Get(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, &MergeRequest{ID: 10, IID: 1, State: "merged"}, mr)
+	})
+
+	t.Run("WithHeadPipeline", func(t *testing.T) {
+		ts := newTestServer(t, "GET", "/api/v4/projects/group%2Fproject/merge_requests/1", http.StatusOK, `{"id": 10, "iid": 1, "state": "opened", "head_pipeline": {"id": 100, "status": "running"}}`)
+		defer ts.Close()
+
+		mr, resp, err := newTestClient(t, ts).Project("group/project").MergeRequests.Get(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, &MergeRequest{ID: 10, IID: 1, State: "opened", HeadPipeline: &Pipeline{ID: 100, Status: "running"}}, mr)
+	})
+}
+
+func TestMergeRequestService_List(t *testing.T) {
+	t.Run("Fails", func(t *testing.T) {
+		ts := newTestServer(t, "GET", "/api/v4/projects/group%2Fproject/merge_requests", http.StatusForbidden, `{"message": "403 Forbidden"}`)
+		defer ts.Close()
+
+		mrs, _, err := newTestClient(t, ts).Project("group/project").MergeRequests.List(context.Background(), 10, 1, MergeRequestsFilter{})
+
+		assert.Nil(t, mrs)
+		assert.Error(t, err)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		ts := newTestServer(t, "GET", "/api/v4/projects/group%2Fproject/merge_requests", http.StatusOK, `[{"id": 10, "iid": 1, "state": "opened"}]`)
+		defer ts.Close()
+
+		mrs, resp, err := newTestClient(t, ts).Project("group/project").MergeRequests.List(context.Background(), 10, 1, MergeRequestsFilter{
+			State:  "opened",
+			Search: "RELEASE",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "opened", resp.Request.URL.Query().Get("state"))
+		assert.Equal(t, "RELEASE", resp.Request.URL.Query().Get("search"))
+		assert.Equal(t, []MergeRequest{{ID: 10, IID: 1, State: "opened"}}, mrs)
+	})
+}
+
+func TestMergeRequestService_Create(t *testing.T) {
+	t.Run("Fails", func(t *testing.T) {
+		ts := newTestServer(t, "POST", "/api/v4/projects/group%2Fproject/merge_requests", http.StatusConflict, `{"message": ["Another open merge request already exists"]}`)
+		defer ts.Close()
+
+		mr, _, err := newTestClient(t, ts).Project("group/project").MergeRequests.Create(context.Background(), CreateMergeRequestParams{})
+
+		assert.Nil(t, mr)
+		assert.EqualError(t, err, "POST /api/v4/projects/group/project/merge_requests: 409 [Another open merge request already exists]")
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		ts := newTestServer(t, "POST", "/api/v4/projects/group%2Fproject/merge_requests", http.StatusCreated, `{"id": 10, "iid": 1, "state": "opened"}`)
+		defer ts.Close()
+
+		mr, resp, err := newTestClient(t, ts).Project("group/project").MergeRequests.Create(context.Background(), CreateMergeRequestParams{
+			Title:        "RELEASE 0.1.0",
+			SourceBranch: "release-0.1.0",
+			TargetBranch: "main",
+		})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, &MergeRequest{ID: 10, IID: 1, State: "opened"}, mr)
+	})
+}
+
+func TestMergeRequestService_Update(t *testing.T) {
+	t.Run("Fails", func(t *testing.T) {
+		ts := newTestServer(t, "PUT", "/api/v4/projects/group%2Fproject/merge_requests/1", http.StatusNotFound, `{"message": "404 Not found"}`)
+		defer ts.Close()
+
+		mr, _, err := newTestClient(t, ts).Project("group/project").MergeRequests.Update(context.Background(), 1, UpdateMergeRequestParams{})
+
+		assert.Nil(t, mr)
+		assert.Error(t, err)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		ts := newTestServer(t, "PUT", "/api/v4/projects/group%2Fproject/merge_requests/1", http.StatusOK, `{"id": 10, "iid": 1, "state": "opened", "title": "RELEASE 0.1.0"}`)
+		defer ts.Close()
+
+		mr, resp, err := newTestClient(t, ts).Project("group/project").MergeRequests.Update(context.Background(), 1, UpdateMergeRequestParams{
+			Title: "RELEASE 0.1.0",
+		})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, &MergeRequest{ID: 10, IID: 1, State: "opened", Title: "RELEASE 0.1.0"}, mr)
+	})
+}
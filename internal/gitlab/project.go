@@ -0,0 +1,249 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// AccessLevel is the access level of a project member.
+// See https://docs.gitlab.com/ee/api/members.html#roles
+type AccessLevel int
+
+const (
+	// NoAccess is the no access level.
+	NoAccess AccessLevel = 0
+	// GuestAccess is the guest access level.
+	GuestAccess AccessLevel = 10
+	// ReporterAccess is the reporter access level.
+	ReporterAccess AccessLevel = 20
+	// DeveloperAccess is the developer access level.
+	DeveloperAccess AccessLevel = 30
+	// MaintainerAccess is the maintainer access level.
+	MaintainerAccess AccessLevel = 40
+	// OwnerAccess is the owner access level.
+	OwnerAccess AccessLevel = 50
+)
+
+// ProjectService provides GitLab APIs for a project.
+// See https://docs.gitlab.com/ee/api/projects.html
+type ProjectService struct {
+	client *Client
+	path   string
+	id     string
+
+	// Services
+	MergeRequests *MergeRequestService
+	Releases      *ReleaseService
+}
+
+type (
+	// Project is a GitLab project object.
+	Project struct {
+		ID                int    `json:"id"`
+		Name              string `json:"name"`
+		Path              string `json:"path"`
+		PathWithNamespace string `json:"path_with_namespace"`
+		Description       string `json:"description"`
+		DefaultBranch     string `json:"default_branch"`
+		Visibility        string `json:"visibility"`
+		Archived          bool   `json:"archived"`
+		WebURL            string `json:"web_url"`
+	}
+
+	// Member is a GitLab project member object.
+	Member struct {
+		ID          int         `json:"id"`
+		Username    string      `json:"username"`
+		Name        string      `json:"name"`
+		State       string      `json:"state"`
+		AccessLevel AccessLevel `json:"access_level"`
+	}
+
+	// BranchAccess is an access level for a protected branch.
+	BranchAccess struct {
+		AccessLevel AccessLevel `json:"access_level"`
+	}
+
+	// ProtectedBranch is a GitLab protected branch object.
+	ProtectedBranch struct {
+		ID                int            `json:"id"`
+		Name              string         `json:"name"`
+		PushAccessLevels  []BranchAccess `json:"push_access_levels"`
+		MergeAccessLevels []BranchAccess `json:"merge_access_levels"`
+		AllowForcePush    bool           `json:"allow_force_push"`
+	}
+
+	// ProtectBranchParams is used for protecting a branch.
+	ProtectBranchParams struct {
+		Name             string      `json:"name"`
+		PushAccessLevel  AccessLevel `json:"push_access_level"`
+		MergeAccessLevel AccessLevel `json:"merge_access_level"`
+		AllowForcePush   bool        `json:"allow_force_push"`
+	}
+
+	// Upload is a file uploaded to a GitLab project.
+	Upload struct {
+		Alt      string `json:"alt"`
+		URL      string `json:"url"`
+		FullPath string `json:"full_path"`
+		Markdown string `json:"markdown"`
+	}
+)
+
+// Get retrieves the project.
+// See https://docs.gitlab.com/ee/api/projects.html#get-single-project
+func (s *ProjectService) Get(ctx context.Context) (*Project, *Response, error) {
+	url := fmt.Sprintf("/projects/%s", s.id)
+	req, err := s.client.NewRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	project := new(Project)
+
+	resp, err := s.client.Do(req, project)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return project, resp, nil
+}
+
+// Member retrieves a member of the project including inherited members.
+// See https://docs.gitlab.com/ee/api/members.html#get-a-member-of-a-group-or-project-including-inherited-and-invited-members
+func (s *ProjectService) Member(ctx context.Context, userID int) (*Member, *Response, error) {
+	url := fmt.Sprintf("/projects/%s/members/all/%d", s.id, userID)
+	req, err := s.client.NewRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	member := new(Member)
+
+	resp, err := s.client.Do(req, member)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return member, resp, nil
+}
+
+// ProtectedBranch retrieves a protected branch by its name.
+// See https://docs.gitlab.com/ee/api/protected_branches.html#get-a-single-protected-branch-or-wildcard-protected-branch
+func (s *ProjectService) ProtectedBranch(ctx context.Context, name string) (*ProtectedBranch, *Response, error) {
+	url := fmt.Sprintf("/projects/%s/protected_branches/%s", s.id, url.PathEscape(name))
+	req, err := s.client.NewRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	branch := new(ProtectedBranch)
+
+	resp, err := s.client.Do(req, branch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return branch, resp, nil
+}
+
+// ProtectBranch protects a branch.
+// See https://docs.gitlab.com/ee/api/protected_branches.html#protect-repository-branches
+func (s *ProjectService) ProtectBranch(ctx context.Context, params ProtectBranchParams) (*ProtectedBranch, *Response, error) {
+	url := fmt.Sprintf("/projects/%s/protected_branches", s.id)
+	req, err := s.client.NewRequest(ctx, "POST", url, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	branch := new(ProtectedBranch)
+
+	resp, err := s.client.Do(req, branch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return branch, resp, nil
+}
+
+// UnprotectBranch removes the protection of a branch.
+// See https://docs.gitlab.com/ee/api/protected_branches.html#unprotect-repository-branches
+func (s *ProjectService) UnprotectBranch(ctx context.Context, name string) (*Response, error) {
+	url := fmt.Sprintf("/projects/%s/protected_branches/%s", s.id, url.PathEscape(name))
+	req, err := s.client.NewRequest(ctx, "DELETE", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// UploadFile uploads a file to the project.
+// The uploaded file can be linked to a release as an asset.
+// See https://docs.gitlab.com/ee/api/projects.html#upload-a-file
+func (s *ProjectService) UploadFile(ctx context.Context, file string) (*Upload, *Response, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	body := new(bytes.Buffer)
+	w := multipart.NewWriter(body)
+
+	part, err := w.CreateFormFile("file", filepath.Base(file))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := io.Copy(part, f); err != nil {
+		return nil, nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, nil, err
+	}
+
+	url := fmt.Sprintf("/projects/%s/uploads", s.id)
+	req, err := s.client.NewRequest(ctx, "POST", url, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	upload := new(Upload)
+
+	resp, err := s.client.Do(req, upload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return upload, resp, nil
+}
+
+// UploadURL returns the absolute URL for downloading an uploaded file.
+func (s *ProjectService) UploadURL(upload Upload) string {
+	// Newer GitLab versions return the full path of uploaded files.
+	if upload.FullPath != "" {
+		return s.client.WebURL(upload.FullPath)
+	}
+
+	return s.client.WebURL(s.path + upload.URL)
+}
+
+// WebURL returns the web address of a path in the project.
+func (s *ProjectService) WebURL(path string) string {
+	return s.client.WebURL(s.path + "/" + path)
+}
@@ -0,0 +1,190 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectService_Get(t *testing.T) {
+	t.Run("Fails", func(t *testing.T) {
+		ts := newTestServer(t, "GET", "/api/v4/projects/group%2Fproject", http.StatusNotFound, `{"message": "404 Project Not Found"}`)
+		defer ts.Close()
+
+		project, _, err := newTestClient(t, ts).Project("group/project").Get(context.Background())
+
+		assert.Nil(t, project)
+		assert.True(t, IsNotFound(err))
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		ts := newTestServer(t, "GET", "/api/v4/projects/group%2Fproject", http.StatusOK, `{"id": 1, "path_with_namespace": "group/project", "default_branch": "main"}`)
+		defer ts.Close()
+
+		project, resp, err := newTestClient(t, ts).Project("group/project").Get(context.Background())
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, &Project{ID: 1, PathWithNamespace: "group/project", DefaultBranch: "main"}, project)
+	})
+}
+
+func TestProjectService_Member(t *testing.T) {
+	t.Run("Fails", func(t *testing.T) {
+		ts := newTestServer(t, "GET", "/api/v4/projects/group%2Fproject/members/all/1", http.StatusNotFound, `{"message": "404 Not found"}`)
+		defer ts.Close()
+
+		member, _, err := newTestClient(t, ts).Project("group/project").Member(context.Background(), 1)
+
+		assert.Nil(t, member)
+		assert.Error(t, err)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		ts := newTestServer(t, "GET", "/api/v4/projects/group%2Fproject/members/all/1", http.StatusOK, `{"id": 1, "username": "octocat", "access_level": 40}`)
+		defer ts.Close()
+
+		member, resp, err := newTestClient(t, ts).Project("group/project").Member(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, &Member{ID: 1, Username: "octocat", AccessLevel: MaintainerAccess}, member)
+	})
+}
+
+func TestProjectService_ProtectedBranch(t *testing.T) {
+	t.Run("Fails", func(t *testing.T) {
+		ts := newTestServer(t, "GET", "/api/v4/projects/group%2Fproject/protected_branches/main", http.StatusNotFound, `{"message": "404 Not found"}`)
+		defer ts.Close()
+
+		branch, _, err := newTestClient(t, ts).Project("group/project").ProtectedBranch(context.Background(), "main")
+
+		assert.Nil(t, branch)
+		assert.True(t, IsNotFound(err))
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		ts := newTestServer(t, "GET", "/api/v4/projects/group%2Fproject/protected_branches/main", http.StatusOK, `{"id": 1, "name": "main", "push_access_levels": [{"access_level": 0}], "merge_access_levels": [{"access_level": 40}]}`)
+		defer ts.Close()
+
+		branch, resp, err := newTestClient(t, ts).Project("group/project").ProtectedBranch(context.Background(), "main")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, &ProtectedBranch{
+			ID:                1,
+			Name:              "main",
+			PushAccessLevels:  []BranchAccess{{AccessLevel: NoAccess}},
+			MergeAccessLevels: []BranchAccess{{AccessLevel: MaintainerAccess}},
+		}, branch)
+	})
+}
+
+func TestProjectService_ProtectBranch(t *testing.T) {
+	t.Run("Fails", func(t *testing.T) {
+		ts := newTestServer(t, "POST", "/api/v4/projects/group%2Fproject/protected_branches", http.StatusConflict, `{"message": "Protected branch 'main' already exists"}`)
+		defer ts.Close()
+
+		branch, _, err := newTestClient(t, ts).Project("group/project").ProtectBranch(context.Background(), ProtectBranchParams{Name: "main"})
+
+		assert.Nil(t, branch)
+		assert.Error(t, err)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		ts := newTestServer(t, "POST", "/api/v4/projects/group%2Fproject/protected_branches", http.StatusCreated, `{"id": 1, "name": "main"}`)
+		defer ts.Close()
+
+		branch, resp, err := newTestClient(t, ts).Project("group/project").ProtectBranch(context.Background(), ProtectBranchParams{Name: "main"})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, &ProtectedBranch{ID: 1, Name: "main"}, branch)
+	})
+}
+
+func TestProjectService_UnprotectBranch(t *testing.T) {
+	t.Run("Fails", func(t *testing.T) {
+		ts := newTestServer(t, "DELETE", "/api/v4/projects/group%2Fproject/protected_branches/main", http.StatusForbidden, `{"message": "403 Forbidden"}`)
+		defer ts.Close()
+
+		_, err := newTestClient(t, ts).Project("group/project").UnprotectBranch(context.Background(), "main")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		ts := newTestServer(t, "DELETE", "/api/v4/projects/group%2Fproject/protected_branches/main", http.StatusNoContent, ``)
+		defer ts.Close()
+
+		resp, err := newTestClient(t, ts).Project("group/project").UnprotectBranch(context.Background(), "main")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+	})
+}
+
+func TestProjectService_UploadFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "app")
+	assert.NoError(t, os.WriteFile(file, []byte("binary"), 0644))
+
+	t.Run("NoFile", func(t *testing.T) {
+		c, err := NewClient("https://gitlab.example.com", "token")
+		assert.NoError(t, err)
+
+		upload, _, err := c.Project("group/project").UploadFile(context.Background(), "/dev/null/app")
+
+		assert.Nil(t, upload)
+		assert.Error(t, err)
+	})
+
+	t.Run("Fails", func(t *testing.T) {
+		ts := newTestServer(t, "POST", "/api/v4/projects/group%2Fproject/uploads", http.StatusRequestEntityTooLarge, `{"message": "413 Request Entity Too Large"}`)
+		defer ts.Close()
+
+		upload, _, err := newTestClient(t, ts).Project("group/project").UploadFile(context.Background(), file)
+
+		assert.Nil(t, upload)
+		assert.Error(t, err)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			f, h, err := r.FormFile("file")
+			assert.NoError(t, err)
+			assert.Equal(t, "app", h.Filename)
+			_ = f.Close()
+
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"alt": "app", "url": "/uploads/abcd/app", "full_path": "/-/project/1/uploads/abcd/app"}`))
+		}))
+		defer ts.Close()
+
+		upload, resp, err := newTestClient(t, ts).Project("group/project").UploadFile(context.Background(), file)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, &Upload{Alt: "app", URL: "/uploads/abcd/app", FullPath: "/-/project/1/uploads/abcd/app"}, upload)
+	})
+}
+
+func TestProjectService_UploadURL(t *testing.T) {
+	c, err := NewClient("https://gitlab.example.com", "")
+	assert.NoError(t, err)
+	p := c.Project("group/project")
+
+	assert.Equal(t, "https://gitlab.example.com/-/project/1/uploads/abcd/app", p.UploadURL(Upload{URL: "/uploads/abcd/app", FullPath: "/-/project/1/uploads/abcd/app"}))
+	assert.Equal(t, "https://gitlab.example.com/group/project/uploads/abcd/app", p.UploadURL(Upload{URL: "/uploads/abcd/app"}))
+}
+
+func TestProjectService_WebURL(t *testing.T) {
+	c, err := NewClient("https://gitlab.example.com", "")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "https://gitlab.example.com/group/project/-/releases", c.Project("group/project").WebURL("-/releases"))
+}
@@ -0,0 +1,172 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ReleaseService provides GitLab APIs for releases in a project.
+// See https://docs.gitlab.com/ee/api/releases
+type ReleaseService struct {
+	client *Client
+	id     string
+}
+
+type (
+	// ReleaseLink is a GitLab release asset link object.
+	ReleaseLink struct {
+		ID             int    `json:"id"`
+		Name           string `json:"name"`
+		URL            string `json:"url"`
+		DirectAssetURL string `json:"direct_asset_url"`
+		LinkType       string `json:"link_type"`
+	}
+
+	// ReleaseAssets is the assets of a GitLab release.
+	ReleaseAssets struct {
+		Count int           `json:"count"`
+		Links []ReleaseLink `json:"links"`
+	}
+
+	// ReleaseLinks are the web links of a GitLab release.
+	ReleaseLinks struct {
+		Self string `json:"self"`
+	}
+
+	// Release is a GitLab release object.
+	// A GitLab release is always associated with a tag and identified by the tag name.
+	Release struct {
+		Name            string        `json:"name"`
+		TagName         string        `json:"tag_name"`
+		Description     string        `json:"description"`
+		CreatedAt       time.Time     `json:"created_at"`
+		ReleasedAt      time.Time     `json:"released_at"`
+		UpcomingRelease bool          `json:"upcoming_release"`
+		Assets          ReleaseAssets `json:"assets"`
+		Links           ReleaseLinks  `json:"_links"`
+	}
+
+	// ReleaseLinkParams is used for creating a release asset link.
+	ReleaseLinkParams struct {
+		Name     string `json:"name"`
+		URL      string `json:"url"`
+		LinkType string `json:"link_type,omitempty"` // Either other, runbook, image, or package
+	}
+
+	// ReleaseAssetsParams is used for creating release assets along with a release.
+	ReleaseAssetsParams struct {
+		Links []ReleaseLinkParams `json:"links"`
+	}
+
+	// CreateReleaseParams is used for creating a release.
+	// If the tag does not exist, it will be created from Ref.
+	CreateReleaseParams struct {
+		Name        string               `json:"name"`
+		TagName     string               `json:"tag_name"`
+		Ref         string               `json:"ref,omitempty"`
+		Description string               `json:"description"`
+		Assets      *ReleaseAssetsParams `json:"assets,omitempty"`
+	}
+
+	// UpdateReleaseParams is used for updating a release.
+	UpdateReleaseParams struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+)
+
+// List retrieves all releases page by page.
+// See https://docs.gitlab.com/ee/api/releases/#list-releases
+func (s *ReleaseService) List(ctx context.Context, pageSize, pageNo int) ([]Release, *Response, error) {
+	url := fmt.Sprintf("/projects/%s/releases", s.id)
+	req, err := s.client.NewPageRequest(ctx, "GET", url, pageSize, pageNo, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	releases := []Release{}
+
+	resp, err := s.client.Do(req, &releases)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return releases, resp, nil
+}
+
+// Create creates a new release.
+// See https://docs.gitlab.com/ee/api/releases/#create-a-release
+func (s *ReleaseService) Create(ctx context.Context, params CreateReleaseParams) (*Release, *Response, error) {
+	url := fmt.Sprintf("/projects/%s/releases", s.id)
+	req, err := s.client.NewRequest(ctx, "POST", url, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	release := new(Release)
+
+	resp, err := s.client.Do(req, release)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return release, resp, nil
+}
+
+// Update updates an existing release by its tag name.
+// See https://docs.gitlab.com/ee/api/releases/#update-a-release
+func (s *ReleaseService) Update(ctx context.Context, tag string, params UpdateReleaseParams) (*Release, *Response, error) {
+	url := fmt.Sprintf("/projects/%s/releases/%s", s.id, url.PathEscape(tag))
+	req, err := s.client.NewRequest(ctx, "PUT", url, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	release := new(Release)
+
+	resp, err := s.client.Do(req, release)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return release, resp, nil
+}
+
+// Delete deletes a release by its tag name.
+// The tag of the release is not deleted.
+// See https://docs.gitlab.com/ee/api/releases/#delete-a-release
+func (s *ReleaseService) Delete(ctx context.Context, tag string) (*Response, error) {
+	url := fmt.Sprintf("/projects/%s/releases/%s", s.id, url.PathEscape(tag))
+	req, err := s.client.NewRequest(ctx, "DELETE", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// CreateLink creates a new asset link for an existing release.
+// See https://docs.gitlab.com/ee/api/releases/links.html#create-a-release-link
+func (s *ReleaseService) CreateLink(ctx context.Context, tag string, params ReleaseLinkParams) (*ReleaseLink, *Response, error) {
+	url := fmt.Sprintf("/projects/%s/releases/%s/assets/links", s.id, url.PathEscape(tag))
+	req, err := s.client.NewRequest(ctx, "POST", url, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	link := new(ReleaseLink)
+
+	resp, err := s.client.Do(req, link)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return link, resp, nil
+}
@@ -0,0 +1,137 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleaseService_List(t *testing.T) {
+	t.Run("Fails", func(t *testing.T) {
+		ts := newTestServer(t, "GET", "/api/v4/projects/group%2Fproject/releases", http.StatusForbidden, `{"message": "403 Forbidden"}`)
+		defer ts.Close()
+
+		releases, _, err := newTestClient(t, ts).Project("group/project").Releases.List(context.Background(), 10, 1)
+
+		assert.Nil(t, releases)
+		assert.Error(t, err)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		ts := newTestServer(t, "GET", "/api/v4/projects/group%2Fproject/releases", http.StatusOK, `[{"name": "0.1.0", "tag_name": "v0.1.0"}]`)
+		defer ts.Close()
+
+		releases, resp, err := newTestClient(t, ts).Project("group/project").Releases.List(context.Background(), 10, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, Pages{Next: 2, Total: 3}, resp.Pages)
+		assert.Equal(t, []Release{{Name: "0.1.0", TagName: "v0.1.0"}}, releases)
+	})
+}
+
+func TestReleaseService_Create(t *testing.T) {
+	t.Run("Fails", func(t *testing.T) {
+		ts := newTestServer(t, "POST", "/api/v4/projects/group%2Fproject/releases", http.StatusConflict, `{"message": "Release already exists"}`)
+		defer ts.Close()
+
+		release, _, err := newTestClient(t, ts).Project("group/project").Releases.Create(context.Background(), CreateReleaseParams{})
+
+		assert.Nil(t, release)
+		assert.Error(t, err)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		ts := newTestServer(t, "POST", "/api/v4/projects/group%2Fproject/releases", http.StatusCreated, `{"name": "0.1.0", "tag_name": "v0.1.0"}`)
+		defer ts.Close()
+
+		release, resp, err := newTestClient(t, ts).Project("group/project").Releases.Create(context.Background(), CreateReleaseParams{
+			Name:    "0.1.0",
+			TagName: "v0.1.0",
+			Assets: &ReleaseAssetsParams{
+				Links: []ReleaseLinkParams{
+					{Name: "app", URL: "https://gitlab.example.com/group/project/uploads/abcd/app"},
+				},
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, &Release{Name: "0.1.0", TagName: "v0.1.0"}, release)
+	})
+}
+
+func TestReleaseService_Update(t *testing.T) {
+	t.Run("Fails", func(t *testing.T) {
+		ts := newTestServer(t, "PUT", "/api/v4/projects/group%2Fproject/releases/v0.1.0", http.StatusNotFound, `{"message": "404 Not found"}`)
+		defer ts.Close()
+
+		release, _, err := newTestClient(t, ts).Project("group/project").Releases.Update(context.Background(), "v0.1.0", UpdateReleaseParams{})
+
+		assert.Nil(t, release)
+		assert.Error(t, err)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		ts := newTestServer(t, "PUT", "/api/v4/projects/group%2Fproject/releases/v0.1.0", http.StatusOK, `{"name": "0.1.0", "tag_name": "v0.1.0", "description": "notes"}`)
+		defer ts.Close()
+
+		release, resp, err := newTestClient(t, ts).Project("group/project").Releases.Update(context.Background(), "v0.1.0", UpdateReleaseParams{
+			Name:        "0.1.0",
+			Description: "notes",
+		})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, &Release{Name: "0.1.0", TagName: "v0.1.0", Description: "notes"}, release)
+	})
+}
+
+func TestReleaseService_Delete(t *testing.T) {
+	t.Run("Fails", func(t *testing.T) {
+		ts := newTestServer(t, "DELETE", "/api/v4/projects/group%2Fproject/releases/v0.1.0", http.StatusNotFound, `{"message": "404 Not Found"}`)
+		defer ts.Close()
+
+		_, err := newTestClient(t, ts).Project("group/project").Releases.Delete(context.Background(), "v0.1.0")
+
+		assert.True(t, IsNotFound(err))
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		ts := newTestServer(t, "DELETE", "/api/v4/projects/group%2Fproject/releases/v0.1.0", http.StatusOK, `{"name": "0.1.0", "tag_name": "v0.1.0"}`)
+		defer ts.Close()
+
+		resp, err := newTestClient(t, ts).Project("group/project").Releases.Delete(context.Background(), "v0.1.0")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+	})
+}
+
+func TestReleaseService_CreateLink(t *testing.T) {
+	t.Run("Fails", func(t *testing.T) {
+		ts := newTestServer(t, "POST", "/api/v4/projects/group%2Fproject/releases/v0.1.0/assets/links", http.StatusBadRequest, `{"message": "url is invalid"}`)
+		defer ts.Close()
+
+		link, _, err := newTestClient(t, ts).Project("group/project").Releases.CreateLink(context.Background(), "v0.1.0", ReleaseLinkParams{})
+
+		assert.Nil(t, link)
+		assert.Error(t, err)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		ts := newTestServer(t, "POST", "/api/v4/projects/group%2Fproject/releases/v0.1.0/assets/links", http.StatusCreated, `{"id": 1, "name": "app", "url": "https://example.com/app", "link_type": "package"}`)
+		defer ts.Close()
+
+		link, resp, err := newTestClient(t, ts).Project("group/project").Releases.CreateLink(context.Background(), "v0.1.0", ReleaseLinkParams{
+			Name:     "app",
+			URL:      "https://example.com/app",
+			LinkType: "package",
+		})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, &ReleaseLink{ID: 1, Name: "app", URL: "https://example.com/app", LinkType: "package"}, link)
+	})
+}
@@ -0,0 +1,63 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// UserService provides GitLab APIs for users.
+// See https://docs.gitlab.com/ee/api/users.html
+type UserService struct {
+	client *Client
+}
+
+// User is a GitLab user object.
+type User struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	State    string `json:"state"`
+	WebURL   string `json:"web_url"`
+}
+
+// User returns the authenticated user.
+// See https://docs.gitlab.com/ee/api/users.html#list-current-user
+func (s *UserService) User(ctx context.Context) (*User, *Response, error) {
+	req, err := s.client.NewRequest(ctx, "GET", "/user", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user := new(User)
+
+	resp, err := s.client.Do(req, user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, resp, nil
+}
+
+// Get retrieves a user by its username.
+// See https://docs.gitlab.com/ee/api/users.html#list-users
+func (s *UserService) Get(ctx context.Context, username string) (*User, *Response, error) {
+	req, err := s.client.NewRequest(ctx, "GET", "/users?username="+url.QueryEscape(username), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	users := []User{}
+
+	resp, err := s.client.Do(req, &users)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(users) == 0 {
+		return nil, nil, fmt.Errorf("gitlab user not found: %s", username)
+	}
+
+	return &users[0], resp, nil
+}
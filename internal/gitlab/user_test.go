@@ -0,0 +1,65 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserService_User(t *testing.T) {
+	t.Run("Fails", func(t *testing.T) {
+		ts := newTestServer(t, "GET", "/api/v4/user", http.StatusUnauthorized, `{"message": "401 Unauthorized"}`)
+		defer ts.Close()
+
+		user, _, err := newTestClient(t, ts).Users.User(context.Background())
+
+		assert.Nil(t, user)
+		assert.Error(t, err)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		ts := newTestServer(t, "GET", "/api/v4/user", http.StatusOK, `{"id": 1, "username": "octocat"}`)
+		defer ts.Close()
+
+		user, resp, err := newTestClient(t, ts).Users.User(context.Background())
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, &User{ID: 1, Username: "octocat"}, user)
+	})
+}
+
+func TestUserService_Get(t *testing.T) {
+	t.Run("Fails", func(t *testing.T) {
+		ts := newTestServer(t, "GET", "/api/v4/users", http.StatusForbidden, `{"message": "403 Forbidden"}`)
+		defer ts.Close()
+
+		user, _, err := newTestClient(t, ts).Users.Get(context.Background(), "octocat")
+
+		assert.Nil(t, user)
+		assert.Error(t, err)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		ts := newTestServer(t, "GET", "/api/v4/users", http.StatusOK, `[]`)
+		defer ts.Close()
+
+		user, _, err := newTestClient(t, ts).Users.Get(context.Background(), "octocat")
+
+		assert.Nil(t, user)
+		assert.EqualError(t, err, "gitlab user not found: octocat")
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		ts := newTestServer(t, "GET", "/api/v4/users", http.StatusOK, `[{"id": 1, "username": "octocat"}]`)
+		defer ts.Close()
+
+		user, resp, err := newTestClient(t, ts).Users.Get(context.Background(), "octocat")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, &User{ID: 1, Username: "octocat"}, user)
+	})
+}
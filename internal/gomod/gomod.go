@@ -0,0 +1,168 @@
+// Package gomod provides functions for finding the Go modules of a project.
+package gomod
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+const (
+	// ModFile is the name of the file defining a Go module.
+	ModFile = "go.mod"
+	// WorkFile is the name of the file defining a Go workspace.
+	WorkFile = "go.work"
+)
+
+// Module is a Go module in a project.
+type Module struct {
+	// Dir is the directory of the module relative to the root directory of the project.
+	Dir string
+	// Path is the module path declared in the go.mod file (empty if there is no go.mod file).
+	Path string
+}
+
+// Name returns a human-readable name for the module.
+func (m Module) Name() string {
+	if m.Path == "" {
+		return m.Dir
+	}
+
+	return m.Path
+}
+
+// Matches determines whether or not a module is selected by a directory or a module path.
+func (m Module) Matches(selector string) bool {
+	return selector == m.Path || filepath.Clean(selector) == m.Dir
+}
+
+// Find returns the Go modules in a directory tree.
+// If a go.work file exists, the modules are the ones used by the workspace.
+// Otherwise, every go.mod file in the directory tree (excluding the directories ignored by the go command) is a module.
+// If no module is found, the directory itself is considered as a module.
+func Find(root string) ([]Module, error) {
+	var dirs []string
+
+	if data, err := os.ReadFile(filepath.Join(root, WorkFile)); err == nil {
+		work, err := modfile.ParseWork(WorkFile, data, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, use := range work.Use {
+			dirs = append(dirs, filepath.Clean(use.Path))
+		}
+	} else if os.IsNotExist(err) {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			name := d.Name()
+
+			if d.IsDir() {
+				if path != root && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			if name == ModFile {
+				dir, err := filepath.Rel(root, filepath.Dir(path))
+				if err != nil {
+					return err
+				}
+				dirs = append(dirs, dir)
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, err
+	}
+
+	if len(dirs) == 0 {
+		return []Module{{Dir: "."}}, nil
+	}
+
+	sort.Strings(dirs)
+
+	modules := make([]Module, 0, len(dirs))
+	for _, dir := range dirs {
+		data, err := os.ReadFile(filepath.Join(root, dir, ModFile))
+		if err != nil {
+			return nil, err
+		}
+
+		path := modfile.ModulePath(data)
+		if path == "" {
+			return nil, fmt.Errorf("no module path in %s", filepath.Join(dir, ModFile))
+		}
+
+		modules = append(modules, Module{
+			Dir:  dir,
+			Path: path,
+		})
+	}
+
+	return modules, nil
+}
+
+// Select returns the modules matching any of the selectors (directories or module paths).
+// If no selector is specified, all modules are returned.
+func Select(modules []Module, selectors []string) ([]Module, error) {
+	if len(selectors) == 0 {
+		return modules, nil
+	}
+
+	selected := []Module{}
+	for _, selector := range selectors {
+		found := false
+		for _, m := range modules {
+			if m.Matches(selector) {
+				if !contains(selected, m) {
+					selected = append(selected, m)
+				}
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("no module found for %s", selector)
+		}
+	}
+
+	return selected, nil
+}
+
+func contains(modules []Module, m Module) bool {
+	for _, mod := range modules {
+		if mod.Dir == m.Dir {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseSelectors parses a comma-separated list of module selectors.
+func ParseSelectors(s string) []string {
+	selectors := []string{}
+	for _, selector := range strings.Split(s, ",") {
+		if selector = strings.TrimSpace(selector); selector != "" {
+			selectors = append(selectors, selector)
+		}
+	}
+
+	return selectors
+}
@@ -0,0 +1,162 @@
+package gomod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestModule_Name(t *testing.T) {
+	assert.Equal(t, ".", Module{Dir: "."}.Name())
+	assert.Equal(t, "github.com/foo/bar", Module{Dir: ".", Path: "github.com/foo/bar"}.Name())
+}
+
+func TestModule_Matches(t *testing.T) {
+	m := Module{Dir: "tools/gen", Path: "github.com/foo/bar/tools/gen"}
+
+	assert.True(t, m.Matches("tools/gen"))
+	assert.True(t, m.Matches("./tools/gen/"))
+	assert.True(t, m.Matches("github.com/foo/bar/tools/gen"))
+	assert.False(t, m.Matches("tools"))
+	assert.False(t, m.Matches("github.com/foo/bar"))
+}
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		name            string
+		files           map[string]string
+		expectedModules []Module
+		expectedError   bool
+	}{
+		{
+			name:            "NoModule",
+			files:           map[string]string{"main.go": "package main\n"},
+			expectedModules: []Module{{Dir: "."}},
+		},
+		{
+			name: "NestedModules",
+			files: map[string]string{
+				"go.mod":                  "module github.com/foo/bar\n",
+				"tools/gen/go.mod":        "module github.com/foo/bar/tools/gen\n",
+				"vendor/x/go.mod":         "module x\n",
+				"testdata/y/go.mod":       "module y\n",
+				".cache/z/go.mod":         "module z\n",
+				"services/api/go.mod":     "module github.com/foo/bar/services/api\n",
+				"services/api/cmd/a/a.go": "package main\n",
+			},
+			expectedModules: []Module{
+				{Dir: ".", Path: "github.com/foo/bar"},
+				{Dir: "services/api", Path: "github.com/foo/bar/services/api"},
+				{Dir: "tools/gen", Path: "github.com/foo/bar/tools/gen"},
+			},
+		},
+		{
+			name: "Workspace",
+			files: map[string]string{
+				"go.work":             "go 1.25\n\nuse (\n\t./services/api\n\t.\n)\n",
+				"go.mod":              "module github.com/foo/bar\n",
+				"tools/gen/go.mod":    "module github.com/foo/bar/tools/gen\n",
+				"services/api/go.mod": "module github.com/foo/bar/services/api\n",
+			},
+			expectedModules: []Module{
+				{Dir: ".", Path: "github.com/foo/bar"},
+				{Dir: "services/api", Path: "github.com/foo/bar/services/api"},
+			},
+		},
+		{
+			name: "InvalidWorkspace",
+			files: map[string]string{
+				"go.work": "use (\n",
+			},
+			expectedError: true,
+		},
+		{
+			name: "WorkspaceModuleMissing",
+			files: map[string]string{
+				"go.work": "go 1.25\n\nuse ./missing\n",
+			},
+			expectedError: true,
+		},
+		{
+			name: "NoModulePath",
+			files: map[string]string{
+				"go.mod": "go 1.25\n",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFiles(t, root, tc.files)
+
+			modules, err := Find(root)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedModules, modules)
+			}
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	modules := []Module{
+		{Dir: ".", Path: "github.com/foo/bar"},
+		{Dir: "services/api", Path: "github.com/foo/bar/services/api"},
+		{Dir: "tools/gen", Path: "github.com/foo/bar/tools/gen"},
+	}
+
+	tests := []struct {
+		name            string
+		selectors       []string
+		expectedModules []Module
+		expectedError   string
+	}{
+		{
+			name:            "NoSelector",
+			expectedModules: modules,
+		},
+		{
+			name:            "ByDirAndPath",
+			selectors:       []string{"./tools/gen", "github.com/foo/bar/services/api", "tools/gen"},
+			expectedModules: []Module{modules[2], modules[1]},
+		},
+		{
+			name:          "NotFound",
+			selectors:     []string{"services/web"},
+			expectedError: "no module found for services/web",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			selected, err := Select(modules, tc.selectors)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedModules, selected)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestParseSelectors(t *testing.T) {
+	assert.Equal(t, []string{}, ParseSelectors(""))
+	assert.Equal(t, []string{"./tools/gen", "github.com/foo/bar"}, ParseSelectors("./tools/gen, github.com/foo/bar,,"))
+}
@@ -0,0 +1,464 @@
+// Package image provides functionality for building and pushing OCI container images.
+// Images are assembled in pure Go from prebuilt binaries, so no container runtime is needed.
+package image
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"time"
+)
+
+// Media types of OCI images.
+const (
+	MediaTypeIndex    = "application/vnd.oci.image.index.v1+json"
+	MediaTypeManifest = "application/vnd.oci.image.manifest.v1+json"
+	MediaTypeConfig   = "application/vnd.oci.image.config.v1+json"
+	MediaTypeLayer    = "application/vnd.oci.image.layer.v1.tar+gzip"
+)
+
+const (
+	layoutFile    = "oci-layout"
+	indexFile     = "index.json"
+	blobsDir      = "blobs"
+	layoutVersion = `{"imageLayoutVersion":"1.0.0"}`
+
+	annotationRefName = "org.opencontainers.image.ref.name"
+
+	binDir     = "/usr/local/bin"
+	nonroot    = 65532
+	passwdFile = "root:x:0:0:root:/root:/sbin/nologin\nnonroot:x:65532:65532:nonroot:/home/nonroot:/sbin/nologin\n"
+	groupFile  = "root:x:0:\nnonroot:x:65532:\n"
+)
+
+// Platform is the platform of an image.
+type Platform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+// String returns the platform in the form of os/arch or os/arch/variant.
+func (p Platform) String() string {
+	if p.Variant == "" {
+		return p.OS + "/" + p.Architecture
+	}
+
+	return p.OS + "/" + p.Architecture + "/" + p.Variant
+}
+
+// Descriptor describes a content addressable blob.
+type Descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Platform    *Platform         `json:"platform,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// index is an OCI image index.
+type index struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType,omitempty"`
+	Manifests     []Descriptor `json:"manifests"`
+}
+
+// manifest is an OCI image manifest.
+type manifest struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType,omitempty"`
+	Config        Descriptor   `json:"config"`
+	Layers        []Descriptor `json:"layers"`
+}
+
+// config is an OCI image configuration.
+type config struct {
+	Created      time.Time `json:"created"`
+	Architecture string    `json:"architecture"`
+	OS           string    `json:"os"`
+	Variant      string    `json:"variant,omitempty"`
+	Config       struct {
+		User       string            `json:"User"`
+		Env        []string          `json:"Env"`
+		Entrypoint []string          `json:"Entrypoint"`
+		WorkingDir string            `json:"WorkingDir"`
+		Labels     map[string]string `json:"Labels,omitempty"`
+	} `json:"config"`
+	RootFS struct {
+		Type    string   `json:"type"`
+		DiffIDs []string `json:"diff_ids"`
+	} `json:"rootfs"`
+}
+
+// Binary is a binary for including in an image.
+type Binary struct {
+	// Path is the path to the binary on disk.
+	Path     string
+	Platform Platform
+}
+
+// Options are the options for building an image.
+type Options struct {
+	// Name is the name of the binary in the image.
+	Name string
+	// Created is the creation time of the image and the modification time of all files in the image.
+	Created time.Time
+	// Labels are the labels of the image (e.g. org.opencontainers.image.version).
+	Labels map[string]string
+}
+
+// Layout is an OCI image layout holding a single image.
+type Layout struct {
+	// Image is the descriptor of the image, which is either an image index or an image manifest.
+	Image Descriptor
+	blobs map[string][]byte
+}
+
+func newLayout() *Layout {
+	return &Layout{
+		blobs: map[string][]byte{},
+	}
+}
+
+// add adds a blob to the layout and returns its descriptor.
+func (l *Layout) add(mediaType string, data []byte) Descriptor {
+	d := digest(data)
+	l.blobs[d] = data
+
+	return Descriptor{
+		MediaType: mediaType,
+		Digest:    d,
+		Size:      int64(len(data)),
+	}
+}
+
+// addJSON adds a JSON blob to the layout and returns its descriptor.
+func (l *Layout) addJSON(mediaType string, v any) (Descriptor, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return Descriptor{}, err
+	}
+
+	return l.add(mediaType, data), nil
+}
+
+// Blob returns the content of a blob in the layout.
+func (l *Layout) Blob(digest string) ([]byte, bool) {
+	data, ok := l.blobs[digest]
+	return data, ok
+}
+
+// Build assembles a minimal distroless-style image from the binaries of a program.
+// Every binary is for a different platform and the image is a multi-platform image index.
+// The image has a base layer with a nonroot user and a layer with the binary as the entrypoint.
+// The image is reproducible, since all timestamps are set to the creation time.
+func Build(binaries []Binary, opts Options) (*Layout, error) {
+	if len(binaries) == 0 {
+		return nil, errors.New("no binary for building the image")
+	}
+
+	l := newLayout()
+
+	baseLayer, baseDiffID, err := tarLayer(opts.Created, baseFiles())
+	if err != nil {
+		return nil, err
+	}
+
+	manifests := make([]Descriptor, 0, len(binaries))
+	for _, binary := range binaries {
+		data, err := os.ReadFile(binary.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		appLayer, appDiffID, err := tarLayer(opts.Created, appFiles(opts.Name, data))
+		if err != nil {
+			return nil, err
+		}
+
+		var cfg config
+		cfg.Created = opts.Created.UTC()
+		cfg.Architecture = binary.Platform.Architecture
+		cfg.OS = binary.Platform.OS
+		cfg.Variant = binary.Platform.Variant
+		cfg.Config.User = fmt.Sprintf("%d:%d", nonroot, nonroot)
+		cfg.Config.Env = []string{"PATH=" + binDir + ":/usr/bin:/bin"}
+		cfg.Config.Entrypoint = []string{path.Join(binDir, opts.Name)}
+		cfg.Config.WorkingDir = "/home/nonroot"
+		cfg.Config.Labels = opts.Labels
+		cfg.RootFS.Type = "layers"
+		cfg.RootFS.DiffIDs = []string{baseDiffID, appDiffID}
+
+		configDesc, err := l.addJSON(MediaTypeConfig, cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		manifestDesc, err := l.addJSON(MediaTypeManifest, manifest{
+			SchemaVersion: 2,
+			MediaType:     MediaTypeManifest,
+			Config:        configDesc,
+			Layers: []Descriptor{
+				l.add(MediaTypeLayer, baseLayer),
+				l.add(MediaTypeLayer, appLayer),
+			},
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		platform := binary.Platform
+		manifestDesc.Platform = &platform
+		manifests = append(manifests, manifestDesc)
+	}
+
+	// The platforms are sorted, so the image index is deterministic
+	sort.Slice(manifests, func(i, j int) bool {
+		return manifests[i].Platform.String() < manifests[j].Platform.String()
+	})
+
+	l.Image, err = l.addJSON(MediaTypeIndex, index{
+		SchemaVersion: 2,
+		MediaType:     MediaTypeIndex,
+		Manifests:     manifests,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// Write writes the layout as a tarball in the OCI image layout format.
+// The image is referenced by a tag in the index of the layout.
+func (l *Layout) Write(w io.Writer, tag string) error {
+	image := l.Image
+	image.Annotations = map[string]string{annotationRefName: tag}
+
+	indexData, err := json.Marshal(index{
+		SchemaVersion: 2,
+		MediaType:     MediaTypeIndex,
+		Manifests:     []Descriptor{image},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	digests := make([]string, 0, len(l.blobs))
+	for d := range l.blobs {
+		digests = append(digests, d)
+	}
+	sort.Strings(digests)
+
+	tw := tar.NewWriter(w)
+
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{layoutFile, []byte(layoutVersion)},
+		{indexFile, indexData},
+	}
+
+	for _, d := range digests {
+		files = append(files, struct {
+			name string
+			data []byte
+		}{blobPath(d), l.blobs[d]})
+	}
+
+	for _, dir := range []string{blobsDir + "/", blobsDir + "/sha256/"} {
+		if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: dir, Mode: 0755}); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range files {
+		if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: f.name, Mode: 0644, Size: int64(len(f.data))}); err != nil {
+			return err
+		}
+
+		if _, err := tw.Write(f.data); err != nil {
+			return err
+		}
+	}
+
+	return tw.Close()
+}
+
+// WriteFile writes the layout as a tarball to a file.
+func (l *Layout) WriteFile(filename, tag string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	if err := l.Write(f, tag); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// ReadLayout reads a tarball in the OCI image layout format (e.g. created by docker buildx).
+// The first image in the index of the layout is used as the image.
+func ReadLayout(filename string) (*Layout, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	l := newLayout()
+	var indexData []byte
+
+	tr := tar.NewReader(f)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+
+		name := path.Clean(header.Name)
+		switch dir, file := path.Split(name); {
+		case name == indexFile:
+			indexData = data
+		case dir == blobsDir+"/sha256/":
+			l.blobs["sha256:"+file] = data
+		}
+	}
+
+	if indexData == nil {
+		return nil, fmt.Errorf("invalid image layout %s: no %s", filename, indexFile)
+	}
+
+	var idx index
+	if err := json.Unmarshal(indexData, &idx); err != nil {
+		return nil, fmt.Errorf("invalid image layout %s: %s", filename, err)
+	}
+
+	if len(idx.Manifests) == 0 {
+		return nil, fmt.Errorf("invalid image layout %s: no image", filename)
+	}
+
+	l.Image = idx.Manifests[0]
+	l.Image.Annotations = nil
+
+	return l, nil
+}
+
+// file is a file or directory in a layer.
+type file struct {
+	name string
+	mode int64
+	uid  int
+	data []byte
+	dir  bool
+}
+
+// baseFiles returns the files of the base layer shared by all images.
+func baseFiles() []file {
+	return []file{
+		{name: "etc/", mode: 0755, dir: true},
+		{name: "etc/group", mode: 0644, data: []byte(groupFile)},
+		{name: "etc/passwd", mode: 0644, data: []byte(passwdFile)},
+		{name: "home/", mode: 0755, dir: true},
+		{name: "home/nonroot/", mode: 0700, uid: nonroot, dir: true},
+		{name: "tmp/", mode: 01777, dir: true},
+	}
+}
+
+// appFiles returns the files of the application layer.
+func appFiles(name string, binary []byte) []file {
+	return []file{
+		{name: "usr/", mode: 0755, dir: true},
+		{name: "usr/local/", mode: 0755, dir: true},
+		{name: "usr/local/bin/", mode: 0755, dir: true},
+		{name: "usr/local/bin/" + name, mode: 0755, data: binary},
+	}
+}
+
+// tarLayer creates a gzipped tar layer and returns the layer and the digest of the uncompressed tar (diff ID).
+func tarLayer(modTime time.Time, files []file) ([]byte, string, error) {
+	var tarBuf bytes.Buffer
+	tw := tar.NewWriter(&tarBuf)
+
+	for _, f := range files {
+		header := &tar.Header{
+			Name:    f.name,
+			Mode:    f.mode,
+			Uid:     f.uid,
+			Gid:     f.uid,
+			ModTime: modTime.UTC(),
+			Format:  tar.FormatPAX,
+		}
+
+		if f.dir {
+			header.Typeflag = tar.TypeDir
+		} else {
+			header.Typeflag = tar.TypeReg
+			header.Size = int64(len(f.data))
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, "", err
+		}
+
+		if _, err := tw.Write(f.data); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, "", err
+	}
+
+	var gzipBuf bytes.Buffer
+	gw := gzip.NewWriter(&gzipBuf)
+
+	if _, err := gw.Write(tarBuf.Bytes()); err != nil {
+		return nil, "", err
+	}
+
+	if err := gw.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return gzipBuf.Bytes(), digest(tarBuf.Bytes()), nil
+}
+
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func blobPath(digest string) string {
+	return blobsDir + "/sha256/" + digest[len("sha256:"):]
+}
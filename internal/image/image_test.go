@@ -0,0 +1,229 @@
+package image
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeBinary(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0755))
+	return path
+}
+
+func TestBuild(t *testing.T) {
+	dir := t.TempDir()
+	amd64 := writeBinary(t, dir, "app-linux-amd64", "amd64 binary")
+	arm := writeBinary(t, dir, "app-linux-armv7", "arm binary")
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		binaries          []Binary
+		opts              Options
+		expectedError     string
+		expectedPlatforms []string
+	}{
+		{
+			name:          "NoBinary",
+			binaries:      nil,
+			expectedError: "no binary for building the image",
+		},
+		{
+			name: "BinaryNotFound",
+			binaries: []Binary{
+				{Path: filepath.Join(dir, "missing"), Platform: Platform{OS: "linux", Architecture: "amd64"}},
+			},
+			expectedError: "open " + filepath.Join(dir, "missing") + ": no such file or directory",
+		},
+		{
+			name: "Success",
+			binaries: []Binary{
+				{Path: arm, Platform: Platform{OS: "linux", Architecture: "arm", Variant: "v7"}},
+				{Path: amd64, Platform: Platform{OS: "linux", Architecture: "amd64"}},
+			},
+			opts: Options{
+				Name:    "app",
+				Created: created,
+				Labels:  map[string]string{"org.opencontainers.image.version": "0.1.0"},
+			},
+			expectedPlatforms: []string{"linux/amd64", "linux/arm/v7"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			l, err := Build(tc.binaries, tc.opts)
+
+			if tc.expectedError != "" {
+				assert.Nil(t, l)
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, MediaTypeIndex, l.Image.MediaType)
+
+			data, ok := l.Blob(l.Image.Digest)
+			assert.True(t, ok)
+
+			var idx index
+			assert.NoError(t, json.Unmarshal(data, &idx))
+			assert.Len(t, idx.Manifests, len(tc.expectedPlatforms))
+
+			for i, desc := range idx.Manifests {
+				assert.Equal(t, tc.expectedPlatforms[i], desc.Platform.String())
+
+				data, ok := l.Blob(desc.Digest)
+				assert.True(t, ok)
+
+				var m manifest
+				assert.NoError(t, json.Unmarshal(data, &m))
+				assert.Len(t, m.Layers, 2)
+
+				data, ok = l.Blob(m.Config.Digest)
+				assert.True(t, ok)
+
+				var cfg config
+				assert.NoError(t, json.Unmarshal(data, &cfg))
+				assert.Equal(t, created, cfg.Created)
+				assert.Equal(t, desc.Platform.Architecture, cfg.Architecture)
+				assert.Equal(t, desc.Platform.Variant, cfg.Variant)
+				assert.Equal(t, "65532:65532", cfg.Config.User)
+				assert.Equal(t, []string{"/usr/local/bin/app"}, cfg.Config.Entrypoint)
+				assert.Equal(t, tc.opts.Labels, cfg.Config.Labels)
+				assert.Len(t, cfg.RootFS.DiffIDs, 2)
+
+				layer, ok := l.Blob(m.Layers[1].Digest)
+				assert.True(t, ok)
+				files := layerFiles(t, layer)
+				assert.Contains(t, files, "usr/local/bin/app")
+			}
+
+			// The build is reproducible
+			again, err := Build(tc.binaries, tc.opts)
+			assert.NoError(t, err)
+			assert.Equal(t, l.Image, again.Image)
+		})
+	}
+}
+
+func TestLayout_WriteFile(t *testing.T) {
+	dir := t.TempDir()
+	bin := writeBinary(t, dir, "app-linux-amd64", "amd64 binary")
+
+	l, err := Build([]Binary{
+		{Path: bin, Platform: Platform{OS: "linux", Architecture: "amd64"}},
+	}, Options{Name: "app"})
+	assert.NoError(t, err)
+
+	path := filepath.Join(dir, "app_0.1.0_image.tar")
+	assert.NoError(t, l.WriteFile(path, "0.1.0"))
+	assert.Error(t, l.WriteFile(filepath.Join(dir, "missing", "image.tar"), "0.1.0"))
+
+	f, err := os.Open(path)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	names := []string{}
+	tr := tar.NewReader(f)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		names = append(names, header.Name)
+	}
+
+	assert.Contains(t, names, "oci-layout")
+	assert.Contains(t, names, "index.json")
+	assert.Contains(t, names, "blobs/sha256/"+l.Image.Digest[len("sha256:"):])
+
+	read, err := ReadLayout(path)
+	assert.NoError(t, err)
+	assert.Equal(t, l.Image, read.Image)
+	assert.Equal(t, l.blobs, read.blobs)
+}
+
+func TestReadLayout(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTar := func(name string, files map[string]string) string {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for name, content := range files {
+			assert.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}))
+			_, err := tw.Write([]byte(content))
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, tw.Close())
+
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+		return path
+	}
+
+	tests := []struct {
+		name          string
+		path          string
+		expectedError string
+	}{
+		{
+			name:          "FileNotFound",
+			path:          filepath.Join(dir, "missing.tar"),
+			expectedError: "open " + filepath.Join(dir, "missing.tar") + ": no such file or directory",
+		},
+		{
+			name:          "NoIndex",
+			path:          writeTar("noindex.tar", map[string]string{"oci-layout": layoutVersion}),
+			expectedError: "invalid image layout " + filepath.Join(dir, "noindex.tar") + ": no index.json",
+		},
+		{
+			name:          "InvalidIndex",
+			path:          writeTar("invalid.tar", map[string]string{"index.json": "{"}),
+			expectedError: "invalid image layout " + filepath.Join(dir, "invalid.tar") + ": unexpected end of JSON input",
+		},
+		{
+			name:          "NoImage",
+			path:          writeTar("noimage.tar", map[string]string{"index.json": `{"schemaVersion":2,"manifests":[]}`}),
+			expectedError: "invalid image layout " + filepath.Join(dir, "noimage.tar") + ": no image",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			l, err := ReadLayout(tc.path)
+
+			assert.Nil(t, l)
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func layerFiles(t *testing.T, layer []byte) []string {
+	gr, err := gzip.NewReader(bytes.NewReader(layer))
+	assert.NoError(t, err)
+
+	names := []string{}
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		names = append(names, header.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,99 @@
+package image
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+const (
+	dockerHub         = "docker.io"
+	dockerHubRegistry = "registry-1.docker.io"
+)
+
+var (
+	repositoryRegexp = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`)
+	tagRegexp        = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+	invalidTagRegexp = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+)
+
+// Reference is a reference to a tagged image in a registry.
+type Reference struct {
+	// Registry is the host (and port) of the registry (e.g. docker.io, ghcr.io, or localhost:5000).
+	Registry string
+	// Repository is the path of the image in the registry (e.g. octocat/app).
+	Repository string
+	Tag        string
+}
+
+// ParseReference parses an image reference in the form of [registry/]repository:tag.
+// If no registry is specified, the reference is to Docker Hub.
+func ParseReference(s string) (Reference, error) {
+	name, tag, ok := cutLast(s, ":")
+	if !ok || strings.Contains(tag, "/") {
+		return Reference{}, fmt.Errorf("invalid image reference %q: no tag", s)
+	}
+
+	ref := Reference{
+		Registry:   dockerHub,
+		Repository: name,
+		Tag:        tag,
+	}
+
+	// The first component is a registry if it looks like a host
+	if host, path, ok := strings.Cut(name, "/"); ok && (strings.ContainsAny(host, ".:") || host == "localhost") {
+		ref.Registry, ref.Repository = host, path
+	}
+
+	if !repositoryRegexp.MatchString(ref.Repository) {
+		return Reference{}, fmt.Errorf("invalid image reference %q: invalid repository %q", s, ref.Repository)
+	}
+
+	if !tagRegexp.MatchString(ref.Tag) {
+		return Reference{}, fmt.Errorf("invalid image reference %q: invalid tag %q", s, ref.Tag)
+	}
+
+	return ref, nil
+}
+
+// String returns the reference in the form of registry/repository:tag.
+func (r Reference) String() string {
+	return r.Registry + "/" + r.Repository + ":" + r.Tag
+}
+
+// Tag converts a version string to a valid image tag by replacing invalid characters (e.g. + in build metadata) with -.
+func Tag(version string) string {
+	return invalidTagRegexp.ReplaceAllString(version, "-")
+}
+
+// baseURL returns the base URL of the registry API.
+// Registries on the loopback interface are accessed over plain HTTP.
+func (r Reference) baseURL() string {
+	if r.Registry == dockerHub {
+		return "https://" + dockerHubRegistry
+	}
+
+	host := r.Registry
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	if host == "localhost" {
+		return "http://" + r.Registry
+	}
+
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return "http://" + r.Registry
+	}
+
+	return "https://" + r.Registry
+}
+
+func cutLast(s, sep string) (string, string, bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+
+	return s, "", false
+}
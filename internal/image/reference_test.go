@@ -0,0 +1,98 @@
+package image
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		name              string
+		s                 string
+		expectedReference Reference
+		expectedError     string
+	}{
+		{
+			name:          "NoTag",
+			s:             "octocat/app",
+			expectedError: `invalid image reference "octocat/app": no tag`,
+		},
+		{
+			name:          "RegistryPortNoTag",
+			s:             "localhost:5000/octocat/app",
+			expectedError: `invalid image reference "localhost:5000/octocat/app": no tag`,
+		},
+		{
+			name:          "InvalidRepository",
+			s:             "Octocat/App:0.1.0",
+			expectedError: `invalid image reference "Octocat/App:0.1.0": invalid repository "Octocat/App"`,
+		},
+		{
+			name:          "InvalidTag",
+			s:             "octocat/app:0.1.0+abcdef",
+			expectedError: `invalid image reference "octocat/app:0.1.0+abcdef": invalid tag "0.1.0+abcdef"`,
+		},
+		{
+			name:              "DockerHub",
+			s:                 "octocat/app:0.1.0",
+			expectedReference: Reference{Registry: "docker.io", Repository: "octocat/app", Tag: "0.1.0"},
+		},
+		{
+			name:              "Registry",
+			s:                 "ghcr.io/octocat/app:0.1.0",
+			expectedReference: Reference{Registry: "ghcr.io", Repository: "octocat/app", Tag: "0.1.0"},
+		},
+		{
+			name:              "LocalRegistry",
+			s:                 "localhost:5000/octocat/app:0.1.0-3-abcdef",
+			expectedReference: Reference{Registry: "localhost:5000", Repository: "octocat/app", Tag: "0.1.0-3-abcdef"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ref, err := ParseReference(tc.s)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedReference, ref)
+			}
+		})
+	}
+}
+
+func TestReference_String(t *testing.T) {
+	ref := Reference{Registry: "docker.io", Repository: "octocat/app", Tag: "0.1.0"}
+
+	assert.Equal(t, "docker.io/octocat/app:0.1.0", ref.String())
+}
+
+func TestTag(t *testing.T) {
+	assert.Equal(t, "0.1.0", Tag("0.1.0"))
+	assert.Equal(t, "0.1.0-3.dev-abcdef", Tag("0.1.0-3.dev+abcdef"))
+}
+
+func TestReference_baseURL(t *testing.T) {
+	tests := []struct {
+		registry    string
+		expectedURL string
+	}{
+		{"docker.io", "https://registry-1.docker.io"},
+		{"ghcr.io", "https://ghcr.io"},
+		{"localhost", "http://localhost"},
+		{"localhost:5000", "http://localhost:5000"},
+		{"127.0.0.1:5000", "http://127.0.0.1:5000"},
+		{"registry.example.com:5000", "https://registry.example.com:5000"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.registry, func(t *testing.T) {
+			ref := Reference{Registry: tc.registry}
+
+			assert.Equal(t, tc.expectedURL, ref.baseURL())
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package image
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const registryTimeout = 5 * time.Minute
+
+// Registry is a client for pushing images to a registry using the OCI distribution API.
+type Registry struct {
+	client   *http.Client
+	username string
+	password string
+	token    string
+}
+
+// NewRegistry creates a new registry client.
+// If username and password are empty, the registry is accessed anonymously.
+func NewRegistry(username, password string) *Registry {
+	return &Registry{
+		client: &http.Client{
+			Timeout: registryTimeout,
+		},
+		username: username,
+		password: password,
+	}
+}
+
+// Push pushes an image and all of its blobs to a registry and tags it.
+func (r *Registry) Push(ctx context.Context, l *Layout, ref Reference) error {
+	return r.pushManifest(ctx, l, ref, l.Image, ref.Tag)
+}
+
+// pushManifest pushes an image index or an image manifest after pushing all of its children.
+func (r *Registry) pushManifest(ctx context.Context, l *Layout, ref Reference, desc Descriptor, reference string) error {
+	data, ok := l.Blob(desc.Digest)
+	if !ok {
+		return fmt.Errorf("blob %s not found in image layout", desc.Digest)
+	}
+
+	var content struct {
+		Manifests []Descriptor `json:"manifests"`
+		Config    *Descriptor  `json:"config"`
+		Layers    []Descriptor `json:"layers"`
+	}
+
+	if err := json.Unmarshal(data, &content); err != nil {
+		return fmt.Errorf("invalid manifest %s: %s", desc.Digest, err)
+	}
+
+	for _, m := range content.Manifests {
+		if err := r.pushManifest(ctx, l, ref, m, m.Digest); err != nil {
+			return err
+		}
+	}
+
+	blobs := content.Layers
+	if content.Config != nil {
+		blobs = append([]Descriptor{*content.Config}, blobs...)
+	}
+
+	for _, b := range blobs {
+		if err := r.pushBlob(ctx, l, ref, b); err != nil {
+			return err
+		}
+	}
+
+	url := fmt.Sprintf("%s/v2/%s/manifests/%s", ref.baseURL(), ref.Repository, reference)
+	resp, err := r.do(ctx, ref, http.MethodPut, url, desc.MediaType, data)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return responseError(resp, "push manifest "+desc.Digest)
+	}
+
+	return nil
+}
+
+// pushBlob uploads a blob in a single request unless the blob already exists in the repository.
+func (r *Registry) pushBlob(ctx context.Context, l *Layout, ref Reference, desc Descriptor) error {
+	data, ok := l.Blob(desc.Digest)
+	if !ok {
+		return fmt.Errorf("blob %s not found in image layout", desc.Digest)
+	}
+
+	blobURL := fmt.Sprintf("%s/v2/%s/blobs/%s", ref.baseURL(), ref.Repository, desc.Digest)
+	resp, err := r.do(ctx, ref, http.MethodHead, blobURL, "", nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	uploadURL := fmt.Sprintf("%s/v2/%s/blobs/uploads/", ref.baseURL(), ref.Repository)
+	resp, err = r.do(ctx, ref, http.MethodPost, uploadURL, "", nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		return responseError(resp, "start upload of blob "+desc.Digest)
+	}
+
+	// The upload location can be relative to the registry
+	base, err := url.Parse(uploadURL)
+	if err != nil {
+		return err
+	}
+
+	location, err := base.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return fmt.Errorf("invalid upload location: %s", err)
+	}
+
+	q := location.Query()
+	q.Set("digest", desc.Digest)
+	location.RawQuery = q.Encode()
+
+	resp, err = r.do(ctx, ref, http.MethodPut, location.String(), "application/octet-stream", data)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return responseError(resp, "upload blob "+desc.Digest)
+	}
+
+	return nil
+}
+
+// do sends a request to the registry and authenticates once if the registry challenges the request.
+// The body of the response is read and closed.
+func (r *Registry) do(ctx context.Context, ref Reference, method, url, contentType string, body []byte) (*http.Response, error) {
+	resp, err := r.send(ctx, method, url, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		return resp, nil
+	}
+
+	if err := r.authenticate(ctx, ref, resp.Header.Get("WWW-Authenticate")); err != nil {
+		return nil, err
+	}
+
+	return r.send(ctx, method, url, contentType, body)
+}
+
+func (r *Registry) send(ctx context.Context, method, url, contentType string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	} else if r.username != "" {
+		req.SetBasicAuth(r.username, r.password)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	// The body is kept for reporting errors
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	return resp, nil
+}
+
+// authenticate handles an authentication challenge from the registry.
+// For the Bearer scheme, a token with push access to the repository is obtained from the token service.
+func (r *Registry) authenticate(ctx context.Context, ref Reference, challenge string) error {
+	scheme, params := parseChallenge(challenge)
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if r.username == "" {
+			return fmt.Errorf("registry %s requires credentials", ref.Registry)
+		}
+		return nil
+
+	case "bearer":
+		realm, err := url.Parse(params["realm"])
+		if err != nil || params["realm"] == "" {
+			return fmt.Errorf("invalid authentication challenge from registry %s: %q", ref.Registry, challenge)
+		}
+
+		q := realm.Query()
+		if service := params["service"]; service != "" {
+			q.Set("service", service)
+		}
+		q.Set("scope", fmt.Sprintf("repository:%s:pull,push", ref.Repository))
+		realm.RawQuery = q.Encode()
+
+		// Clear the previous token, so the token request uses the basic credentials
+		r.token = ""
+		resp, err := r.send(ctx, http.MethodGet, realm.String(), "", nil)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return responseError(resp, "authenticate to registry "+ref.Registry)
+		}
+
+		var token struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}
+
+		if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+			return fmt.Errorf("invalid token from registry %s: %s", ref.Registry, err)
+		}
+
+		if r.token = token.Token; r.token == "" {
+			r.token = token.AccessToken
+		}
+
+		if r.token == "" {
+			return fmt.Errorf("no token from registry %s", ref.Registry)
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported authentication challenge from registry %s: %q", ref.Registry, challenge)
+	}
+}
+
+// parseChallenge parses a WWW-Authenticate header (e.g. Bearer realm="https://auth.docker.io/token",service="registry.docker.io").
+func parseChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := map[string]string{}
+
+	for _, param := range strings.Split(rest, ",") {
+		if key, value, ok := strings.Cut(strings.TrimSpace(param), "="); ok {
+			params[strings.ToLower(key)] = strings.Trim(value, `"`)
+		}
+	}
+
+	return scheme, params
+}
+
+func responseError(resp *http.Response, action string) error {
+	body, _ := io.ReadAll(resp.Body)
+	return fmt.Errorf("cannot %s: %s %s: %d %s", action, resp.Request.Method, resp.Request.URL.Redacted(), resp.StatusCode, strings.TrimSpace(string(body)))
+}
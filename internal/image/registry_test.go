@@ -0,0 +1,184 @@
+package image
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRegistry is an in-memory registry implementing the push endpoints of the OCI distribution API.
+type fakeRegistry struct {
+	sync.Mutex
+	token     string
+	blobs     map[string][]byte
+	manifests map[string]string
+	uploads   int
+}
+
+func newFakeRegistry(token string) *fakeRegistry {
+	return &fakeRegistry{
+		token:     token,
+		blobs:     map[string][]byte{},
+		manifests: map[string]string{},
+	}
+}
+
+func (f *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	if r.URL.Path == "/token" {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "octocat" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = io.WriteString(w, `{"token":"`+f.token+`"}`)
+		return
+	}
+
+	if f.token != "" && r.Header.Get("Authorization") != "Bearer "+f.token {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="http://`+r.Host+`/token",service="fake"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v2/octocat/app/")
+	body, _ := io.ReadAll(r.Body)
+
+	switch {
+	case r.Method == http.MethodHead && strings.HasPrefix(path, "blobs/"):
+		if _, ok := f.blobs[strings.TrimPrefix(path, "blobs/")]; ok {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+
+	case r.Method == http.MethodPost && path == "blobs/uploads/":
+		w.Header().Set("Location", "/v2/octocat/app/blobs/uploads/1?state=x")
+		w.WriteHeader(http.StatusAccepted)
+
+	case r.Method == http.MethodPut && strings.HasPrefix(path, "blobs/uploads/"):
+		f.uploads++
+		f.blobs[r.URL.Query().Get("digest")] = body
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodPut && strings.HasPrefix(path, "manifests/"):
+		f.manifests[strings.TrimPrefix(path, "manifests/")] = r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusCreated)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, "not found")
+	}
+}
+
+func TestNewRegistry(t *testing.T) {
+	r := NewRegistry("octocat", "secret")
+
+	assert.NotNil(t, r)
+	assert.NotNil(t, r.client)
+	assert.Equal(t, "octocat", r.username)
+	assert.Equal(t, "secret", r.password)
+}
+
+func TestRegistry_Push(t *testing.T) {
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "app-linux-amd64")
+	assert.NoError(t, os.WriteFile(bin, []byte("binary"), 0755))
+
+	l, err := Build([]Binary{
+		{Path: bin, Platform: Platform{OS: "linux", Architecture: "amd64"}},
+	}, Options{Name: "app"})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		token         string
+		username      string
+		password      string
+		repository    string
+		expectedError string
+	}{
+		{
+			name:          "NotFound",
+			repository:    "octocat/missing",
+			expectedError: "cannot start upload of blob",
+		},
+		{
+			name:          "Unauthorized",
+			token:         "token",
+			username:      "octocat",
+			password:      "wrong",
+			repository:    "octocat/app",
+			expectedError: "cannot authenticate to registry",
+		},
+		{
+			name:       "Anonymous",
+			repository: "octocat/app",
+		},
+		{
+			name:       "Bearer",
+			token:      "token",
+			username:   "octocat",
+			password:   "secret",
+			repository: "octocat/app",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := newFakeRegistry(tc.token)
+			ts := httptest.NewServer(fake)
+			defer ts.Close()
+
+			ref := Reference{
+				Registry:   strings.TrimPrefix(ts.URL, "http://"),
+				Repository: tc.repository,
+				Tag:        "0.1.0",
+			}
+
+			r := NewRegistry(tc.username, tc.password)
+			err := r.Push(context.Background(), l, ref)
+
+			if tc.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, MediaTypeIndex, fake.manifests["0.1.0"])
+			assert.Len(t, fake.manifests, 2)
+			assert.Len(t, fake.blobs, 3) // config, base layer, and app layer
+
+			for digest, data := range fake.blobs {
+				blob, ok := l.Blob(digest)
+				assert.True(t, ok)
+				assert.Equal(t, blob, data)
+			}
+
+			// Existing blobs are not uploaded again
+			uploads := fake.uploads
+			assert.NoError(t, r.Push(context.Background(), l, ref))
+			assert.Equal(t, uploads, fake.uploads)
+		})
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://auth.docker.io/token",service="registry.docker.io"`)
+
+	assert.Equal(t, "Bearer", scheme)
+	assert.Equal(t, map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+	}, params)
+}
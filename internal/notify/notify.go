@@ -0,0 +1,332 @@
+// Package notify provides functionality for announcing published releases.
+// Notifications are delivered to generic webhooks and Slack/Mattermost incoming webhooks.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/gardenbed/basil-cli/internal/spec"
+)
+
+const (
+	defaultAttempts = 3
+	defaultBackoff  = time.Second
+	defaultTimeout  = 30 * time.Second
+	contentTypeJSON = "application/json"
+)
+
+type (
+	// Artifact is an artifact of a release.
+	Artifact struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	}
+
+	// Release is a published release.
+	Release struct {
+		Repo       string     `json:"repo"`
+		Name       string     `json:"name"`
+		Version    string     `json:"version"`
+		Tag        string     `json:"tag"`
+		URL        string     `json:"url"`
+		Changelog  string     `json:"changelog"`
+		Prerelease bool       `json:"prerelease"`
+		Artifacts  []Artifact `json:"artifacts"`
+	}
+)
+
+// endpoint is a destination for release notifications.
+type endpoint struct {
+	url     string
+	headers map[string]string
+	payload func(Release) ([]byte, error)
+}
+
+// Notifier delivers release notifications to the endpoints configured in the spec.
+type Notifier struct {
+	client    *http.Client
+	endpoints []endpoint
+	attempts  int
+	backoff   time.Duration
+	sleep     func(context.Context, time.Duration) error
+}
+
+// New creates a new notifier for the notification endpoints.
+// Environment variables in the webhook URLs and headers are expanded.
+func New(s spec.Notifications) *Notifier {
+	n := &Notifier{
+		client: &http.Client{
+			Timeout: defaultTimeout,
+		},
+		attempts: defaultAttempts,
+		backoff:  defaultBackoff,
+		sleep:    sleep,
+	}
+
+	for _, webhook := range s.Webhooks {
+		headers := map[string]string{}
+		for key, val := range webhook.Headers {
+			headers[key] = os.ExpandEnv(val)
+		}
+
+		n.endpoints = append(n.endpoints, endpoint{
+			url:     os.ExpandEnv(webhook.URL),
+			headers: headers,
+			payload: webhookPayload(webhook.Payload),
+		})
+	}
+
+	for _, u := range s.Slack {
+		n.endpoints = append(n.endpoints, endpoint{
+			url:     os.ExpandEnv(u),
+			payload: slackPayload,
+		})
+	}
+
+	for _, u := range s.Mattermost {
+		n.endpoints = append(n.endpoints, endpoint{
+			url:     os.ExpandEnv(u),
+			payload: mattermostPayload,
+		})
+	}
+
+	return n
+}
+
+// sleep pauses for a duration or until the context is cancelled.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Endpoints returns the endpoints to be notified.
+// Only the scheme and host of the URLs are returned since incoming webhook URLs are secrets.
+func (n *Notifier) Endpoints() []string {
+	endpoints := make([]string, len(n.endpoints))
+	for i, e := range n.endpoints {
+		endpoints[i] = redact(e.url)
+	}
+
+	return endpoints
+}
+
+// Notify delivers a release notification to all endpoints concurrently.
+// It returns an error for every endpoint the notification could not be delivered to.
+func (n *Notifier) Notify(ctx context.Context, release Release) error {
+	errs := make([]error, len(n.endpoints))
+
+	var wg sync.WaitGroup
+	for i, e := range n.endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := n.deliver(ctx, e, release); err != nil {
+				errs[i] = fmt.Errorf("notification to %s failed: %w", redact(e.url), err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// deliver posts a release notification to an endpoint.
+// Network errors, server errors, and rate limiting responses are retried with an exponential backoff.
+func (n *Notifier) deliver(ctx context.Context, e endpoint, release Release) error {
+	body, err := e.payload(release)
+	if err != nil {
+		return err
+	}
+
+	backoff := n.backoff
+
+	for attempt := 1; ; attempt++ {
+		wait, err := n.post(ctx, e, body)
+		if err == nil {
+			return nil
+		}
+
+		var retryable *retryableError
+		if !errors.As(err, &retryable) || attempt >= n.attempts {
+			return err
+		}
+
+		if wait == 0 {
+			wait = backoff
+		}
+		backoff *= 2
+
+		if err := n.sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// retryableError is an error that can be resolved by retrying the request.
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+// post makes a single request to an endpoint.
+// If the endpoint asks for retrying after a while, the duration is returned.
+func (n *Notifier) post(ctx context.Context, e endpoint, body []byte) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", e.url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", contentTypeJSON)
+	for key, val := range e.headers {
+		req.Header.Set(key, val)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		// The error includes the URL
+		if ue, ok := err.(*url.Error); ok {
+			err = ue.Err
+		}
+		return 0, &retryableError{err}
+	}
+
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return 0, nil
+	}
+
+	err = fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		var wait time.Duration
+		if sec, e := strconv.Atoi(resp.Header.Get("Retry-After")); e == nil && sec > 0 {
+			wait = time.Duration(sec) * time.Second
+		}
+		return wait, &retryableError{err}
+	}
+
+	return 0, err
+}
+
+// redact removes everything except the scheme and host from a URL.
+func redact(u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil || parsed.Host == "" {
+		return "<invalid url>"
+	}
+
+	return parsed.Scheme + "://" + parsed.Host
+}
+
+// ==============================> PAYLOADS <==============================
+
+var funcs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// webhookPayload returns a function for creating the payload of a generic webhook.
+// If no template is given, the release itself is the payload.
+func webhookPayload(text string) func(Release) ([]byte, error) {
+	if text == "" {
+		return func(release Release) ([]byte, error) {
+			return json.Marshal(release)
+		}
+	}
+
+	return func(release Release) ([]byte, error) {
+		tmpl, err := template.New("payload").Funcs(funcs).Parse(text)
+		if err != nil {
+			return nil, err
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, release); err != nil {
+			return nil, err
+		}
+
+		if !json.Valid(buf.Bytes()) {
+			return nil, errors.New("webhook payload is not valid JSON")
+		}
+
+		return buf.Bytes(), nil
+	}
+}
+
+// slackPayload creates the payload of a Slack incoming webhook using the Slack mrkdwn format.
+func slackPayload(release Release) ([]byte, error) {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, ":rocket: *%s* <%s|%s> is released!", release.Repo, release.URL, release.Name)
+
+	if release.Changelog != "" {
+		fmt.Fprintf(&b, "\n\n%s", release.Changelog)
+	}
+
+	if len(release.Artifacts) > 0 {
+		b.WriteString("\n\n*Artifacts*")
+		for _, artifact := range release.Artifacts {
+			fmt.Fprintf(&b, "\n• <%s|%s>", artifact.URL, artifact.Name)
+		}
+	}
+
+	return json.Marshal(map[string]string{
+		"text": b.String(),
+	})
+}
+
+// mattermostPayload creates the payload of a Mattermost incoming webhook using Markdown.
+func mattermostPayload(release Release) ([]byte, error) {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, ":rocket: **%s** [%s](%s) is released!", release.Repo, release.Name, release.URL)
+
+	if release.Changelog != "" {
+		fmt.Fprintf(&b, "\n\n%s", release.Changelog)
+	}
+
+	if len(release.Artifacts) > 0 {
+		b.WriteString("\n\n**Artifacts**")
+		for _, artifact := range release.Artifacts {
+			fmt.Fprintf(&b, "\n- [%s](%s)", artifact.Name, artifact.URL)
+		}
+	}
+
+	return json.Marshal(map[string]string{
+		"text": b.String(),
+	})
+}
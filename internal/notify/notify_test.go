@@ -0,0 +1,295 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gardenbed/basil-cli/internal/spec"
+)
+
+var release = Release{
+	Repo:      "octocat/Hello-World",
+	Name:      "v0.1.0",
+	Version:   "0.1.0",
+	Tag:       "v0.1.0",
+	URL:       "https://github.com/octocat/Hello-World/releases/tag/v0.1.0",
+	Changelog: "## v0.1.0\n\nFixed bugs.",
+	Artifacts: []Artifact{
+		{
+			Name: "app-linux-amd64",
+			URL:  "https://github.com/octocat/Hello-World/releases/download/v0.1.0/app-linux-amd64",
+		},
+	},
+}
+
+// request is a request received by the test server.
+type request struct {
+	Header http.Header
+	Body   string
+}
+
+// server is a local HTTP server responding with a sequence of status codes.
+type server struct {
+	*httptest.Server
+	sync.Mutex
+	statuses []int
+	requests []request
+}
+
+func newServer(statuses ...int) *server {
+	s := &server{
+		statuses: statuses,
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		s.Lock()
+		defer s.Unlock()
+
+		s.requests = append(s.requests, request{
+			Header: r.Header,
+			Body:   string(body),
+		})
+
+		status := http.StatusOK
+		if len(s.statuses) > 0 {
+			status, s.statuses = s.statuses[0], s.statuses[1:]
+		}
+
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "5")
+		}
+
+		w.WriteHeader(status)
+	}))
+
+	return s
+}
+
+func TestNew(t *testing.T) {
+	t.Setenv("WEBHOOK_TOKEN", "secret")
+	t.Setenv("SLACK_WEBHOOK", "https://hooks.slack.com/services/T000/B000/XXXX")
+
+	n := New(spec.Notifications{
+		Webhooks: []spec.Webhook{
+			{
+				URL: "https://hooks.example.com/releases",
+				Headers: map[string]string{
+					"Authorization": "Bearer ${WEBHOOK_TOKEN}",
+				},
+			},
+		},
+		Slack:      []string{"${SLACK_WEBHOOK}"},
+		Mattermost: []string{"https://chat.example.com/hooks/xxxx"},
+	})
+
+	assert.NotNil(t, n)
+	assert.NotNil(t, n.client)
+	assert.Equal(t, defaultAttempts, n.attempts)
+	assert.Equal(t, defaultBackoff, n.backoff)
+	assert.Len(t, n.endpoints, 3)
+	assert.Equal(t, "https://hooks.example.com/releases", n.endpoints[0].url)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer secret"}, n.endpoints[0].headers)
+	assert.Equal(t, "https://hooks.slack.com/services/T000/B000/XXXX", n.endpoints[1].url)
+	assert.Equal(t, "https://chat.example.com/hooks/xxxx", n.endpoints[2].url)
+}
+
+func TestNotifier_Endpoints(t *testing.T) {
+	n := New(spec.Notifications{
+		Webhooks: []spec.Webhook{
+			{URL: "https://hooks.example.com/releases?token=secret"},
+		},
+		Slack:      []string{"https://hooks.slack.com/services/T000/B000/XXXX"},
+		Mattermost: []string{"invalid"},
+	})
+
+	assert.Equal(t, []string{
+		"https://hooks.example.com",
+		"https://hooks.slack.com",
+		"<invalid url>",
+	}, n.Endpoints())
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	tests := []struct {
+		name             string
+		statuses         []int
+		webhook          spec.Webhook
+		expectedError    string
+		expectedRequests int
+		expectedSleeps   []time.Duration
+		expectedBody     string
+		expectedHeader   string
+	}{
+		{
+			name:             "InvalidTemplate",
+			webhook:          spec.Webhook{Payload: `{{ .Version`},
+			expectedError:    "template: payload:1: unclosed action",
+			expectedRequests: 0,
+		},
+		{
+			name:             "InvalidJSON",
+			webhook:          spec.Webhook{Payload: `{"version": {{ .Version }}}`},
+			expectedError:    "webhook payload is not valid JSON",
+			expectedRequests: 0,
+		},
+		{
+			name:             "ClientError",
+			statuses:         []int{http.StatusBadRequest},
+			webhook:          spec.Webhook{},
+			expectedError:    "unexpected status code: 400",
+			expectedRequests: 1,
+		},
+		{
+			name:             "ServerErrorRetriesExhausted",
+			statuses:         []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable},
+			webhook:          spec.Webhook{},
+			expectedError:    "unexpected status code: 503",
+			expectedRequests: 3,
+			expectedSleeps:   []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			name:             "RetryAfter",
+			statuses:         []int{http.StatusTooManyRequests},
+			webhook:          spec.Webhook{},
+			expectedRequests: 2,
+			expectedSleeps:   []time.Duration{5 * time.Second},
+		},
+		{
+			name:     "DefaultPayload",
+			statuses: []int{http.StatusInternalServerError},
+			webhook: spec.Webhook{
+				Headers: map[string]string{"X-Token": "secret"},
+			},
+			expectedRequests: 2,
+			expectedSleeps:   []time.Duration{time.Second},
+			expectedBody:     `{"repo":"octocat/Hello-World","name":"v0.1.0","version":"0.1.0","tag":"v0.1.0","url":"https://github.com/octocat/Hello-World/releases/tag/v0.1.0","changelog":"## v0.1.0\n\nFixed bugs.","prerelease":false,"artifacts":[{"name":"app-linux-amd64","url":"https://github.com/octocat/Hello-World/releases/download/v0.1.0/app-linux-amd64"}]}`,
+			expectedHeader:   "secret",
+		},
+		{
+			name: "TemplatePayload",
+			webhook: spec.Webhook{
+				Payload: `{"version": {{ json .Version }}, "artifacts": [{{ range $i, $a := .Artifacts }}{{ if $i }}, {{ end }}{{ json $a.Name }}{{ end }}]}`,
+			},
+			expectedRequests: 1,
+			expectedBody:     `{"version": "0.1.0", "artifacts": ["app-linux-amd64"]}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newServer(tc.statuses...)
+			defer s.Close()
+
+			tc.webhook.URL = s.URL
+			n := New(spec.Notifications{
+				Webhooks: []spec.Webhook{tc.webhook},
+			})
+
+			var sleeps []time.Duration
+			n.sleep = func(_ context.Context, d time.Duration) error {
+				sleeps = append(sleeps, d)
+				return nil
+			}
+
+			err := n.Notify(context.Background(), release)
+
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				assert.ErrorContains(t, err, "notification to "+s.URL+" failed")
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Len(t, s.requests, tc.expectedRequests)
+			assert.Equal(t, tc.expectedSleeps, sleeps)
+
+			if tc.expectedBody != "" {
+				last := s.requests[len(s.requests)-1]
+				assert.Equal(t, tc.expectedBody, last.Body)
+				assert.Equal(t, "application/json", last.Header.Get("Content-Type"))
+				assert.Equal(t, tc.expectedHeader, last.Header.Get("X-Token"))
+			}
+		})
+	}
+}
+
+func TestNotifier_Notify_ChatEndpoints(t *testing.T) {
+	slack := newServer()
+	defer slack.Close()
+
+	mattermost := newServer()
+	defer mattermost.Close()
+
+	n := New(spec.Notifications{
+		Slack:      []string{slack.URL},
+		Mattermost: []string{mattermost.URL},
+	})
+
+	err := n.Notify(context.Background(), release)
+	assert.NoError(t, err)
+
+	var payload map[string]string
+
+	assert.Len(t, slack.requests, 1)
+	assert.NoError(t, json.Unmarshal([]byte(slack.requests[0].Body), &payload))
+	assert.Equal(t, ":rocket: *octocat/Hello-World* <https://github.com/octocat/Hello-World/releases/tag/v0.1.0|v0.1.0> is released!\n\n## v0.1.0\n\nFixed bugs.\n\n*Artifacts*\n• <https://github.com/octocat/Hello-World/releases/download/v0.1.0/app-linux-amd64|app-linux-amd64>", payload["text"])
+
+	assert.Len(t, mattermost.requests, 1)
+	assert.NoError(t, json.Unmarshal([]byte(mattermost.requests[0].Body), &payload))
+	assert.Equal(t, ":rocket: **octocat/Hello-World** [v0.1.0](https://github.com/octocat/Hello-World/releases/tag/v0.1.0) is released!\n\n## v0.1.0\n\nFixed bugs.\n\n**Artifacts**\n- [app-linux-amd64](https://github.com/octocat/Hello-World/releases/download/v0.1.0/app-linux-amd64)", payload["text"])
+}
+
+func TestNotifier_Notify_PartialFailure(t *testing.T) {
+	ok := newServer()
+	defer ok.Close()
+
+	failed := newServer(http.StatusNotFound)
+	defer failed.Close()
+
+	n := New(spec.Notifications{
+		Slack:      []string{ok.URL},
+		Mattermost: []string{failed.URL},
+	})
+
+	err := n.Notify(context.Background(), release)
+
+	assert.EqualError(t, err, "notification to "+failed.URL+" failed: unexpected status code: 404")
+	assert.Len(t, ok.requests, 1)
+	assert.Len(t, failed.requests, 1)
+}
+
+func TestNotifier_Notify_NetworkError(t *testing.T) {
+	s := newServer()
+	s.Close()
+
+	n := New(spec.Notifications{
+		Slack: []string{s.URL},
+	})
+
+	var sleeps int
+	n.sleep = func(context.Context, time.Duration) error {
+		sleeps++
+		return nil
+	}
+
+	err := n.Notify(context.Background(), release)
+
+	assert.ErrorContains(t, err, "notification to "+s.URL+" failed")
+	assert.Equal(t, defaultAttempts-1, sleeps)
+}
+
+func TestNotifier_Notify_NoEndpoint(t *testing.T) {
+	n := New(spec.Notifications{})
+	err := n.Notify(context.Background(), release)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,70 @@
+package semver
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DefaultBumps is the default mapping of Conventional Commit types to bumps.
+// A breaking change always results in a major bump regardless of its type.
+var DefaultBumps = map[string]Bump{
+	"feat": Minor,
+	"fix":  Patch,
+	"perf": Patch,
+}
+
+// ParseBump parses a bump name (patch, minor, or major).
+func ParseBump(bump string) (Bump, bool) {
+	switch strings.ToLower(bump) {
+	case "patch":
+		return Patch, true
+	case "minor":
+		return Minor, true
+	case "major":
+		return Major, true
+	default:
+		return Patch, false
+	}
+}
+
+var (
+	conventionalRE = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^()\n]*)\))?(!)?: (\S.*)$`)
+	breakingRE     = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE: `)
+)
+
+// ConventionalCommit is a commit message following the Conventional Commits specification.
+// For more information about Conventional Commits, visit https://www.conventionalcommits.org.
+type ConventionalCommit struct {
+	Type        string
+	Scope       string
+	Description string
+	Breaking    bool
+}
+
+// ParseConventionalCommit parses a commit message following the Conventional Commits specification.
+func ParseConventionalCommit(message string) (ConventionalCommit, bool) {
+	header, body, _ := strings.Cut(strings.TrimSpace(message), "\n")
+
+	m := conventionalRE.FindStringSubmatch(strings.TrimSpace(header))
+	if m == nil {
+		return ConventionalCommit{}, false
+	}
+
+	return ConventionalCommit{
+		Type:        strings.ToLower(m[1]),
+		Scope:       m[2],
+		Description: m[4],
+		Breaking:    m[3] == "!" || breakingRE.MatchString(body),
+	}, true
+}
+
+// Bump returns the bump for a Conventional Commit using a mapping of commit types to bumps.
+// If the commit type does not require a release, it returns false.
+func (c ConventionalCommit) Bump(bumps map[string]Bump) (Bump, bool) {
+	if c.Breaking {
+		return Major, true
+	}
+
+	bump, ok := bumps[c.Type]
+	return bump, ok
+}
@@ -0,0 +1,153 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBump(t *testing.T) {
+	tests := []struct {
+		bump         string
+		expectedBump Bump
+		expectedOK   bool
+	}{
+		{"patch", Patch, true},
+		{"minor", Minor, true},
+		{"MAJOR", Major, true},
+		{"none", Patch, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.bump, func(t *testing.T) {
+			bump, ok := ParseBump(tc.bump)
+
+			assert.Equal(t, tc.expectedBump, bump)
+			assert.Equal(t, tc.expectedOK, ok)
+		})
+	}
+}
+
+func TestParseConventionalCommit(t *testing.T) {
+	tests := []struct {
+		name           string
+		message        string
+		expectedCommit ConventionalCommit
+		expectedOK     bool
+	}{
+		{
+			name:       "NotConventional",
+			message:    "Update README",
+			expectedOK: false,
+		},
+		{
+			name:       "MissingDescription",
+			message:    "fix: ",
+			expectedOK: false,
+		},
+		{
+			name:    "Feature",
+			message: "feat: add the -auto flag",
+			expectedCommit: ConventionalCommit{
+				Type:        "feat",
+				Description: "add the -auto flag",
+			},
+			expectedOK: true,
+		},
+		{
+			name:    "FixWithScope",
+			message: "Fix(release): handle pagination\n\nMore details.",
+			expectedCommit: ConventionalCommit{
+				Type:        "fix",
+				Scope:       "release",
+				Description: "handle pagination",
+			},
+			expectedOK: true,
+		},
+		{
+			name:    "BreakingWithBang",
+			message: "refactor(spec)!: rename the build section",
+			expectedCommit: ConventionalCommit{
+				Type:        "refactor",
+				Scope:       "spec",
+				Description: "rename the build section",
+				Breaking:    true,
+			},
+			expectedOK: true,
+		},
+		{
+			name:    "BreakingWithFooter",
+			message: "feat: new config format\n\nBREAKING CHANGE: the old format is not supported.",
+			expectedCommit: ConventionalCommit{
+				Type:        "feat",
+				Description: "new config format",
+				Breaking:    true,
+			},
+			expectedOK: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			commit, ok := ParseConventionalCommit(tc.message)
+
+			assert.Equal(t, tc.expectedCommit, commit)
+			assert.Equal(t, tc.expectedOK, ok)
+		})
+	}
+}
+
+func TestConventionalCommit_Bump(t *testing.T) {
+	tests := []struct {
+		name         string
+		commit       ConventionalCommit
+		bumps        map[string]Bump
+		expectedBump Bump
+		expectedOK   bool
+	}{
+		{
+			name:         "Feature",
+			commit:       ConventionalCommit{Type: "feat"},
+			bumps:        DefaultBumps,
+			expectedBump: Minor,
+			expectedOK:   true,
+		},
+		{
+			name:         "Fix",
+			commit:       ConventionalCommit{Type: "fix"},
+			bumps:        DefaultBumps,
+			expectedBump: Patch,
+			expectedOK:   true,
+		},
+		{
+			name:         "NoRelease",
+			commit:       ConventionalCommit{Type: "docs"},
+			bumps:        DefaultBumps,
+			expectedBump: Patch,
+			expectedOK:   false,
+		},
+		{
+			name:         "Breaking",
+			commit:       ConventionalCommit{Type: "docs", Breaking: true},
+			bumps:        DefaultBumps,
+			expectedBump: Major,
+			expectedOK:   true,
+		},
+		{
+			name:         "CustomMapping",
+			commit:       ConventionalCommit{Type: "docs"},
+			bumps:        map[string]Bump{"docs": Minor},
+			expectedBump: Minor,
+			expectedOK:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			bump, ok := tc.commit.Bump(tc.bumps)
+
+			assert.Equal(t, tc.expectedBump, bump)
+			assert.Equal(t, tc.expectedOK, ok)
+		})
+	}
+}
@@ -9,6 +9,37 @@ import (
 	"strings"
 )
 
+// Bump is the part of a semantic version that is increased for a release.
+type Bump int
+
+const (
+	// Patch is a patch release.
+	Patch Bump = iota
+	// Minor is a minor release.
+	Minor
+	// Major is a major release.
+	Major
+)
+
+// String returns the string representation of a bump.
+func (b Bump) String() string {
+	switch b {
+	case Minor:
+		return "minor"
+	case Major:
+		return "major"
+	default:
+		return "patch"
+	}
+}
+
+var channelRE = regexp.MustCompile(`^[A-Za-z][0-9A-Za-z-]*$`)
+
+// IsChannel determines whether or not a string is a valid pre-release channel name (alpha, beta, rc, etc.).
+func IsChannel(channel string) bool {
+	return channelRE.MatchString(channel)
+}
+
 // SemVer represents a semantic version.
 type SemVer struct {
 	Major      uint
@@ -89,6 +120,80 @@ func (v SemVer) ReleaseMajor() SemVer {
 	}
 }
 
+// Release creates a new semantic version for a release.
+// If channel is not empty, the new version is a pre-release on the channel (e.g. 1.3.0-rc.1).
+//
+// If the current version is already a pre-release on a channel (e.g. 1.3.0-rc.1 or 1.3.0-rc.1.2.abcdef0),
+// the version being pre-released is not released yet, so it is only increased if the bump requires so.
+// A pre-release on the same channel for the same version increases the pre-release number (e.g. 1.3.0-rc.2),
+// and a release without a channel promotes the pre-release (e.g. 1.3.0).
+func (v SemVer) Release(bump Bump, channel string) SemVer {
+	var release SemVer
+	currChannel, currNumber := v.Channel()
+
+	if currChannel == "" {
+		switch bump {
+		case Major:
+			release = v.ReleaseMajor()
+		case Minor:
+			release = v.ReleaseMinor()
+		default:
+			release = v.ReleasePatch()
+		}
+	} else {
+		release = v.ReleasePatch()
+		switch {
+		case bump == Major && (release.Minor != 0 || release.Patch != 0):
+			release = release.ReleaseMajor()
+		case bump == Minor && release.Patch != 0:
+			release = release.ReleaseMinor()
+		}
+	}
+
+	if channel == "" {
+		return release
+	}
+
+	number := uint(1)
+	if channel == currChannel && release.Core().String() == v.Core().String() {
+		number = currNumber + 1
+	}
+
+	release.Prerelease = []string{channel, strconv.FormatUint(uint64(number), 10)}
+
+	return release
+}
+
+// Core returns the major, minor, and patch parts of the current semantic version without pre-release and metadata.
+func (v SemVer) Core() SemVer {
+	return SemVer{
+		Major: v.Major,
+		Minor: v.Minor,
+		Patch: v.Patch,
+	}
+}
+
+// IsPrerelease determines whether or not the current semantic version is a pre-release version.
+func (v SemVer) IsPrerelease() bool {
+	return len(v.Prerelease) > 0
+}
+
+// Channel returns the pre-release channel and number of the current semantic version.
+// For example, the channel and number for 1.3.0-rc.2 are rc and 2.
+// If the current semantic version is not a pre-release on a channel, the channel is empty.
+func (v SemVer) Channel() (string, uint) {
+	if len(v.Prerelease) == 0 || !IsChannel(v.Prerelease[0]) {
+		return "", 0
+	}
+
+	var number uint64
+	if len(v.Prerelease) > 1 {
+		number, _ = strconv.ParseUint(v.Prerelease[1], 10, 64)
+	}
+
+	return v.Prerelease[0], uint(number)
+}
+
 // String returns the string representation of the current semantic version.
 func (v SemVer) String() string {
 	var tail string
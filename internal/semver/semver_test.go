@@ -275,6 +275,89 @@ func TestSemVer_ReleaseMajor(t *testing.T) {
 	}
 }
 
+func TestBump_String(t *testing.T) {
+	assert.Equal(t, "patch", Patch.String())
+	assert.Equal(t, "minor", Minor.String())
+	assert.Equal(t, "major", Major.String())
+}
+
+func TestIsChannel(t *testing.T) {
+	assert.True(t, IsChannel("alpha"))
+	assert.True(t, IsChannel("rc"))
+	assert.True(t, IsChannel("beta-2"))
+	assert.False(t, IsChannel(""))
+	assert.False(t, IsChannel("1"))
+	assert.False(t, IsChannel("rc.1"))
+}
+
+func TestSemVer_Release(t *testing.T) {
+	tests := []struct {
+		name            string
+		semver          string
+		bump            Bump
+		channel         string
+		expectedRelease string
+	}{
+		{"InitialPatch", "0.1.0-5.abcdef0", Patch, "", "0.1.0"},
+		{"Patch", "1.2.4-3.abcdef0", Patch, "", "1.2.4"},
+		{"Minor", "1.2.4-3.abcdef0", Minor, "", "1.3.0"},
+		{"Major", "1.2.4-3.abcdef0", Major, "", "2.0.0"},
+		{"FirstPrerelease", "1.2.4-3.abcdef0", Minor, "rc", "1.3.0-rc.1"},
+		{"NextPrerelease", "1.3.0-rc.1.2.abcdef0", Minor, "rc", "1.3.0-rc.2"},
+		{"NextPrereleaseWithPatch", "1.3.0-rc.1.2.abcdef0", Patch, "rc", "1.3.0-rc.2"},
+		{"NextPrereleaseOnTag", "1.3.0-rc.2", Patch, "rc", "1.3.0-rc.3"},
+		{"NextChannel", "1.3.0-alpha.3.2.abcdef0", Minor, "beta", "1.3.0-beta.1"},
+		{"PrereleaseWithLargerBump", "1.3.0-rc.1.2.abcdef0", Major, "rc", "2.0.0-rc.1"},
+		{"PatchPrereleaseWithMinorBump", "1.2.5-rc.1.2.abcdef0", Minor, "rc", "1.3.0-rc.1"},
+		{"PromotePatch", "1.3.0-rc.2.1.abcdef0", Patch, "", "1.3.0"},
+		{"PromoteMinor", "1.3.0-rc.2.1.abcdef0", Minor, "", "1.3.0"},
+		{"PromoteMajor", "2.0.0-rc.2.1.abcdef0", Major, "", "2.0.0"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sv, ok := Parse(tc.semver)
+			assert.True(t, ok)
+
+			release := sv.Release(tc.bump, tc.channel)
+
+			assert.Equal(t, tc.expectedRelease, release.String())
+		})
+	}
+}
+
+func TestSemVer_Core(t *testing.T) {
+	sv := SemVer{Major: 1, Minor: 2, Patch: 3, Prerelease: []string{"rc", "1"}, Metadata: []string{"20201020"}}
+
+	assert.Equal(t, SemVer{Major: 1, Minor: 2, Patch: 3}, sv.Core())
+}
+
+func TestSemVer_IsPrerelease(t *testing.T) {
+	assert.False(t, SemVer{Major: 1}.IsPrerelease())
+	assert.True(t, SemVer{Major: 1, Prerelease: []string{"rc", "1"}}.IsPrerelease())
+}
+
+func TestSemVer_Channel(t *testing.T) {
+	tests := []struct {
+		semver          SemVer
+		expectedChannel string
+		expectedNumber  uint
+	}{
+		{SemVer{Major: 1}, "", 0},
+		{SemVer{Major: 1, Prerelease: []string{"5", "abcdef0"}}, "", 0},
+		{SemVer{Major: 1, Prerelease: []string{"rc"}}, "rc", 0},
+		{SemVer{Major: 1, Prerelease: []string{"rc", "2"}}, "rc", 2},
+		{SemVer{Major: 1, Prerelease: []string{"beta", "1", "3", "abcdef0"}}, "beta", 1},
+	}
+
+	for _, tc := range tests {
+		channel, number := tc.semver.Channel()
+
+		assert.Equal(t, tc.expectedChannel, channel)
+		assert.Equal(t, tc.expectedNumber, number)
+	}
+}
+
 func TestSemVer_String(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -2,8 +2,10 @@
 package spec
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -20,6 +22,12 @@ var (
 	}
 )
 
+// DefaultPlatforms returns the default platforms for cross compilation.
+// Not every Go toolchain supports all of them (e.g. windows-arm is removed from recent Go versions).
+func DefaultPlatforms() []string {
+	return append([]string{}, defaultPlatforms...)
+}
+
 // Spec is the model for all specifications.
 type Spec struct {
 	Version string  `json:"version" yaml:"version"`
@@ -62,6 +70,134 @@ func Read() (Spec, error) {
 	return spec, nil
 }
 
+// SetDockerID sets the Docker ID (project.docker_id) in the spec file of a project directory.
+// The rest of a YAML spec file (including comments) is kept as is.
+// If no spec file is found, a basil.yaml file is created.
+func SetDockerID(dir, dockerID string) error {
+	for _, specFile := range specFiles {
+		path := filepath.Join(dir, specFile)
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+
+		if filepath.Ext(specFile) == ".json" {
+			data, err = setJSONValue(data, []string{"project", "dockerId"}, dockerID)
+		} else {
+			data, err = setYAMLValue(data, []string{"project", "docker_id"}, dockerID)
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s: %s", specFile, err)
+		}
+
+		return os.WriteFile(path, data, 0644)
+	}
+
+	data, err := setYAMLValue(nil, []string{"project", "docker_id"}, dockerID)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, "basil.yaml"), data, 0644)
+}
+
+// setYAMLValue sets a string value for a nested key in a YAML document.
+func setYAMLValue(data []byte, keys []string, value string) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	// An empty document
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.MappingNode}},
+		}
+	}
+
+	node := doc.Content[0]
+	if node.Kind != yaml.MappingNode {
+		return nil, errors.New("the document is not a mapping")
+	}
+
+	for i, key := range keys {
+		if node.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("%s is not a mapping", strings.Join(keys[:i], "."))
+		}
+
+		var child *yaml.Node
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			if node.Content[j].Value == key {
+				child = node.Content[j+1]
+				break
+			}
+		}
+
+		if child == nil {
+			child = &yaml.Node{Kind: yaml.MappingNode}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, child)
+		}
+
+		if i == len(keys)-1 {
+			child.Kind, child.Tag, child.Value, child.Content = yaml.ScalarNode, "!!str", value, nil
+		} else if child.Kind == yaml.ScalarNode && child.Tag == "!!null" {
+			// An empty mapping (e.g. project:)
+			child.Kind, child.Tag, child.Value = yaml.MappingNode, "", ""
+		}
+
+		node = child
+	}
+
+	buf := new(bytes.Buffer)
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// setJSONValue sets a string value for a nested key in a JSON document.
+func setJSONValue(data []byte, keys []string, value string) ([]byte, error) {
+	doc := map[string]any{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	m := doc
+	for i, key := range keys[:len(keys)-1] {
+		child, ok := m[key]
+		if !ok || child == nil {
+			child = map[string]any{}
+			m[key] = child
+		}
+
+		if m, ok = child.(map[string]any); !ok {
+			return nil, fmt.Errorf("%s is not an object", strings.Join(keys[:i+1], "."))
+		}
+	}
+	m[keys[len(keys)-1]] = value
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(out, '\n'), nil
+}
+
 // WithDefaults returns a new object with default values.
 func (s Spec) WithDefaults() Spec {
 	if s.Version == "" {
@@ -76,8 +212,10 @@ func (s Spec) WithDefaults() Spec {
 // Project has the specifications for a Basil project.
 type Project struct {
 	Owner    string          `json:"owner" yaml:"owner"`
+	DockerID string          `json:"dockerId" yaml:"docker_id"`
 	Language ProjectLanguage `json:"language" yaml:"language"`
 	Profile  ProjectProfile  `json:"profile" yaml:"profile"`
+	Gates    Gates           `json:"gates" yaml:"gates"`
 	Build    Build           `json:"build" yaml:"build"`
 	Release  Release         `json:"release" yaml:"release"`
 }
@@ -126,10 +264,32 @@ func (p Project) WithDefaults() Project {
 	return p
 }
 
+// Gates has the specifications for the quality gates run before building and releasing.
+type Gates struct {
+	Vet       bool `json:"vet" yaml:"vet" flag:"vet"`
+	Test      bool `json:"test" yaml:"test" flag:"test"`
+	Race      bool `json:"race" yaml:"race" flag:"race"`
+	Tidy      bool `json:"tidy" yaml:"tidy" flag:"tidy"`
+	NoReplace bool `json:"noReplace" yaml:"no_replace" flag:"no-replace"`
+}
+
+// Enabled determines whether or not any quality gate is enabled.
+func (g Gates) Enabled() bool {
+	return g.Vet || g.Test || g.Tidy || g.NoReplace
+}
+
 // Build has the specifications for the build command.
 type Build struct {
-	CrossCompile bool     `json:"crossCompile" yaml:"cross_compile" flag:"cross-compile"`
-	Platforms    []string `json:"platforms" yaml:"platforms" flag:"platforms"`
+	CrossCompile bool              `json:"crossCompile" yaml:"cross_compile" flag:"cross-compile"`
+	Platforms    []string          `json:"platforms" yaml:"platforms" flag:"platforms"`
+	SHA512       bool              `json:"sha512" yaml:"sha512" flag:"sha512"`
+	Parallel     int               `json:"parallel" yaml:"parallel" flag:"parallel"`
+	NoCache      bool              `json:"noCache" yaml:"no_cache" flag:"no-cache"`
+	Reproducible bool              `json:"reproducible" yaml:"reproducible" flag:"reproducible"`
+	Archive      Archive           `json:"archive" yaml:"archive"`
+	Image        Image             `json:"image" yaml:"image"`
+	Targets      []Target          `json:"targets" yaml:"targets"`
+	Metadata     map[string]string `json:"metadata" yaml:"metadata"`
 }
 
 // WithDefaults returns a new object with default values.
@@ -141,9 +301,72 @@ func (b Build) WithDefaults() Build {
 	return b
 }
 
+// Target has the specifications for building a binary.
+// If no target is specified, the binaries are built by convention from the cmd directory and the main.go file of every module.
+// Module is the directory or path of the module containing the main package (default: the module in the working directory).
+type Target struct {
+	Module     string            `json:"module" yaml:"module"`
+	Main       string            `json:"main" yaml:"main"`
+	Name       string            `json:"name" yaml:"name"`
+	Platforms  []string          `json:"platforms" yaml:"platforms"`
+	Tags       []string          `json:"tags" yaml:"tags"`
+	CGOEnabled *bool             `json:"cgoEnabled" yaml:"cgo_enabled"`
+	TrimPath   bool              `json:"trimPath" yaml:"trim_path"`
+	LDFlags    string            `json:"ldFlags" yaml:"ld_flags"`
+	Env        map[string]string `json:"env" yaml:"env"`
+}
+
+// Archive has the specifications for archiving the built binaries.
+type Archive struct {
+	Enabled bool              `json:"enabled" yaml:"enabled" flag:"archive"`
+	Formats map[string]string `json:"formats" yaml:"formats"`
+	Files   []string          `json:"files" yaml:"files"`
+	Name    string            `json:"name" yaml:"name"`
+}
+
+// Image has the specifications for building container images from Linux binaries.
+// If Dockerfile is empty and a Dockerfile exists in the working directory, it is used for building the images.
+// Otherwise, a minimal distroless-style image is assembled from the binaries.
+type Image struct {
+	Enabled    bool   `json:"enabled" yaml:"enabled" flag:"image"`
+	Registry   string `json:"registry" yaml:"registry" flag:"registry"`
+	Dockerfile string `json:"dockerfile" yaml:"dockerfile"`
+	Push       bool   `json:"push" yaml:"push" flag:"push"`
+}
+
 // Release has the specifications for the release command.
 type Release struct {
-	Mode ReleaseMode `json:"mode" yaml:"mode" flag:"mode"`
+	Mode              ReleaseMode       `json:"mode" yaml:"mode" flag:"mode"`
+	Bumps             map[string]string `json:"bumps" yaml:"bumps"`
+	Sign              bool              `json:"sign" yaml:"sign" flag:"sign"`
+	SigningKey        string            `json:"signingKey" yaml:"signing_key" flag:"signing-key"`
+	MaintenanceBranch string            `json:"maintenanceBranch" yaml:"maintenance_branch" flag:"maintenance-branch"`
+	Hooks             Hooks             `json:"hooks" yaml:"hooks"`
+	Notifications     Notifications     `json:"notifications" yaml:"notifications"`
+}
+
+// Hooks has the shell commands to run at different phases of a release.
+type Hooks struct {
+	BeforeChangelog []string `json:"beforeChangelog" yaml:"before_changelog"`
+	BeforeCommit    []string `json:"beforeCommit" yaml:"before_commit"`
+	AfterTag        []string `json:"afterTag" yaml:"after_tag"`
+	AfterPublish    []string `json:"afterPublish" yaml:"after_publish"`
+}
+
+// Notifications has the endpoints to notify after a release is published.
+type Notifications struct {
+	Webhooks   []Webhook `json:"webhooks" yaml:"webhooks"`
+	Slack      []string  `json:"slack" yaml:"slack"`
+	Mattermost []string  `json:"mattermost" yaml:"mattermost"`
+}
+
+// Webhook is a generic webhook receiving a JSON payload.
+// Payload is a Go template for the JSON payload; if not specified, the release is sent as JSON.
+// Environment variables in URL and Headers are expanded, so secrets do not need to be stored in the spec file.
+type Webhook struct {
+	URL     string            `json:"url" yaml:"url"`
+	Headers map[string]string `json:"headers" yaml:"headers"`
+	Payload string            `json:"payload" yaml:"payload"`
 }
 
 // ReleaseMode is the type for the release mode.
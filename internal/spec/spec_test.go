@@ -1,12 +1,16 @@
 package spec
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestRead(t *testing.T) {
+	cgoDisabled := false
+
 	tests := []struct {
 		name          string
 		specFiles     []string
@@ -50,8 +54,16 @@ func TestRead(t *testing.T) {
 				Version: "1.0",
 				Project: Project{
 					Owner:    "my-team",
+					DockerID: "octocat",
 					Language: ProjectLanguageGo,
 					Profile:  ProjectProfileGeneric,
+					Gates: Gates{
+						Vet:       true,
+						Test:      true,
+						Race:      true,
+						Tidy:      true,
+						NoReplace: true,
+					},
 					Build: Build{
 						CrossCompile: true,
 						Platforms: []string{
@@ -59,9 +71,58 @@ func TestRead(t *testing.T) {
 							"darwin-amd64", "darwin-arm64",
 							"windows-386", "windows-amd64", "windows-arm", "windows-arm64",
 						},
+						Archive: Archive{
+							Enabled: true,
+							Formats: map[string]string{
+								"darwin": "zip",
+							},
+						},
+						Image: Image{
+							Enabled:  true,
+							Registry: "ghcr.io",
+							Push:     true,
+						},
+						Targets: []Target{
+							{
+								Main:       "./cmd/server",
+								Name:       "server",
+								Platforms:  []string{"linux-amd64", "linux-arm64"},
+								Tags:       []string{"netgo"},
+								CGOEnabled: &cgoDisabled,
+								TrimPath:   true,
+								LDFlags:    "-s -w",
+								Env: map[string]string{
+									"GOAMD64": "v3",
+								},
+							},
+						},
+						Metadata: map[string]string{
+							"RepoURL": "{{.Git.RemoteURL}}",
+							"github.com/octocat/service/internal/info.BuildNumber": "{{.Env.BUILD_NUMBER}}",
+						},
 					},
 					Release: Release{
 						Mode: ReleaseModeDirect,
+						Bumps: map[string]string{
+							"feat": "minor",
+							"fix":  "patch",
+						},
+						MaintenanceBranch: "release/*",
+						Hooks: Hooks{
+							BeforeCommit: []string{"go test ./..."},
+						},
+						Notifications: Notifications{
+							Webhooks: []Webhook{
+								{
+									URL: "https://hooks.example.com/releases",
+									Headers: map[string]string{
+										"Authorization": "Bearer ${WEBHOOK_TOKEN}",
+									},
+									Payload: `{"version": {{ json .Version }}}`,
+								},
+							},
+							Slack: []string{"https://hooks.slack.com/services/T000/B000/XXXX"},
+						},
 					},
 				},
 			},
@@ -73,8 +134,16 @@ func TestRead(t *testing.T) {
 				Version: "1.0",
 				Project: Project{
 					Owner:    "my-team",
+					DockerID: "octocat",
 					Language: ProjectLanguageGo,
 					Profile:  ProjectProfileGeneric,
+					Gates: Gates{
+						Vet:       true,
+						Test:      true,
+						Race:      true,
+						Tidy:      true,
+						NoReplace: true,
+					},
 					Build: Build{
 						CrossCompile: true,
 						Platforms: []string{
@@ -82,9 +151,58 @@ func TestRead(t *testing.T) {
 							"darwin-amd64", "darwin-arm64",
 							"windows-386", "windows-amd64", "windows-arm", "windows-arm64",
 						},
+						Archive: Archive{
+							Enabled: true,
+							Formats: map[string]string{
+								"darwin": "zip",
+							},
+						},
+						Image: Image{
+							Enabled:  true,
+							Registry: "ghcr.io",
+							Push:     true,
+						},
+						Targets: []Target{
+							{
+								Main:       "./cmd/server",
+								Name:       "server",
+								Platforms:  []string{"linux-amd64", "linux-arm64"},
+								Tags:       []string{"netgo"},
+								CGOEnabled: &cgoDisabled,
+								TrimPath:   true,
+								LDFlags:    "-s -w",
+								Env: map[string]string{
+									"GOAMD64": "v3",
+								},
+							},
+						},
+						Metadata: map[string]string{
+							"RepoURL": "{{.Git.RemoteURL}}",
+							"github.com/octocat/service/internal/info.BuildNumber": "{{.Env.BUILD_NUMBER}}",
+						},
 					},
 					Release: Release{
 						Mode: ReleaseModeDirect,
+						Bumps: map[string]string{
+							"feat": "minor",
+							"fix":  "patch",
+						},
+						MaintenanceBranch: "release/*",
+						Hooks: Hooks{
+							BeforeCommit: []string{"go test ./..."},
+						},
+						Notifications: Notifications{
+							Webhooks: []Webhook{
+								{
+									URL: "https://hooks.example.com/releases",
+									Headers: map[string]string{
+										"Authorization": "Bearer ${WEBHOOK_TOKEN}",
+									},
+									Payload: `{"version": {{ json .Version }}}`,
+								},
+							},
+							Slack: []string{"https://hooks.slack.com/services/T000/B000/XXXX"},
+						},
 					},
 				},
 			},
@@ -107,6 +225,89 @@ func TestRead(t *testing.T) {
 	}
 }
 
+func TestSetDockerID(t *testing.T) {
+	tests := []struct {
+		name          string
+		specFile      string
+		content       string
+		expectedError string
+		expectedFile  string
+		expected      string
+	}{
+		{
+			name:         "NoSpecFile",
+			expectedFile: "basil.yaml",
+			expected:     "project:\n  docker_id: octocat\n",
+		},
+		{
+			name:          "InvalidYAML",
+			specFile:      "basil.yaml",
+			content:       "- octocat\n",
+			expectedError: "basil.yaml: the document is not a mapping",
+		},
+		{
+			name:          "InvalidProject",
+			specFile:      "basil.yml",
+			content:       "project: octocat\n",
+			expectedError: "basil.yml: project is not a mapping",
+		},
+		{
+			name:         "EmptyProject",
+			specFile:     "basil.yaml",
+			content:      "version: \"1.0\"\nproject:\n",
+			expectedFile: "basil.yaml",
+			expected:     "version: \"1.0\"\nproject:\n  docker_id: octocat\n",
+		},
+		{
+			name:         "YAML",
+			specFile:     "basil.yml",
+			content:      "# Basil spec\nversion: \"1.0\"\nproject:\n  owner: my-team # the owning team\n  docker_id: orca\n",
+			expectedFile: "basil.yml",
+			expected:     "# Basil spec\nversion: \"1.0\"\nproject:\n  owner: my-team # the owning team\n  docker_id: octocat\n",
+		},
+		{
+			name:          "InvalidJSON",
+			specFile:      "basil.json",
+			content:       `{ "project": "octocat" }`,
+			expectedError: "basil.json: project is not an object",
+		},
+		{
+			name:         "JSON",
+			specFile:     "basil.json",
+			content:      `{ "version": "1.0", "project": { "owner": "my-team" } }`,
+			expectedFile: "basil.json",
+			expected:     "{\n  \"project\": {\n    \"dockerId\": \"octocat\",\n    \"owner\": \"my-team\"\n  },\n  \"version\": \"1.0\"\n}\n",
+		},
+	}
+
+	defer func(files []string) {
+		specFiles = files
+	}(specFiles)
+
+	specFiles = []string{"basil.yml", "basil.yaml", "basil.json"}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			if tc.specFile != "" {
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, tc.specFile), []byte(tc.content), 0644))
+			}
+
+			err := SetDockerID(dir, "octocat")
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				data, err := os.ReadFile(filepath.Join(dir, tc.expectedFile))
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, string(data))
+			}
+		})
+	}
+}
+
 func TestSpec_WithDefaults(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -228,6 +429,26 @@ func TestProject_WithDefaults(t *testing.T) {
 	}
 }
 
+func TestGates_Enabled(t *testing.T) {
+	assert.False(t, Gates{}.Enabled())
+	assert.False(t, Gates{Race: true}.Enabled())
+	assert.True(t, Gates{Vet: true}.Enabled())
+	assert.True(t, Gates{Test: true, Race: true}.Enabled())
+	assert.True(t, Gates{Tidy: true}.Enabled())
+	assert.True(t, Gates{NoReplace: true}.Enabled())
+}
+
+func TestDefaultPlatforms(t *testing.T) {
+	platforms := DefaultPlatforms()
+
+	assert.Equal(t, defaultPlatforms, platforms)
+	assert.Contains(t, platforms, "windows-arm")
+
+	// The default platforms are not modified through the returned slice
+	platforms[0] = "plan9-amd64"
+	assert.Equal(t, "linux-386", defaultPlatforms[0])
+}
+
 func TestBuild_WithDefaults(t *testing.T) {
 	tests := []struct {
 		name          string
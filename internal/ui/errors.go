@@ -0,0 +1,25 @@
+package ui
+
+import "github.com/gardenbed/charm/ui"
+
+type errorsUI struct {
+	UI
+}
+
+// NewErrorsOnly creates a user interface that only reports errors through another user interface.
+// It is used when the standard output is reserved for a machine-readable output (e.g. JSON).
+func NewErrorsOnly(u UI) UI {
+	return &errorsUI{
+		UI: u,
+	}
+}
+
+func (u *errorsUI) Printf(string, ...interface{}) {}
+
+func (u *errorsUI) Tracef(ui.Style, string, ...interface{}) {}
+
+func (u *errorsUI) Debugf(ui.Style, string, ...interface{}) {}
+
+func (u *errorsUI) Infof(ui.Style, string, ...interface{}) {}
+
+func (u *errorsUI) Warnf(ui.Style, string, ...interface{}) {}
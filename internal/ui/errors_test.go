@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/gardenbed/charm/ui"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordUI struct {
+	UI
+	messages []string
+}
+
+func (u *recordUI) Errorf(_ ui.Style, format string, a ...interface{}) {
+	u.messages = append(u.messages, format)
+}
+
+func TestNewErrorsOnly(t *testing.T) {
+	r := &recordUI{UI: NewNop()}
+	u := NewErrorsOnly(r)
+
+	u.Printf("print")
+	u.Tracef(Green, "trace")
+	u.Debugf(Green, "debug")
+	u.Infof(Green, "info")
+	u.Warnf(Yellow, "warn")
+	u.Errorf(Red, "error")
+
+	assert.Equal(t, []string{"error"}, r.messages)
+}